@@ -20,11 +20,29 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// RouteAnnouncementConfig configures communities for a class of routes.
+// RouteAnnouncementConfig configures communities, AS path prepending and
+// preference for a class of routes.
 type RouteAnnouncementConfig struct {
-	// Communities lists BGP community strings to attach to these routes.
+	// Communities lists BGP community strings to attach to these routes:
+	// standard ("65000:100" or a well-known name like "no-export"), large
+	// ("4200000000:1:2") or extended ("rt 65000:100", "soo 65000:100")
+	// communities.
 	// +optional
 	Communities []string `json:"communities,omitempty"`
+
+	// ASPathPrepend prepends AS numbers to the AS path of these routes.
+	// +optional
+	ASPathPrepend *ASPathPrepend `json:"asPathPrepend,omitempty"`
+
+	// LocalPreference is set on these routes, the fabric prefers routes with a
+	// higher local preference (e.g. 200 on the primary, 50 on the backup).
+	// +optional
+	LocalPreference *uint32 `json:"localPreference,omitempty"`
+
+	// MED is the multi-exit discriminator set on these routes, neighboring
+	// ASes prefer routes with a lower MED.
+	// +optional
+	MED *uint32 `json:"med,omitempty"`
 }
 
 // AggregateConfig controls aggregate (covering prefix) route export behavior.
@@ -36,10 +54,23 @@ type AggregateConfig struct {
 	// +kubebuilder:default=true
 	Enabled *bool `json:"enabled,omitempty"`
 
-	// Communities attached to the aggregate route.
+	// Communities attached to the aggregate route, in the same formats as
+	// RouteAnnouncementConfig communities.
 	// +optional
 	Communities []string `json:"communities,omitempty"`
 
+	// ASPathPrepend prepends AS numbers to the AS path of the aggregate route.
+	// +optional
+	ASPathPrepend *ASPathPrepend `json:"asPathPrepend,omitempty"`
+
+	// LocalPreference is set on the aggregate route.
+	// +optional
+	LocalPreference *uint32 `json:"localPreference,omitempty"`
+
+	// MED is the multi-exit discriminator set on the aggregate route.
+	// +optional
+	MED *uint32 `json:"med,omitempty"`
+
 	// PrefixLengthV4 overrides the auto-computed IPv4 aggregate size.
 	// Must be between the Network CIDR prefix length and 32.
 	// If omitted, controller auto-computes the smallest covering prefix.
@@ -59,8 +90,9 @@ type AggregateConfig struct {
 
 // AnnouncementPolicySpec defines the desired state of AnnouncementPolicy.
 // Host routes (/32, /128) are always exported — the DC fabric needs them as
-// more-specifics. This policy controls communities on host routes and whether
-// to also export an aggregate covering prefix.
+// more-specifics. This policy controls communities, AS path prepending and
+// preference of host routes and whether to also export an aggregate covering
+// prefix.
 type AnnouncementPolicySpec struct {
 	// VRFRef is the VRF this policy governs exports into. Required.
 	// +kubebuilder:validation:Required
@@ -74,7 +106,8 @@ type AnnouncementPolicySpec struct {
 	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 
 	// HostRoutes configures communities for host routes (/32, /128).
-	// Host routes are always exported; this controls their community tags.
+	// Host routes are always exported; this controls their community tags,
+	// AS path prepending and preference.
 	// +optional
 	HostRoutes *RouteAnnouncementConfig `json:"hostRoutes,omitempty"`
 
@@ -75,10 +75,33 @@ type BGPPeeringRef struct {
 // It has no effect for loopbackPeer mode and is ignored there.
 type BGPPeeringExport struct {
 	// Communities lists BGP community strings attached (additively) to the
-	// prefixes re-exported into the EVPN fabric. Follows the same free-form
-	// convention as AnnouncementPolicy communities (e.g. "65000:100").
+	// prefixes re-exported into the EVPN fabric. Follows the same convention
+	// as AnnouncementPolicy communities: standard ("65000:100"), large
+	// ("4200000000:1:2") or extended ("rt 65000:100") communities.
 	// +optional
 	Communities []string `json:"communities,omitempty"`
+
+	// ASPathMatch restricts the re-exported prefixes to the ones whose AS
+	// path matches this regular expression (FRR syntax, e.g. "^65010_").
+	// Only AS numbers and the FRR as-path regex operators are allowed.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[-0-9_^$.*+?()\[\]{}|,]+$`
+	ASPathMatch string `json:"asPathMatch,omitempty"`
+
+	// ASPathPrepend prepends AS numbers to the AS path of the re-exported
+	// prefixes.
+	// +optional
+	ASPathPrepend *ASPathPrepend `json:"asPathPrepend,omitempty"`
+
+	// LocalPreference is set on the re-exported prefixes, the fabric prefers
+	// routes with a higher local preference.
+	// +optional
+	LocalPreference *uint32 `json:"localPreference,omitempty"`
+
+	// MED is the multi-exit discriminator set on the re-exported prefixes.
+	// +optional
+	MED *uint32 `json:"med,omitempty"`
 }
 
 // BGPPeeringSpec defines the desired state of BGPPeering.
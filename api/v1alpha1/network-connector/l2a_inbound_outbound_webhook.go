@@ -52,6 +52,9 @@ func (*Layer2Attachment) ValidateCreate(_ context.Context, r *Layer2Attachment)
 
 func (*Layer2Attachment) ValidateUpdate(_ context.Context, old, r *Layer2Attachment) (admission.Warnings, error) {
 	l2alog.Info("validate update", "name", r.Name)
+	if err := validateDryRunUpdate(old, r); err != nil {
+		return nil, err
+	}
 	if err := r.validateLayer2Attachment(); err != nil {
 		return nil, err
 	}
@@ -96,8 +99,11 @@ func (*Inbound) ValidateCreate(_ context.Context, r *Inbound) (admission.Warning
 	return nil, r.validateInbound()
 }
 
-func (*Inbound) ValidateUpdate(_ context.Context, _, r *Inbound) (admission.Warnings, error) {
+func (*Inbound) ValidateUpdate(_ context.Context, old, r *Inbound) (admission.Warnings, error) {
 	inboundlog.Info("validate update", "name", r.Name)
+	if err := validateDryRunUpdate(old, r); err != nil {
+		return nil, err
+	}
 	return nil, r.validateInbound()
 }
 
@@ -141,8 +147,11 @@ func (*Outbound) ValidateCreate(_ context.Context, r *Outbound) (admission.Warni
 	return nil, r.validateOutbound()
 }
 
-func (*Outbound) ValidateUpdate(_ context.Context, _, r *Outbound) (admission.Warnings, error) {
+func (*Outbound) ValidateUpdate(_ context.Context, old, r *Outbound) (admission.Warnings, error) {
 	outboundlog.Info("validate update", "name", r.Name)
+	if err := validateDryRunUpdate(old, r); err != nil {
+		return nil, err
+	}
 	return nil, r.validateOutbound()
 }
 
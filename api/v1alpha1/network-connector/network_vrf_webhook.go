@@ -56,8 +56,11 @@ func (*Network) ValidateCreate(_ context.Context, r *Network) (admission.Warning
 	return nil, r.validateNetwork()
 }
 
-func (*Network) ValidateUpdate(_ context.Context, _, r *Network) (admission.Warnings, error) {
+func (*Network) ValidateUpdate(_ context.Context, old, r *Network) (admission.Warnings, error) {
 	networklog.Info("validate update", "name", r.Name)
+	if err := validateDryRunUpdate(old, r); err != nil {
+		return nil, err
+	}
 	return nil, r.validateNetwork()
 }
 
@@ -125,8 +128,11 @@ func (*VRF) ValidateCreate(_ context.Context, r *VRF) (admission.Warnings, error
 	return nil, r.validateVRF()
 }
 
-func (*VRF) ValidateUpdate(_ context.Context, _, r *VRF) (admission.Warnings, error) {
+func (*VRF) ValidateUpdate(_ context.Context, old, r *VRF) (admission.Warnings, error) {
 	vrflog.Info("validate update", "name", r.Name)
+	if err := validateDryRunUpdate(old, r); err != nil {
+		return nil, err
+	}
 	return nil, r.validateVRF()
 }
 
@@ -148,5 +154,29 @@ func (r *VRF) validateVRF() error {
 	if r.Spec.RouteTarget != nil && !routeTargetExpr.MatchString(*r.Spec.RouteTarget) {
 		return fmt.Errorf("routeTarget %q must match ASN:value format (e.g. 65000:100)", *r.Spec.RouteTarget)
 	}
+	if r.Spec.EVPNImport != nil {
+		for i, p := range r.Spec.EVPNImport.Prefixes {
+			if err := validateEVPNImportPrefix(p); err != nil {
+				return fmt.Errorf("spec.evpnImport.prefixes[%d]: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateEVPNImportPrefix(p EVPNImportPrefix) error {
+	ip, ipNet, err := net.ParseCIDR(p.Prefix)
+	if err != nil {
+		return fmt.Errorf("invalid prefix %q: %w", p.Prefix, err)
+	}
+	if !ip.Equal(ipNet.IP) {
+		return fmt.Errorf("invalid prefix %q: must be the network address (host bits zero, e.g. %q)", p.Prefix, ipNet.String())
+	}
+	if p.MaxLength != nil {
+		ones, bits := ipNet.Mask.Size()
+		if int(*p.MaxLength) < ones || int(*p.MaxLength) > bits {
+			return fmt.Errorf("maxLength %d must be between %d and %d for prefix %q", *p.MaxLength, ones, bits, p.Prefix)
+		}
+	}
 	return nil
 }
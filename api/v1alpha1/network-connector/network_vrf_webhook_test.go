@@ -220,6 +220,16 @@ func TestVRFValidateCreate_Full(t *testing.T) {
 	}
 }
 
+func TestVRFValidateCreate_WithEVPNImport(t *testing.T) {
+	v := &VRF{Spec: VRFSpec{VRF: "prod", EVPNImport: &EVPNImport{Prefixes: []EVPNImportPrefix{
+		{Prefix: "10.0.0.0/8", MaxLength: int32Ptr(24)},
+		{Prefix: "fd00::/48"},
+	}}}}
+	if _, err := v.ValidateCreate(context.Background(), v); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
 func TestVRFValidateUpdate_Valid(t *testing.T) {
 	old := &VRF{Spec: VRFSpec{VRF: "prod"}}
 	v := &VRF{Spec: VRFSpec{VRF: "prod", VNI: int32Ptr(100)}}
@@ -296,3 +306,17 @@ func TestVRFValidateCreate_InvalidRouteTarget_Empty(t *testing.T) {
 		t.Fatal("expected error for empty route target, got nil")
 	}
 }
+
+func TestVRFValidateCreate_InvalidEVPNImportPrefix(t *testing.T) {
+	for _, p := range []EVPNImportPrefix{
+		{Prefix: "10.0.0.0"},
+		{Prefix: "10.0.0.1/8"},
+		{Prefix: "10.0.0.0/16", MaxLength: int32Ptr(8)},
+		{Prefix: "10.0.0.0/16", MaxLength: int32Ptr(33)},
+	} {
+		v := &VRF{Spec: VRFSpec{VRF: "prod", EVPNImport: &EVPNImport{Prefixes: []EVPNImportPrefix{p}}}}
+		if _, err := v.ValidateCreate(context.Background(), v); err == nil {
+			t.Errorf("expected error for EVPN import prefix %+v, got nil", p)
+		}
+	}
+}
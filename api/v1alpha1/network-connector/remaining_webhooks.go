@@ -20,11 +20,15 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"regexp"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/telekom/das-schiff-network-operator/pkg/bgpcommunity"
 )
 
 var (
@@ -35,8 +39,24 @@ var (
 	announcementpolicylog = logf.Log.WithName("announcementpolicy-resource")
 	destinationlog        = logf.Log.WithName("destination-resource")
 	interfaceconfiglog    = logf.Log.WithName("interfaceconfig-resource")
+	nodeattachmentlog     = logf.Log.WithName("nodeattachment-resource")
+
+	// asPathRegexExpr limits AS path regexes to AS numbers and the operators
+	// of the FRR as-path regex syntax, so that they cannot break out of the
+	// rendered as-path access-list line.
+	asPathRegexExpr = regexp.MustCompile(`^[-0-9_^$.*+?()\[\]{}|,]+$`)
 )
 
+// validateDryRunUpdate rejects marking an existing intent CRD as a dry-run
+// proposal. The reconciler ignores dry-run objects, so the annotation would
+// silently withdraw the config of an applied object from every node.
+func validateDryRunUpdate(old, r metav1.Object) error {
+	if old.GetAnnotations()[AnnotationDryRun] != "true" && r.GetAnnotations()[AnnotationDryRun] == "true" {
+		return fmt.Errorf("annotation %s can only be set when the object is created", AnnotationDryRun)
+	}
+	return nil
+}
+
 // ===========================================================================
 // BGPPeering webhook.
 // ===========================================================================
@@ -59,6 +79,9 @@ func (*BGPPeering) ValidateCreate(_ context.Context, r *BGPPeering) (admission.W
 
 func (*BGPPeering) ValidateUpdate(_ context.Context, old, r *BGPPeering) (admission.Warnings, error) {
 	bgppeeringlog.Info("validate update", "name", r.Name)
+	if err := validateDryRunUpdate(old, r); err != nil {
+		return nil, err
+	}
 	if err := r.validateBGPPeering(); err != nil {
 		return nil, err
 	}
@@ -99,6 +122,17 @@ func (r *BGPPeering) validateBGPPeering() error {
 			return fmt.Errorf("spec.ref.networkRefs must not be set for loopbackPeer mode")
 		}
 	}
+	if r.Spec.Export != nil {
+		if err := validateCommunities("spec.export.communities", r.Spec.Export.Communities); err != nil {
+			return err
+		}
+		if err := validateASPathRegex("spec.export.asPathMatch", r.Spec.Export.ASPathMatch); err != nil {
+			return err
+		}
+		if err := validateASPathPrepend("spec.export.asPathPrepend", r.Spec.Export.ASPathPrepend); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -124,6 +158,9 @@ func (*PodNetwork) ValidateCreate(_ context.Context, r *PodNetwork) (admission.W
 
 func (*PodNetwork) ValidateUpdate(_ context.Context, old, r *PodNetwork) (admission.Warnings, error) {
 	podnetworklog.Info("validate update", "name", r.Name)
+	if err := validateDryRunUpdate(old, r); err != nil {
+		return nil, err
+	}
 	if err := r.validatePodNetwork(); err != nil {
 		return nil, err
 	}
@@ -167,6 +204,9 @@ func (*Collector) ValidateCreate(_ context.Context, r *Collector) (admission.War
 
 func (*Collector) ValidateUpdate(_ context.Context, old, r *Collector) (admission.Warnings, error) {
 	collectorlog.Info("validate update", "name", r.Name)
+	if err := validateDryRunUpdate(old, r); err != nil {
+		return nil, err
+	}
 	if err := r.validateCollector(); err != nil {
 		return nil, err
 	}
@@ -223,8 +263,11 @@ func (*TrafficMirror) ValidateCreate(_ context.Context, r *TrafficMirror) (admis
 	return nil, r.validateTrafficMirror()
 }
 
-func (*TrafficMirror) ValidateUpdate(_ context.Context, _, r *TrafficMirror) (admission.Warnings, error) {
+func (*TrafficMirror) ValidateUpdate(_ context.Context, old, r *TrafficMirror) (admission.Warnings, error) {
 	trafficmirrorlog.Info("validate update", "name", r.Name)
+	if err := validateDryRunUpdate(old, r); err != nil {
+		return nil, err
+	}
 	return nil, r.validateTrafficMirror()
 }
 
@@ -275,8 +318,11 @@ func (*AnnouncementPolicy) ValidateCreate(_ context.Context, r *AnnouncementPoli
 	return nil, r.validateAnnouncementPolicy()
 }
 
-func (*AnnouncementPolicy) ValidateUpdate(_ context.Context, _, r *AnnouncementPolicy) (admission.Warnings, error) {
+func (*AnnouncementPolicy) ValidateUpdate(_ context.Context, old, r *AnnouncementPolicy) (admission.Warnings, error) {
 	announcementpolicylog.Info("validate update", "name", r.Name)
+	if err := validateDryRunUpdate(old, r); err != nil {
+		return nil, err
+	}
 	return nil, r.validateAnnouncementPolicy()
 }
 
@@ -289,7 +335,21 @@ func (r *AnnouncementPolicy) validateAnnouncementPolicy() error {
 	if r.Spec.VRFRef == "" {
 		return fmt.Errorf("spec.vrfRef must not be empty")
 	}
+	if r.Spec.HostRoutes != nil {
+		if err := validateCommunities("spec.hostRoutes.communities", r.Spec.HostRoutes.Communities); err != nil {
+			return err
+		}
+		if err := validateASPathPrepend("spec.hostRoutes.asPathPrepend", r.Spec.HostRoutes.ASPathPrepend); err != nil {
+			return err
+		}
+	}
 	if r.Spec.Aggregate != nil {
+		if err := validateCommunities("spec.aggregate.communities", r.Spec.Aggregate.Communities); err != nil {
+			return err
+		}
+		if err := validateASPathPrepend("spec.aggregate.asPathPrepend", r.Spec.Aggregate.ASPathPrepend); err != nil {
+			return err
+		}
 		if r.Spec.Aggregate.PrefixLengthV4 != nil {
 			v := *r.Spec.Aggregate.PrefixLengthV4
 			if v < 1 || v > 32 {
@@ -306,6 +366,49 @@ func (r *AnnouncementPolicy) validateAnnouncementPolicy() error {
 	return nil
 }
 
+// validateCommunities checks that communities are standard, large or extended
+// communities.
+func validateCommunities(field string, communities []string) error {
+	for i, community := range communities {
+		if err := bgpcommunity.Validate(community); err != nil {
+			return fmt.Errorf("%s[%d]: %w", field, i, err)
+		}
+	}
+	return nil
+}
+
+// validateASPathRegex checks that the AS path regex expr only contains AS
+// numbers and FRR as-path regex operators and that it compiles.
+func validateASPathRegex(field, expr string) error {
+	if expr == "" {
+		return nil
+	}
+	if !asPathRegexExpr.MatchString(expr) {
+		return fmt.Errorf("%s: %q contains characters not allowed in an AS path regex", field, expr)
+	}
+	if _, err := regexp.Compile(expr); err != nil {
+		return fmt.Errorf("%s: invalid AS path regex: %w", field, err)
+	}
+	return nil
+}
+
+// validateASPathPrepend checks that exactly one of ownASCount and asns of
+// prepend is set and that the AS numbers are not the reserved AS 0.
+func validateASPathPrepend(field string, prepend *ASPathPrepend) error {
+	if prepend == nil {
+		return nil
+	}
+	if (prepend.OwnASCount != nil) == (len(prepend.ASNs) > 0) {
+		return fmt.Errorf("%s: exactly one of ownASCount or asns must be set", field)
+	}
+	for _, asn := range prepend.ASNs {
+		if asn == 0 {
+			return fmt.Errorf("%s.asns must not contain the reserved AS 0", field)
+		}
+	}
+	return nil
+}
+
 // ===========================================================================
 // Destination webhook
 // ===========================================================================
@@ -326,8 +429,11 @@ func (*Destination) ValidateCreate(_ context.Context, r *Destination) (admission
 	return nil, r.validateDestination()
 }
 
-func (*Destination) ValidateUpdate(_ context.Context, _, r *Destination) (admission.Warnings, error) {
+func (*Destination) ValidateUpdate(_ context.Context, old, r *Destination) (admission.Warnings, error) {
 	destinationlog.Info("validate update", "name", r.Name)
+	if err := validateDryRunUpdate(old, r); err != nil {
+		return nil, err
+	}
 	return nil, r.validateDestination()
 }
 
@@ -353,6 +459,11 @@ func (r *Destination) validateDestination() error {
 				return fmt.Errorf("spec.nextHop.ipv6 must be a valid IP address, got %q", *r.Spec.NextHop.IPv6)
 			}
 		}
+		for i, gw := range r.Spec.NextHop.Gateways {
+			if net.ParseIP(gw.Address) == nil {
+				return fmt.Errorf("spec.nextHop.gateways[%d].address must be a valid IP address, got %q", i, gw.Address)
+			}
+		}
 	}
 	return nil
 }
@@ -408,3 +519,33 @@ func (r *InterfaceConfig) validateInterfaceConfig() error {
 	}
 	return nil
 }
+
+// ===========================================================================
+// NodeAttachment webhook
+// ===========================================================================
+
+func (r *NodeAttachment) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if err := builder.WebhookManagedBy(mgr, r).WithValidator(r).Complete(); err != nil {
+		return fmt.Errorf("error building NodeAttachment webhook: %w", err)
+	}
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-network-connector-sylvaproject-org-v1alpha1-nodeattachment,mutating=false,failurePolicy=fail,sideEffects=None,groups=network-connector.sylvaproject.org,resources=nodeattachments,verbs=update,versions=v1alpha1,name=vnodeattachment.kb.io,admissionReviewVersions=v1
+
+var _ admission.Validator[*NodeAttachment] = &NodeAttachment{}
+
+func (*NodeAttachment) ValidateCreate(_ context.Context, r *NodeAttachment) (admission.Warnings, error) {
+	nodeattachmentlog.Info("validate create", "name", r.Name)
+	return nil, nil
+}
+
+func (*NodeAttachment) ValidateUpdate(_ context.Context, old, r *NodeAttachment) (admission.Warnings, error) {
+	nodeattachmentlog.Info("validate update", "name", r.Name)
+	return nil, validateDryRunUpdate(old, r)
+}
+
+func (*NodeAttachment) ValidateDelete(_ context.Context, r *NodeAttachment) (admission.Warnings, error) {
+	nodeattachmentlog.Info("validate delete", "name", r.Name)
+	return nil, nil
+}
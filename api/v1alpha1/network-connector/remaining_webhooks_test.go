@@ -140,6 +140,54 @@ func TestBGPPeeringValidateCreate_InvalidMode(t *testing.T) {
 	}
 }
 
+func TestBGPPeeringValidateCreate_ExportCommunities(t *testing.T) {
+	newPeering := func(communities ...string) *BGPPeering {
+		return &BGPPeering{Spec: BGPPeeringSpec{
+			Mode: BGPPeeringModeListenRange,
+			Ref: BGPPeeringRef{
+				AttachmentRef: strPtr("l2a-1"),
+				NetworkRefs:   []string{"net-1"},
+			},
+			Export: &BGPPeeringExport{Communities: communities},
+		}}
+	}
+
+	r := newPeering("65000:100", "4200000000:1:2", "rt 65000:100", "no-export")
+	if _, err := r.ValidateCreate(context.Background(), r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r = newPeering("65000:100", "4200000000:100")
+	if _, err := r.ValidateCreate(context.Background(), r); err == nil {
+		t.Fatal("expected error for a 4-byte AS in a standard community, got nil")
+	}
+}
+
+func TestBGPPeeringValidateCreate_ExportASPathMatch(t *testing.T) {
+	newPeering := func(asPathMatch string) *BGPPeering {
+		return &BGPPeering{Spec: BGPPeeringSpec{
+			Mode: BGPPeeringModeListenRange,
+			Ref: BGPPeeringRef{
+				AttachmentRef: strPtr("l2a-1"),
+				NetworkRefs:   []string{"net-1"},
+			},
+			Export: &BGPPeeringExport{ASPathMatch: asPathMatch},
+		}}
+	}
+
+	for _, expr := range []string{"^65010_", "_6501[0-9]$", "^(65010|65011)_.*", "^$"} {
+		r := newPeering(expr)
+		if _, err := r.ValidateCreate(context.Background(), r); err != nil {
+			t.Fatalf("unexpected error for %q: %v", expr, err)
+		}
+	}
+	for _, expr := range []string{"^65010_\nip forwarding", "^65010 65011$", "^6501[0-9", "a.*"} {
+		r := newPeering(expr)
+		if _, err := r.ValidateCreate(context.Background(), r); err == nil {
+			t.Fatalf("expected error for %q, got nil", expr)
+		}
+	}
+}
+
 func TestBGPPeeringValidateUpdate_ModeImmutable(t *testing.T) {
 	old := &BGPPeering{Spec: BGPPeeringSpec{
 		Mode: BGPPeeringModeListenRange,
@@ -525,6 +573,49 @@ func TestAnnouncementPolicyValidateCreate_PrefixLengthV6Boundary(t *testing.T) {
 	}
 }
 
+func TestAnnouncementPolicyValidateCreate_WithPreference(t *testing.T) {
+	localPref, med := uint32(200), uint32(0)
+	r := &AnnouncementPolicy{Spec: AnnouncementPolicySpec{
+		VRFRef:     "vrf-1",
+		HostRoutes: &RouteAnnouncementConfig{LocalPreference: &localPref},
+		Aggregate:  &AggregateConfig{MED: &med, ASPathPrepend: &ASPathPrepend{ASNs: []uint32{65001}}},
+	}}
+	if _, err := r.ValidateCreate(context.Background(), r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAnnouncementPolicyValidateCreate_InvalidCommunity(t *testing.T) {
+	r := &AnnouncementPolicy{Spec: AnnouncementPolicySpec{
+		VRFRef:     "vrf-1",
+		HostRoutes: &RouteAnnouncementConfig{Communities: []string{"target 65000:100"}},
+	}}
+	if _, err := r.ValidateCreate(context.Background(), r); err == nil {
+		t.Fatal("expected error for an invalid extended community, got nil")
+	}
+}
+
+func TestAnnouncementPolicyValidateCreate_ASPathPrependBoth(t *testing.T) {
+	count := 2
+	r := &AnnouncementPolicy{Spec: AnnouncementPolicySpec{
+		VRFRef:     "vrf-1",
+		HostRoutes: &RouteAnnouncementConfig{ASPathPrepend: &ASPathPrepend{OwnASCount: &count, ASNs: []uint32{65001}}},
+	}}
+	if _, err := r.ValidateCreate(context.Background(), r); err == nil {
+		t.Fatal("expected error for ownASCount and asns both set, got nil")
+	}
+}
+
+func TestAnnouncementPolicyValidateCreate_ASPathPrependReservedAS(t *testing.T) {
+	r := &AnnouncementPolicy{Spec: AnnouncementPolicySpec{
+		VRFRef:    "vrf-1",
+		Aggregate: &AggregateConfig{ASPathPrepend: &ASPathPrepend{ASNs: []uint32{65001, 0}}},
+	}}
+	if _, err := r.ValidateCreate(context.Background(), r); err == nil {
+		t.Fatal("expected error for AS 0 in asns, got nil")
+	}
+}
+
 func TestAnnouncementPolicyValidateUpdate_Valid(t *testing.T) {
 	old := &AnnouncementPolicy{Spec: AnnouncementPolicySpec{VRFRef: "vrf-1"}}
 	r := &AnnouncementPolicy{Spec: AnnouncementPolicySpec{
@@ -603,6 +694,27 @@ func TestDestinationValidateCreate_InvalidNextHopIPv6(t *testing.T) {
 	}
 }
 
+func TestDestinationValidateCreate_WithNextHopGateways(t *testing.T) {
+	r := &Destination{Spec: DestinationSpec{
+		NextHop: &NextHopConfig{Gateways: []NextHopGateway{
+			{Address: "10.0.0.1", Weight: int32Ptr(2)},
+			{Address: "2001:db8::1"},
+		}},
+	}}
+	if _, err := r.ValidateCreate(context.Background(), r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDestinationValidateCreate_InvalidNextHopGateway(t *testing.T) {
+	r := &Destination{Spec: DestinationSpec{
+		NextHop: &NextHopConfig{Gateways: []NextHopGateway{{Address: "10.0.0.1"}, {Address: "not-an-ip"}}},
+	}}
+	if _, err := r.ValidateCreate(context.Background(), r); err == nil {
+		t.Fatal("expected error for invalid gateway address, got nil")
+	}
+}
+
 func TestDestinationValidateUpdate_Valid(t *testing.T) {
 	old := &Destination{Spec: DestinationSpec{VRFRef: strPtr("vrf-1")}}
 	r := &Destination{Spec: DestinationSpec{
@@ -614,6 +726,26 @@ func TestDestinationValidateUpdate_Valid(t *testing.T) {
 	}
 }
 
+func TestDestinationValidateUpdate_DryRun(t *testing.T) {
+	dryRun := map[string]string{AnnotationDryRun: "true"}
+	applied := &Destination{Spec: DestinationSpec{VRFRef: strPtr("vrf-1")}}
+	proposal := &Destination{Spec: DestinationSpec{VRFRef: strPtr("vrf-1")}}
+	proposal.Annotations = dryRun
+
+	if _, err := proposal.ValidateCreate(context.Background(), proposal); err != nil {
+		t.Fatalf("unexpected error creating a proposal: %v", err)
+	}
+	if _, err := proposal.ValidateUpdate(context.Background(), proposal, proposal); err != nil {
+		t.Fatalf("unexpected error updating a proposal: %v", err)
+	}
+	if _, err := applied.ValidateUpdate(context.Background(), proposal, applied); err != nil {
+		t.Fatalf("unexpected error applying a proposal: %v", err)
+	}
+	if _, err := proposal.ValidateUpdate(context.Background(), applied, proposal); err == nil {
+		t.Fatal("expected error for marking an existing object as dry-run, got nil")
+	}
+}
+
 func TestDestinationValidateDelete_AlwaysSucceeds(t *testing.T) {
 	r := &Destination{}
 	if _, err := r.ValidateDelete(context.Background(), r); err != nil {
@@ -68,6 +68,11 @@ const (
 	// AnnotationTargetNamespace specifies the hardcoded namespace on the workload cluster
 	// where synced intent CRDs are placed.
 	AnnotationTargetNamespace = "network-connector.sylvaproject.org/target-namespace"
+
+	// AnnotationDryRun marks an intent CRD as a proposal: the reconciler does not
+	// apply it, but `kubectl nnc plan` shows the NodeNetworkConfig changes it would cause.
+	// The webhooks only accept it on creation, so an applied CRD is never withdrawn by it.
+	AnnotationDryRun = "network-connector.sylvaproject.org/dry-run"
 )
 
 // --- Shared Types ---
@@ -95,9 +100,10 @@ type AdvertisementConfig struct {
 	Type string `json:"type"`
 }
 
-// NextHopConfig specifies next-hop addresses for static routing in non-HBN mode.
-// At least one of IPv4 or IPv6 must be set.
-// +kubebuilder:validation:XValidation:rule="has(self.ipv4) || has(self.ipv6)",message="at least one of ipv4 or ipv6 must be set"
+// NextHopConfig specifies next-hop addresses for static routing. IPv4 and IPv6
+// apply in non-HBN mode. Either gateways or at least one of IPv4 or IPv6 must
+// be set.
+// +kubebuilder:validation:XValidation:rule="(has(self.ipv4) || has(self.ipv6)) != has(self.gateways)",message="either gateways or at least one of ipv4 or ipv6 must be set"
 type NextHopConfig struct {
 	// IPv4 is the IPv4 next-hop address (e.g. "198.51.100.1").
 	// +optional
@@ -106,6 +112,40 @@ type NextHopConfig struct {
 	// IPv6 is the IPv6 next-hop address (e.g. "2001:db8:100::1").
 	// +optional
 	IPv6 *string `json:"ipv6,omitempty"`
+
+	// Gateways are next hops the prefixes are load shared across, e.g. the
+	// redundant gateways of an appliance cluster. Each prefix is routed via the
+	// gateways of its address family. On HBN nodes the routes are installed in
+	// the VRF of the Layer2Attachment's IRB, with weights and BFD. In non-HBN
+	// mode netplan cannot install multipath routes, so each prefix is only
+	// routed via the first gateway of its address family, without BFD.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Gateways []NextHopGateway `json:"gateways,omitempty"`
+
+	// BFDProfile configures the BFD timers of the gateways with BFD enabled.
+	// +optional
+	BFDProfile *BFDProfile `json:"bfdProfile,omitempty"`
+}
+
+// NextHopGateway is one of the gateways of a Destination.
+type NextHopGateway struct {
+	// Address is the IPv4 or IPv6 address of the gateway.
+	// +kubebuilder:validation:Required
+	Address string `json:"address"`
+
+	// Weight is the relative weight of the gateway, traffic is shared in
+	// proportion to the weights. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=255
+	Weight *int32 `json:"weight,omitempty"`
+
+	// EnableBFD tracks the gateway with BFD, so a dead gateway is withdrawn
+	// from the routes.
+	// +optional
+	EnableBFD *bool `json:"enableBFD,omitempty"`
 }
 
 // DestinationPort describes a port (or port range) allowed for traffic
@@ -244,6 +284,25 @@ type BFDProfile struct {
 	MinInterval uint32 `json:"minInterval"`
 }
 
+// ASPathPrepend prepends AS numbers to the AS path of exported routes, e.g. to
+// make a standby site less preferred. Exactly one of ownASCount and asns must
+// be set.
+// Consistent with the ASPathPrepend in network.t-caas.telekom.com/v1alpha1.
+// +kubebuilder:validation:XValidation:rule="has(self.ownASCount) != has(self.asns)",message="exactly one of ownASCount or asns must be set"
+type ASPathPrepend struct {
+	// OwnASCount is the number of times the node's own AS number is prepended.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	OwnASCount *int `json:"ownASCount,omitempty"`
+
+	// ASNs are the AS numbers to prepend, the first one becomes the leftmost.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	ASNs []uint32 `json:"asns,omitempty"`
+}
+
 // BGPAddressFamily specifies a BGP address family for session negotiation.
 // +kubebuilder:validation:Enum=ipv4Unicast;ipv6Unicast
 type BGPAddressFamily string
@@ -40,6 +40,33 @@ type VRFSpec struct {
 	// RouteTarget is the BGP route target for the VRF. When omitted, the controller resolves it.
 	// +optional
 	RouteTarget *string `json:"routeTarget,omitempty"`
+
+	// EVPNImport restricts the routes imported from the fabric that are
+	// installed on nodes, e.g. to protect the FIB from a full table. When
+	// omitted, all routes of the VRF are installed.
+	// +optional
+	EVPNImport *EVPNImport `json:"evpnImport,omitempty"`
+}
+
+// EVPNImport restricts the routes a VRF imports from the fabric over EVPN.
+type EVPNImport struct {
+	// Prefixes lists the prefixes imported from the fabric. Routes not within
+	// any of them are not installed.
+	// +kubebuilder:validation:MinItems=1
+	Prefixes []EVPNImportPrefix `json:"prefixes"`
+}
+
+// EVPNImportPrefix is a prefix imported from the fabric.
+type EVPNImportPrefix struct {
+	// Prefix in CIDR notation. Routes within it are imported.
+	Prefix string `json:"prefix"`
+
+	// MaxLength is the longest prefix length imported within Prefix, e.g. 24
+	// to drop more specific routes. Defaults to 32 for IPv4 and 128 for IPv6.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=128
+	MaxLength *int32 `json:"maxLength,omitempty"`
 }
 
 // VRFStatus defines the observed state of VRF.
@@ -26,6 +26,31 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ASPathPrepend) DeepCopyInto(out *ASPathPrepend) {
+	*out = *in
+	if in.OwnASCount != nil {
+		in, out := &in.OwnASCount, &out.OwnASCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.ASNs != nil {
+		in, out := &in.ASNs, &out.ASNs
+		*out = make([]uint32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ASPathPrepend.
+func (in *ASPathPrepend) DeepCopy() *ASPathPrepend {
+	if in == nil {
+		return nil
+	}
+	out := new(ASPathPrepend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddressAllocation) DeepCopyInto(out *AddressAllocation) {
 	*out = *in
@@ -79,6 +104,21 @@ func (in *AggregateConfig) DeepCopyInto(out *AggregateConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ASPathPrepend != nil {
+		in, out := &in.ASPathPrepend, &out.ASPathPrepend
+		*out = new(ASPathPrepend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocalPreference != nil {
+		in, out := &in.LocalPreference, &out.LocalPreference
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MED != nil {
+		in, out := &in.MED, &out.MED
+		*out = new(uint32)
+		**out = **in
+	}
 	if in.PrefixLengthV4 != nil {
 		in, out := &in.PrefixLengthV4, &out.PrefixLengthV4
 		*out = new(int32)
@@ -282,6 +322,21 @@ func (in *BGPPeeringExport) DeepCopyInto(out *BGPPeeringExport) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ASPathPrepend != nil {
+		in, out := &in.ASPathPrepend, &out.ASPathPrepend
+		*out = new(ASPathPrepend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocalPreference != nil {
+		in, out := &in.LocalPreference, &out.LocalPreference
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MED != nil {
+		in, out := &in.MED, &out.MED
+		*out = new(uint32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPPeeringExport.
@@ -801,6 +856,48 @@ func (in *DestinationStatus) DeepCopy() *DestinationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EVPNImport) DeepCopyInto(out *EVPNImport) {
+	*out = *in
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]EVPNImportPrefix, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EVPNImport.
+func (in *EVPNImport) DeepCopy() *EVPNImport {
+	if in == nil {
+		return nil
+	}
+	out := new(EVPNImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EVPNImportPrefix) DeepCopyInto(out *EVPNImportPrefix) {
+	*out = *in
+	if in.MaxLength != nil {
+		in, out := &in.MaxLength, &out.MaxLength
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EVPNImportPrefix.
+func (in *EVPNImportPrefix) DeepCopy() *EVPNImportPrefix {
+	if in == nil {
+		return nil
+	}
+	out := new(EVPNImportPrefix)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EthernetConfig) DeepCopyInto(out *EthernetConfig) {
 	*out = *in
@@ -1462,6 +1559,18 @@ func (in *NextHopConfig) DeepCopyInto(out *NextHopConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]NextHopGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BFDProfile != nil {
+		in, out := &in.BFDProfile, &out.BFDProfile
+		*out = new(BFDProfile)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NextHopConfig.
@@ -1474,6 +1583,31 @@ func (in *NextHopConfig) DeepCopy() *NextHopConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NextHopGateway) DeepCopyInto(out *NextHopGateway) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EnableBFD != nil {
+		in, out := &in.EnableBFD, &out.EnableBFD
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NextHopGateway.
+func (in *NextHopGateway) DeepCopy() *NextHopGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(NextHopGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeAttachment) DeepCopyInto(out *NodeAttachment) {
 	*out = *in
@@ -2054,6 +2188,21 @@ func (in *RouteAnnouncementConfig) DeepCopyInto(out *RouteAnnouncementConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ASPathPrepend != nil {
+		in, out := &in.ASPathPrepend, &out.ASPathPrepend
+		*out = new(ASPathPrepend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocalPreference != nil {
+		in, out := &in.LocalPreference, &out.LocalPreference
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MED != nil {
+		in, out := &in.MED, &out.MED
+		*out = new(uint32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteAnnouncementConfig.
@@ -2295,6 +2444,11 @@ func (in *VRFSpec) DeepCopyInto(out *VRFSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.EVPNImport != nil {
+		in, out := &in.EVPNImport, &out.EVPNImport
+		*out = new(EVPNImport)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRFSpec.
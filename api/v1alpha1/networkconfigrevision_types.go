@@ -25,6 +25,46 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// RolloutPausedAnnotation pauses the rollout of a NetworkConfigRevision if set to "true".
+	RolloutPausedAnnotation = "network.t-caas.telekom.com/rollout-paused"
+	// RolloutApprovedAnnotation approves the rollout of a NetworkConfigRevision up to and
+	// including the RolloutPolicy group it names.
+	RolloutApprovedAnnotation = "network.t-caas.telekom.com/rollout-approved"
+	// RollbackAnnotation requests a rollback to the NetworkConfigRevision if set to "true".
+	// All newer revisions are invalidated, so the annotated revision is deployed again.
+	RollbackAnnotation = "network.t-caas.telekom.com/rollback"
+	// RetryAnnotation requests another rollout of an invalid NetworkConfigRevision of the
+	// intent reconciler if set to "true". The nodes that failed get their configs again.
+	RetryAnnotation = "network.t-caas.telekom.com/retry"
+
+	// QuarantinedLabel is set to "true" on Nodes that are excluded from the rollout of
+	// NetworkConfigRevisions because their configs failed repeatedly.
+	QuarantinedLabel = "network.t-caas.telekom.com/quarantined"
+	// ConfigFailuresAnnotation counts the consecutive NetworkConfigRevisions a Node failed to provision.
+	ConfigFailuresAnnotation = "network.t-caas.telekom.com/config-failures"
+	// LastFailedRevisionAnnotation is the last NetworkConfigRevision a Node failed to provision.
+	LastFailedRevisionAnnotation = "network.t-caas.telekom.com/last-failed-revision"
+
+	// ManagedByLabel is set to ManagedByIntent on objects created by the intent reconciler.
+	ManagedByLabel  = "network-connector.sylvaproject.org/managed-by"
+	ManagedByIntent = "intent"
+)
+
+// RolloutGate describes whether the rollout of a NetworkConfigRevision proceeds.
+type RolloutGate string
+
+const (
+	// RolloutGateOpen means nodes are updated.
+	RolloutGateOpen RolloutGate = "Open"
+	// RolloutGatePaused means the rollout was paused with the rollout-paused annotation.
+	RolloutGatePaused RolloutGate = "Paused"
+	// RolloutGateSoaking means the rollout waits for the soak time of the RolloutPolicy to pass.
+	RolloutGateSoaking RolloutGate = "Soaking"
+	// RolloutGateAwaitingApproval means the rollout waits for the next group to be approved.
+	RolloutGateAwaitingApproval RolloutGate = "AwaitingApproval"
+)
+
 type Layer2Revision struct {
 	Name                           string `json:"name,omitempty"`
 	Layer2NetworkConfigurationSpec `json:",inline"`
@@ -61,6 +101,10 @@ type NetworkConfigRevisionSpec struct {
 	MirrorTargets []MirrorTargetRevision `json:"mirrorTargets,omitempty"`
 	// MirrorSelectors snapshots the cluster's MirrorSelector objects.
 	MirrorSelectors []MirrorSelectorRevision `json:"mirrorSelectors,omitempty"`
+	// NodeRevisions maps node names to the revision of the NodeNetworkConfig
+	// the node is rolled out with. It is only set on revisions created by the
+	// intent reconciler, which assembles the NodeNetworkConfigs per node.
+	NodeRevisions map[string]string `json:"nodeRevisions,omitempty"`
 	// Revision is a hash of the NetworkConfigRevision object that is used to identify the particular revision.
 	Revision string `json:"revision"`
 }
@@ -82,6 +126,30 @@ type NetworkConfigRevisionStatus struct {
 	FailedMessage string `json:"failedMessage,omitempty"`
 	// FailedAt is when the failure occurred.
 	FailedAt *metav1.Time `json:"failedAt,omitempty"`
+	// +kubebuilder:validation:Enum=Open;Paused;Soaking;AwaitingApproval
+	// Gate informs whether the rollout of the revision proceeds or why it is held back.
+	// It is only set on the revision that is currently rolled out.
+	Gate RolloutGate `json:"gate,omitempty"`
+	// Rollout reports the progress of a staged rollout. It is only set if a RolloutPolicy exists.
+	Rollout *RevisionRolloutStatus `json:"rollout,omitempty"`
+	// QuarantinedNodes lists the nodes that are excluded from the rollout because their configs
+	// failed repeatedly. It is only set on the revision that is currently rolled out.
+	QuarantinedNodes []string `json:"quarantinedNodes,omitempty"`
+}
+
+// RevisionRolloutStatus reports the progress of a staged rollout driven by a RolloutPolicy.
+type RevisionRolloutStatus struct {
+	// Stage is the name of the stage currently rolled out. Stages of groups with a
+	// topologyKey are named <group>/<label value>.
+	Stage string `json:"stage,omitempty"`
+	// CompletedStages informs about how many stages are fully provisioned.
+	CompletedStages int `json:"completedStages"`
+	// TotalStages informs about how many stages the rollout consists of.
+	TotalStages int `json:"totalStages"`
+	// SoakingUntil is set while the rollout waits for the soak time to pass before Stage is started.
+	SoakingUntil *metav1.Time `json:"soakingUntil,omitempty"`
+	// AwaitingApproval is the name of the group that has to be approved before Stage is started.
+	AwaitingApproval string `json:"awaitingApproval,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -92,7 +160,9 @@ type NetworkConfigRevisionStatus struct {
 //+kubebuilder:printcolumn:name="Ongoing",type="integer",JSONPath=".status.ongoing"
 //+kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.ready"
 //+kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.total"
+//+kubebuilder:printcolumn:name="Gate",type=string,JSONPath=`.status.gate`
 //+kubebuilder:printcolumn:name="FailedNode",type=string,JSONPath=`.status.failedNode`,priority=1
+//+kubebuilder:printcolumn:name="Stage",type=string,JSONPath=`.status.rollout.stage`,priority=1
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // NetworkConfigRevision is the Schema for the node configuration.
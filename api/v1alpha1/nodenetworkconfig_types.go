@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strconv"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -110,6 +113,11 @@ type FabricVRF struct {
 	EVPNExportRouteTargets []string `json:"evpnExportRouteTargets"`
 	// EVPNExportFilter is the export filter for EVPN.
 	EVPNExportFilter *Filter `json:"evpnExportFilter"`
+	// EVPNImportFilter is the import filter for EVPN. It restricts the routes
+	// imported from the fabric that are installed on the node. Routes of the
+	// VRF's own BGP peers and routes leaked from other VRFs are not filtered.
+	// +optional
+	EVPNImportFilter *Filter `json:"evpnImportFilter,omitempty"`
 }
 
 // Loopback represents a loopback interface.
@@ -192,6 +200,8 @@ type Matcher struct {
 	Prefix *PrefixMatcher `json:"prefix,omitempty"`
 	// BGPCommunity is the BGP community matcher.
 	BGPCommunity *BGPCommunityMatcher `json:"bgpCommunity,omitempty"`
+	// ASPath is the AS path matcher.
+	ASPath *ASPathMatcher `json:"asPath,omitempty"`
 }
 
 // PrefixMatcher represents a prefix matcher.
@@ -206,9 +216,36 @@ type PrefixMatcher struct {
 
 // BGPCommunityMatcher represents a BGP community matcher.
 type BGPCommunityMatcher struct {
-	// Community is the BGP community to match.
+	// Community is the BGP community to match, "AA:NN" for standard,
+	// "GA:LD1:LD2" for large and "rt ASN:NN" or "soo ASN:NN" for extended
+	// communities.
 	Community  string `json:"community"`
 	ExactMatch bool   `json:"exactMatch"`
+	// Type is the type of Community, standard if empty.
+	// +kubebuilder:validation:Enum=standard;large;extended
+	Type CommunityType `json:"type,omitempty"`
+}
+
+// CommunityType represents the type of a BGP community.
+type CommunityType string
+
+const (
+	// CommunityStandard represents a standard community.
+	CommunityStandard CommunityType = "standard"
+	// CommunityLarge represents a large community.
+	CommunityLarge CommunityType = "large"
+	// CommunityExtended represents an extended community.
+	CommunityExtended CommunityType = "extended"
+)
+
+// ASPathMatcher represents an AS path matcher.
+type ASPathMatcher struct {
+	// Regex is the regular expression the AS path must match, e.g. "_65010$"
+	// for routes originated by AS 65010. Only AS numbers and the FRR as-path
+	// regex operators are allowed.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[-0-9_^$.*+?()\[\]{}|,]+$`
+	Regex string `json:"regex"`
 }
 
 // Action represents an action configuration.
@@ -237,23 +274,116 @@ type ModifyRouteAction struct {
 	// AddCommunities is the community to add to the route.
 	AddCommunities []string `json:"addCommunities,omitempty"`
 	// AdditiveCommunities is the flag to add communities to the route, by default the communities are replaced.
+	// It applies to AddCommunities and AddLargeCommunities.
 	AdditiveCommunities *bool `json:"additiveCommunities,omitempty"`
+	// AddLargeCommunities is the large communities (GA:LD1:LD2) to add to the route.
+	// +kubebuilder:validation:items:Pattern=`^[0-9]+:[0-9]+:[0-9]+$`
+	AddLargeCommunities []string `json:"addLargeCommunities,omitempty"`
+	// AddExtCommunities is the extended communities to add to the route, "rt ASN:NN" for route targets
+	// and "soo ASN:NN" for sites of origin.
+	// +kubebuilder:validation:items:Pattern=`^(rt|soo) [0-9.]+:[0-9]+$`
+	AddExtCommunities []string `json:"addExtCommunities,omitempty"`
 	// RemoveCommunities is the community to remove from the route.
 	RemoveCommunities []string `json:"removeCommunities,omitempty"`
 	// RemoveAllCommunities is the flag to remove all communities from the route.
 	RemoveAllCommunities *bool `json:"removeAllCommunities,omitempty"`
+	// ASPathPrepend prepends AS numbers to the AS path of the route.
+	ASPathPrepend *ASPathPrepend `json:"asPathPrepend,omitempty"`
+	// SetLocalPreference is the local preference to set on the route.
+	SetLocalPreference *uint32 `json:"setLocalPreference,omitempty"`
+	// SetMED is the multi-exit discriminator (metric) to set on the route.
+	SetMED *uint32 `json:"setMED,omitempty"`
+	// SetWeight is the weight to set on the route, it is local to the node.
+	SetWeight *uint32 `json:"setWeight,omitempty"`
+	// SetNextHop is the next hop to set on the route, an IP address,
+	// "peer-address" for the address of the peer or "unchanged" to keep the
+	// next hop when advertising the route to eBGP peers.
+	// +kubebuilder:validation:MaxLength=45
+	// +kubebuilder:validation:XValidation:rule="self == 'peer-address' || self == 'unchanged' || isIP(self)",message="setNextHop must be an IP address, peer-address or unchanged"
+	SetNextHop *string `json:"setNextHop,omitempty"`
+}
+
+const (
+	// NextHopPeerAddress sets the next hop of a route to the address of the
+	// peer.
+	NextHopPeerAddress = "peer-address"
+	// NextHopUnchanged keeps the next hop of a route.
+	NextHopUnchanged = "unchanged"
+)
+
+// ASPathPrepend represents an AS path prepend. Exactly one of OwnASCount and
+// ASNs must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.ownASCount) != has(self.asns)",message="exactly one of ownASCount or asns must be set"
+type ASPathPrepend struct {
+	// OwnASCount is the number of times the node's own AS number is prepended.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	OwnASCount *int `json:"ownASCount,omitempty"`
+	// ASNs are the AS numbers to prepend, the first one becomes the leftmost.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	ASNs []uint32 `json:"asns,omitempty"`
+}
+
+// Path returns the AS numbers to prepend separated by spaces, with localASN as
+// the node's own AS number.
+func (p *ASPathPrepend) Path(localASN int) string {
+	var asns []string
+	if p.OwnASCount != nil {
+		for range *p.OwnASCount {
+			asns = append(asns, strconv.Itoa(localASN))
+		}
+	}
+	for _, asn := range p.ASNs {
+		asns = append(asns, strconv.FormatUint(uint64(asn), 10))
+	}
+	return strings.Join(asns, " ")
 }
 
 // StaticRoute represents a static route configuration.
+// +kubebuilder:validation:XValidation:rule="!(has(self.nextHop) && has(self.nextHops))",message="nextHop and nextHops are mutually exclusive"
 type StaticRoute struct {
 	// Prefix is the prefix for the static route.
 	Prefix string `json:"prefix"`
 	// NextHop is the next hop for the static route.
 	NextHop *NextHop `json:"nextHop,omitempty"`
+	// NextHops are the next hops the static route is load shared across.
+	// Mutually exclusive with NextHop.
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	NextHops []StaticRouteNextHop `json:"nextHops,omitempty"`
 	// BFDProfile is the BFD profile for the static route.
 	BFDProfile *BFDProfile `json:"bfdProfile,omitempty"`
 }
 
+// StaticRouteNextHop is one of the next hops of a static route.
+// +kubebuilder:validation:XValidation:rule="!has(self.bfd) || !self.bfd || has(self.address)",message="bfd requires an address"
+type StaticRouteNextHop struct {
+	NextHop `json:",inline"`
+	// Weight is the relative weight of the next hop, traffic is shared in
+	// proportion to the weights. Next hops without weight have weight 1. FRR
+	// does not support weighted static routes and shares traffic equally.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=255
+	// +optional
+	Weight *uint32 `json:"weight,omitempty"`
+	// BFD tracks the next hop with BFD and withdraws it from the route while
+	// the session is down. On FRR the timers are taken from the route's
+	// BFDProfile.
+	// +optional
+	BFD bool `json:"bfd,omitempty"`
+}
+
+// AllNextHops returns the next hops of the route, NextHop or NextHops.
+func (r *StaticRoute) AllNextHops() []StaticRouteNextHop {
+	if r.NextHop != nil {
+		return []StaticRouteNextHop{{NextHop: *r.NextHop}}
+	}
+	return r.NextHops
+}
+
 // TrafficMatch represents a traffic match configuration.
 type TrafficMatch struct {
 	// SrcPrefix is the source prefix to match.
@@ -335,6 +465,78 @@ type NextHop struct {
 	Vrf *string `json:"vrf,omitempty"`
 }
 
+// ApplyStage is a stage of applying a NodeNetworkConfig on a node.
+type ApplyStage string
+
+const (
+	// ApplyStageNetlinkL2 creates, updates and deletes the Layer2 interfaces.
+	ApplyStageNetlinkL2 ApplyStage = "NetlinkL2"
+	// ApplyStageNetlinkL3 creates and deletes the VRF interfaces.
+	ApplyStageNetlinkL3 ApplyStage = "NetlinkL3"
+	// ApplyStageFRRReload reloads FRR with the new config.
+	ApplyStageFRRReload ApplyStage = "FRRReload"
+	// ApplyStagePolicyRoutes installs the policy routes as ip rules.
+	ApplyStagePolicyRoutes ApplyStage = "PolicyRoutes"
+	// ApplyStageMirror installs the traffic mirroring tunnels and filters.
+	ApplyStageMirror ApplyStage = "Mirror"
+	// ApplyStageVSRCommit commits the config to the vSR.
+	ApplyStageVSRCommit ApplyStage = "VSRCommit"
+)
+
+const (
+	// ReasonStageSucceeded is the reason of a stage condition whose stage succeeded.
+	ReasonStageSucceeded = "StageSucceeded"
+	// ReasonStageFailed is the reason of a stage condition whose stage failed.
+	ReasonStageFailed = "StageFailed"
+
+	// ConditionConfigDrift is True if the node's actual network config differs
+	// from the applied NodeNetworkConfig, e.g. because interfaces were removed
+	// or FRR was reconfigured by hand.
+	ConditionConfigDrift = "ConfigDrift"
+	// ReasonDriftDetected is the reason of a ConfigDrift condition that is True.
+	ReasonDriftDetected = "DriftDetected"
+	// ReasonNoDrift is the reason of a ConfigDrift condition that is False.
+	ReasonNoDrift = "NoDrift"
+	// ReasonDriftCheckFailed is the reason of a ConfigDrift condition that is
+	// Unknown as the node could not be checked.
+	ReasonDriftCheckFailed = "DriftCheckFailed"
+)
+
+// StageResult is the result of a stage of applying a NodeNetworkConfig.
+type StageResult struct {
+	// Stage is the applied stage.
+	Stage ApplyStage `json:"stage"`
+	// Duration is the time the stage took.
+	Duration metav1.Duration `json:"duration"`
+	// Error is the reason the stage failed, empty if it succeeded.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// SectionResult is a section of a NodeNetworkConfig that failed to be applied.
+type SectionResult struct {
+	// Section is the key of the section, e.g. "layer2s/100" or
+	// "fabricVRFs/m2m". Interfaces being removed that no section refers to
+	// anymore are given as "vrf:<name>" or "vlan:<id>".
+	Section string `json:"section"`
+	// Stage is the stage the section failed in.
+	Stage ApplyStage `json:"stage"`
+	// Error is the reason the section failed.
+	Error string `json:"error"`
+}
+
+// ApplyResults are the results of applying a NodeNetworkConfig, as reported
+// by the agent.
+type ApplyResults struct {
+	// Stages are the stages of applying the config in the order they ran,
+	// with their duration.
+	// +optional
+	Stages []StageResult `json:"stages,omitempty"`
+	// FailedSections are the Layer2s and VRFs that failed to be applied.
+	// +optional
+	FailedSections []SectionResult `json:"failedSections,omitempty"`
+}
+
 // NodeNetworkConfigStatus defines the observed state of NodeConfig.
 type NodeNetworkConfigStatus struct {
 	// ConfigStatus describes provisioning state of the NodeConfig. Can be either 'provisioning', 'provisioned' or 'invalid'.
@@ -354,6 +556,17 @@ type NodeNetworkConfigStatus struct {
 	// without needing access to the base config themselves. Zero means unset.
 	// +optional
 	ASNumber int64 `json:"asNumber,omitempty"`
+	// ApplyResults are the stage and section results of the last time the
+	// agent applied the config.
+	ApplyResults `json:",inline"`
+	// Conditions has one condition per stage of the last time the agent
+	// applied the config, e.g. NetlinkL2 or FRRReload. It is True if the
+	// stage succeeded and False with the error as message if it failed.
+	// Agents that detect drift additionally set the ConfigDrift condition.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
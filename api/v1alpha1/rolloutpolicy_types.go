@@ -0,0 +1,100 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DefaultRolloutPolicyName is the name of the RolloutPolicy honoured by the
+// operator. Only a single, cluster-wide policy is supported.
+const DefaultRolloutPolicyName = "default"
+
+// RolloutGroup is a set of nodes that is rolled out together before the
+// rollout moves on to the next group.
+type RolloutGroup struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Name identifies the group in the revision's rollout status.
+	Name string `json:"name"`
+
+	// NodeSelector selects the nodes of the group. Nodes already selected by an
+	// earlier group are not selected again. An empty selector selects all
+	// remaining nodes.
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// TopologyKey splits the group into one stage per distinct value of the
+	// given node label (e.g. topology.kubernetes.io/zone). Stages are rolled
+	// out one after another in lexical order of the label value; nodes without
+	// the label form the last stage of the group.
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// MaxUnavailable is the maximum number of nodes of a stage that are updated
+	// at the same time. It is either an absolute number or a percentage of the
+	// stage's nodes (rounded down, at least 1). Defaults to 1. The operator's
+	// --max-updating limit still applies on top of it.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// RequireApproval holds the rollout back before the first node of the group
+	// is updated until the revision is annotated with
+	// network.t-caas.telekom.com/rollout-approved=<group name> (or the name of
+	// a later group).
+	RequireApproval bool `json:"requireApproval,omitempty"`
+}
+
+// RolloutPolicySpec defines the order in which a NetworkConfigRevision is
+// rolled out to the nodes.
+type RolloutPolicySpec struct {
+	// +kubebuilder:validation:MinItems=1
+	// Groups are rolled out in the given order. Nodes that are not selected by
+	// any group are rolled out last, after all groups.
+	Groups []RolloutGroup `json:"groups"`
+
+	// SoakTime is the time to wait after a stage was fully provisioned before
+	// the next stage is started.
+	SoakTime *metav1.Duration `json:"soakTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=rp,scope=Cluster
+//+kubebuilder:validation:XValidation:rule="self.metadata.name == 'default'",message="only a RolloutPolicy named 'default' is supported"
+//+kubebuilder:printcolumn:name="SoakTime",type=string,JSONPath=`.spec.soakTime`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// RolloutPolicy is the Schema for the rolloutpolicies API. It configures a
+// staged rollout of NetworkConfigRevisions (e.g. canary nodes first, then one
+// zone at a time).
+type RolloutPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RolloutPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RolloutPolicyList contains a list of RolloutPolicy.
+type RolloutPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RolloutPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RolloutPolicy{}, &RolloutPolicyList{})
+}
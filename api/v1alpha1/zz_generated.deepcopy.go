@@ -23,8 +23,49 @@ package v1alpha1
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ASPathMatcher) DeepCopyInto(out *ASPathMatcher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ASPathMatcher.
+func (in *ASPathMatcher) DeepCopy() *ASPathMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(ASPathMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ASPathPrepend) DeepCopyInto(out *ASPathPrepend) {
+	*out = *in
+	if in.OwnASCount != nil {
+		in, out := &in.OwnASCount, &out.OwnASCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.ASNs != nil {
+		in, out := &in.ASNs, &out.ASNs
+		*out = make([]uint32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ASPathPrepend.
+func (in *ASPathPrepend) DeepCopy() *ASPathPrepend {
+	if in == nil {
+		return nil
+	}
+	out := new(ASPathPrepend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Action) DeepCopyInto(out *Action) {
 	*out = *in
@@ -75,6 +116,31 @@ func (in *AddressFamily) DeepCopy() *AddressFamily {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyResults) DeepCopyInto(out *ApplyResults) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]StageResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedSections != nil {
+		in, out := &in.FailedSections, &out.FailedSections
+		*out = make([]SectionResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyResults.
+func (in *ApplyResults) DeepCopy() *ApplyResults {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyResults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BFDProfile) DeepCopyInto(out *BFDProfile) {
 	*out = *in
@@ -368,6 +434,11 @@ func (in *FabricVRF) DeepCopyInto(out *FabricVRF) {
 		*out = new(Filter)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EVPNImportFilter != nil {
+		in, out := &in.EVPNImportFilter, &out.EVPNImportFilter
+		*out = new(Filter)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FabricVRF.
@@ -640,6 +711,11 @@ func (in *Matcher) DeepCopyInto(out *Matcher) {
 		*out = new(BGPCommunityMatcher)
 		**out = **in
 	}
+	if in.ASPath != nil {
+		in, out := &in.ASPath, &out.ASPath
+		*out = new(ASPathMatcher)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Matcher.
@@ -913,6 +989,16 @@ func (in *ModifyRouteAction) DeepCopyInto(out *ModifyRouteAction) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AddLargeCommunities != nil {
+		in, out := &in.AddLargeCommunities, &out.AddLargeCommunities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AddExtCommunities != nil {
+		in, out := &in.AddExtCommunities, &out.AddExtCommunities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.RemoveCommunities != nil {
 		in, out := &in.RemoveCommunities, &out.RemoveCommunities
 		*out = make([]string, len(*in))
@@ -923,6 +1009,31 @@ func (in *ModifyRouteAction) DeepCopyInto(out *ModifyRouteAction) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ASPathPrepend != nil {
+		in, out := &in.ASPathPrepend, &out.ASPathPrepend
+		*out = new(ASPathPrepend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SetLocalPreference != nil {
+		in, out := &in.SetLocalPreference, &out.SetLocalPreference
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.SetMED != nil {
+		in, out := &in.SetMED, &out.SetMED
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.SetWeight != nil {
+		in, out := &in.SetWeight, &out.SetWeight
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.SetNextHop != nil {
+		in, out := &in.SetNextHop, &out.SetNextHop
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModifyRouteAction.
@@ -1032,6 +1143,13 @@ func (in *NetworkConfigRevisionSpec) DeepCopyInto(out *NetworkConfigRevisionSpec
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeRevisions != nil {
+		in, out := &in.NodeRevisions, &out.NodeRevisions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkConfigRevisionSpec.
@@ -1051,6 +1169,16 @@ func (in *NetworkConfigRevisionStatus) DeepCopyInto(out *NetworkConfigRevisionSt
 		in, out := &in.FailedAt, &out.FailedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RevisionRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QuarantinedNodes != nil {
+		in, out := &in.QuarantinedNodes, &out.QuarantinedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkConfigRevisionStatus.
@@ -1282,6 +1410,14 @@ func (in *NodeNetworkConfigSpec) DeepCopy() *NodeNetworkConfigSpec {
 func (in *NodeNetworkConfigStatus) DeepCopyInto(out *NodeNetworkConfigStatus) {
 	*out = *in
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	in.ApplyResults.DeepCopyInto(&out.ApplyResults)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeNetworkConfigStatus.
@@ -1361,6 +1497,166 @@ func (in *Redistribute) DeepCopy() *Redistribute {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionRolloutStatus) DeepCopyInto(out *RevisionRolloutStatus) {
+	*out = *in
+	if in.SoakingUntil != nil {
+		in, out := &in.SoakingUntil, &out.SoakingUntil
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionRolloutStatus.
+func (in *RevisionRolloutStatus) DeepCopy() *RevisionRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutGroup) DeepCopyInto(out *RolloutGroup) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutGroup.
+func (in *RolloutGroup) DeepCopy() *RolloutGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPolicy) DeepCopyInto(out *RolloutPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPolicy.
+func (in *RolloutPolicy) DeepCopy() *RolloutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RolloutPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPolicyList) DeepCopyInto(out *RolloutPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RolloutPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPolicyList.
+func (in *RolloutPolicyList) DeepCopy() *RolloutPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RolloutPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPolicySpec) DeepCopyInto(out *RolloutPolicySpec) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]RolloutGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SoakTime != nil {
+		in, out := &in.SoakTime, &out.SoakTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPolicySpec.
+func (in *RolloutPolicySpec) DeepCopy() *RolloutPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SectionResult) DeepCopyInto(out *SectionResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SectionResult.
+func (in *SectionResult) DeepCopy() *SectionResult {
+	if in == nil {
+		return nil
+	}
+	out := new(SectionResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageResult) DeepCopyInto(out *StageResult) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageResult.
+func (in *StageResult) DeepCopy() *StageResult {
+	if in == nil {
+		return nil
+	}
+	out := new(StageResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticRoute) DeepCopyInto(out *StaticRoute) {
 	*out = *in
@@ -1369,6 +1665,13 @@ func (in *StaticRoute) DeepCopyInto(out *StaticRoute) {
 		*out = new(NextHop)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NextHops != nil {
+		in, out := &in.NextHops, &out.NextHops
+		*out = make([]StaticRouteNextHop, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.BFDProfile != nil {
 		in, out := &in.BFDProfile, &out.BFDProfile
 		*out = new(BFDProfile)
@@ -1386,6 +1689,27 @@ func (in *StaticRoute) DeepCopy() *StaticRoute {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticRouteNextHop) DeepCopyInto(out *StaticRouteNextHop) {
+	*out = *in
+	in.NextHop.DeepCopyInto(&out.NextHop)
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticRouteNextHop.
+func (in *StaticRouteNextHop) DeepCopy() *StaticRouteNextHop {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticRouteNextHop)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrafficMatch) DeepCopyInto(out *TrafficMatch) {
 	*out = *in
@@ -41,9 +41,11 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	networkconnector "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
 	controllerfrr "github.com/telekom/das-schiff-network-operator/controllers/agent-cra-frr"
 	"github.com/telekom/das-schiff-network-operator/pkg/cra-frr"
 	"github.com/telekom/das-schiff-network-operator/pkg/monitoring"
+	"github.com/telekom/das-schiff-network-operator/pkg/nodestatus"
 	reconcilerfrr "github.com/telekom/das-schiff-network-operator/pkg/reconciler/agent-cra-frr"
 	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/common"
 	"github.com/telekom/das-schiff-network-operator/pkg/version"
@@ -58,6 +60,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(networkv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(networkconnector.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -104,10 +107,33 @@ func main() {
 	var nodeNetworkConfigPath string
 	var healthAddr string
 	var metricsAddr string
+	var reconcilerOpts reconcilerfrr.Options
+	var applyAPIOpts common.ApplyAPIOptions
+	var publishNodeStatus bool
 	flag.StringVar(&nodeNetworkConfigPath, "nodenetworkconfig-path", common.DefaultNodeNetworkConfigPath,
 		"Path to store working node configuration.")
 	flag.StringVar(&healthAddr, "health-addr", ":7081", "bind address of health/readiness probes")
 	flag.StringVar(&metricsAddr, "metrics-addr", ":7080", "bind address of metrics endpoint")
+	flag.StringVar(&applyAPIOpts.BindAddress, "apply-api-addr", common.DefaultApplyAPIAddr,
+		"bind address of the https apply API (0 disables it)")
+	flag.StringVar(&applyAPIOpts.CertDir, "apply-api-cert-dir", "",
+		"directory with the tls.crt and tls.key of the apply API, a self-signed certificate is used if empty")
+	flag.BoolVar(&reconcilerOpts.IsolateFailures, "isolate-section-failures", false,
+		"apply Layer2s and VRFs independently: failing ones are skipped and reported in the NodeNetworkConfig status "+
+			"instead of failing and restoring the whole config")
+	flag.BoolVar(&publishNodeStatus, "publish-node-status", true,
+		"publish the interfaces and routes of the CRA's network namespace as NodeNetworkStatus")
+	flag.DurationVar(&reconcilerOpts.ConfirmTimeout, "confirm-timeout", 0,
+		"time a new config must pass the reachability and API server checks in, otherwise the previous config is restored (0 disables confirmed apply)")
+	flag.DurationVar(&reconcilerOpts.HealthMonitorWindow, "health-monitor-window", 0,
+		"time after a config was provisioned during which the node's health is re-evaluated every 30s and published "+
+			"as Node conditions, should cover the operator's --health-window (0 disables it)")
+	flag.DurationVar(&reconcilerOpts.DriftCheckInterval, "drift-check-interval", 0,
+		"interval to check the node for drift from the applied config at, each check runs frr-reload.py --test "+
+			"in the CRA (0 disables drift detection)")
+	flag.BoolVar(&reconcilerOpts.ReapplyOnDrift, "reapply-on-drift", false, "re-apply the config when drift is detected")
+	flag.DurationVar(&reconcilerOpts.RestartCheckInterval, "restart-check-interval", common.DefaultRestartCheckInterval,
+		"interval to check the CRA for a restart at, the config is replayed when it restarted (0 disables the check)")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -141,7 +167,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := initComponents(mgr, nodeNetworkConfigPath, craManager); err != nil {
+	if err := initComponents(mgr, nodeNetworkConfigPath, craManager, reconcilerOpts, applyAPIOpts, publishNodeStatus); err != nil {
 		setupLog.Error(err, "unable to initialize components")
 		os.Exit(1)
 	}
@@ -168,7 +194,14 @@ func updateManagerOptions(options *manager.Options, craManager *cra.Manager) err
 	return nil
 }
 
-func initComponents(mgr manager.Manager, nodeConfigPath string, craManager *cra.Manager) error {
+func initComponents(
+	mgr manager.Manager,
+	nodeConfigPath string,
+	craManager *cra.Manager,
+	reconcilerOpts reconcilerfrr.Options,
+	applyAPIOpts common.ApplyAPIOptions,
+	publishNodeStatus bool,
+) error {
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -178,7 +211,13 @@ func initComponents(mgr manager.Manager, nodeConfigPath string, craManager *cra.
 		return fmt.Errorf("unable to set up ready check: %w", err)
 	}
 
-	r, err := setupReconcilers(mgr, nodeConfigPath, craManager)
+	if publishNodeStatus {
+		if err := nodestatus.AddToManager(mgr, craManager); err != nil {
+			return fmt.Errorf("unable to set up NodeNetworkStatus publisher: %w", err)
+		}
+	}
+
+	r, err := setupReconcilers(mgr, nodeConfigPath, craManager, reconcilerOpts, applyAPIOpts)
 	if err != nil {
 		return fmt.Errorf("unable to setup reconcilers: %w", err)
 	}
@@ -191,8 +230,15 @@ func initComponents(mgr manager.Manager, nodeConfigPath string, craManager *cra.
 	return nil
 }
 
-func setupReconcilers(mgr manager.Manager, nodeConfigPath string, craManager *cra.Manager) (*reconcilerfrr.NodeNetworkConfigReconciler, error) {
-	r, err := reconcilerfrr.NewNodeNetworkConfigReconciler(craManager, mgr.GetClient(), mgr.GetLogger(), nodeConfigPath)
+func setupReconcilers(
+	mgr manager.Manager,
+	nodeConfigPath string,
+	craManager *cra.Manager,
+	reconcilerOpts reconcilerfrr.Options,
+	applyAPIOpts common.ApplyAPIOptions,
+) (*reconcilerfrr.NodeNetworkConfigReconciler, error) {
+	reconcilerOpts.EventRecorder = mgr.GetEventRecorder("agent-cra-frr")
+	r, err := reconcilerfrr.NewNodeNetworkConfigReconciler(craManager, mgr.GetClient(), mgr.GetLogger(), nodeConfigPath, reconcilerOpts)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create debounced reconciler: %w", err)
 	}
@@ -205,6 +251,10 @@ func setupReconcilers(mgr manager.Manager, nodeConfigPath string, craManager *cr
 		return nil, fmt.Errorf("unable to create NodeConfig controller: %w", err)
 	}
 
+	if err := common.AddApplyAPIToManager(mgr, r.NodeNetworkConfigReconciler, applyAPIOpts); err != nil {
+		return nil, fmt.Errorf("unable to set up apply API: %w", err)
+	}
+
 	return r, nil
 }
 
@@ -43,9 +43,11 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	networkconnector "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
 	controllervsr "github.com/telekom/das-schiff-network-operator/controllers/agent-cra-vsr"
 	"github.com/telekom/das-schiff-network-operator/pkg/cra-vsr"
 	"github.com/telekom/das-schiff-network-operator/pkg/monitoring"
+	"github.com/telekom/das-schiff-network-operator/pkg/nodestatus"
 	reconcilervsr "github.com/telekom/das-schiff-network-operator/pkg/reconciler/agent-cra-vsr"
 	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/common"
 	"github.com/telekom/das-schiff-network-operator/pkg/version"
@@ -59,6 +61,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(networkv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(networkconnector.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -187,9 +190,12 @@ func setupReconcilers(
 	mgr manager.Manager,
 	nodeConfigPath string,
 	craManager *cra.Manager,
+	reconcilerOpts reconcilervsr.Options,
+	applyAPIOpts common.ApplyAPIOptions,
 ) (*reconcilervsr.NodeNetworkConfigReconciler, error) {
+	reconcilerOpts.EventRecorder = mgr.GetEventRecorder("agent-cra-vsr")
 	r, err := reconcilervsr.NewNodeNetworkConfigReconciler(
-		craManager, mgr.GetClient(), mgr.GetLogger(), nodeConfigPath)
+		craManager, mgr.GetClient(), mgr.GetLogger(), nodeConfigPath, reconcilerOpts)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create debounced reconciler: %w", err)
 	}
@@ -202,10 +208,21 @@ func setupReconcilers(
 		return nil, fmt.Errorf("unable to create NodeConfig controller: %w", err)
 	}
 
+	if err := common.AddApplyAPIToManager(mgr, r.NodeNetworkConfigReconciler, applyAPIOpts); err != nil {
+		return nil, fmt.Errorf("unable to set up apply API: %w", err)
+	}
+
 	return r, nil
 }
 
-func initComponents(mgr manager.Manager, nodeConfigPath string, craManager *cra.Manager) error {
+func initComponents(
+	mgr manager.Manager,
+	nodeConfigPath string,
+	craManager *cra.Manager,
+	reconcilerOpts reconcilervsr.Options,
+	applyAPIOpts common.ApplyAPIOptions,
+	publishNodeStatus bool,
+) error {
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -215,7 +232,13 @@ func initComponents(mgr manager.Manager, nodeConfigPath string, craManager *cra.
 		return fmt.Errorf("unable to set up ready check: %w", err)
 	}
 
-	r, err := setupReconcilers(mgr, nodeConfigPath, craManager)
+	if publishNodeStatus {
+		if err := nodestatus.AddToManager(mgr, nil); err != nil {
+			return fmt.Errorf("unable to set up NodeNetworkStatus publisher: %w", err)
+		}
+	}
+
+	r, err := setupReconcilers(mgr, nodeConfigPath, craManager, reconcilerOpts, applyAPIOpts)
 	if err != nil {
 		return fmt.Errorf("unable to setup reconcilers: %w", err)
 	}
@@ -233,6 +256,9 @@ func main() {
 	var metricsAddr string
 	var healthAddr string
 	var opts zap.Options
+	var reconcilerOpts reconcilervsr.Options
+	var applyAPIOpts common.ApplyAPIOptions
+	var publishNodeStatus bool
 
 	version.Get().Print(os.Args[0])
 
@@ -241,9 +267,22 @@ func main() {
 
 	flag.StringVar(&healthAddr, "health-addr", ":7081", "bind address of health/readiness probes")
 	flag.StringVar(&metricsAddr, "metrics-addr", ":7080", "bind address of metrics endpoint")
+	flag.StringVar(&applyAPIOpts.BindAddress, "apply-api-addr", common.DefaultApplyAPIAddr,
+		"bind address of the https apply API (0 disables it)")
+	flag.StringVar(&applyAPIOpts.CertDir, "apply-api-cert-dir", "",
+		"directory with the tls.crt and tls.key of the apply API, a self-signed certificate is used if empty")
 	flag.StringVar(&nodeNetworkConfigPath, "nodenetworkconfig-path",
 		common.DefaultNodeNetworkConfigPath,
 		"Path to store working node configuration.")
+	flag.BoolVar(&publishNodeStatus, "publish-node-status", true,
+		"publish the host's interfaces and routes as NodeNetworkStatus, the vSR's VRFs are not included")
+	flag.DurationVar(&reconcilerOpts.ConfirmTimeout, "confirm-timeout", 0,
+		"time a new config must pass the reachability and API server checks in, otherwise the previous config is restored (0 disables confirmed apply)")
+	flag.DurationVar(&reconcilerOpts.HealthMonitorWindow, "health-monitor-window", 0,
+		"time after a config was provisioned during which the node's health is re-evaluated every 30s and published "+
+			"as Node conditions, should cover the operator's --health-window (0 disables it)")
+	flag.DurationVar(&reconcilerOpts.RestartCheckInterval, "restart-check-interval", common.DefaultRestartCheckInterval,
+		"interval to check the vSR for a restart at, the config is replayed after a restart (0 disables the check)")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
@@ -274,7 +313,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := initComponents(mgr, nodeNetworkConfigPath, craManager); err != nil {
+	if err := initComponents(mgr, nodeNetworkConfigPath, craManager, reconcilerOpts, applyAPIOpts, publishNodeStatus); err != nil {
 		setupLog.Error(err, "unable to initialize components")
 		os.Exit(1)
 	}
@@ -36,7 +36,9 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	networkconnector "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
 	controllernetplan "github.com/telekom/das-schiff-network-operator/controllers/agent-netplan"
+	"github.com/telekom/das-schiff-network-operator/pkg/nodestatus"
 	reconcilernetplan "github.com/telekom/das-schiff-network-operator/pkg/reconciler/agent-netplan"
 	"github.com/telekom/das-schiff-network-operator/pkg/version"
 )
@@ -50,6 +52,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(networkv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(networkconnector.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -58,8 +61,11 @@ func main() {
 
 	var healthAddr string
 	var metricsAddr string
+	var publishNodeStatus bool
 	flag.StringVar(&healthAddr, "health-addr", ":7083", "bind address of health/readiness probes")
 	flag.StringVar(&metricsAddr, "metrics-addr", ":7082", "bind address of metrics endpoint")
+	flag.BoolVar(&publishNodeStatus, "publish-node-status", false,
+		"publish the host's interfaces and routes as NodeNetworkStatus, enable it on nodes without a CRA agent only")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -82,7 +88,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := initComponents(mgr); err != nil {
+	if err := initComponents(mgr, publishNodeStatus); err != nil {
 		setupLog.Error(err, "unable to initialize components")
 		os.Exit(1)
 	}
@@ -94,7 +100,7 @@ func main() {
 	}
 }
 
-func initComponents(mgr manager.Manager) error {
+func initComponents(mgr manager.Manager, publishNodeStatus bool) error {
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -104,6 +110,12 @@ func initComponents(mgr manager.Manager) error {
 		return fmt.Errorf("unable to set up ready check: %w", err)
 	}
 
+	if publishNodeStatus {
+		if err := nodestatus.AddToManager(mgr, nil); err != nil {
+			return fmt.Errorf("unable to set up NodeNetworkStatus publisher: %w", err)
+		}
+	}
+
 	r, err := setupReconcilers(mgr)
 	if err != nil {
 		return fmt.Errorf("unable to setup reconcilers: %w", err)
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/cra-frr"
+	"github.com/telekom/das-schiff-network-operator/pkg/nl"
+)
+
+// itemError is the error of a single Layer2 or VRF.
+type itemError struct {
+	vlanID int
+	vrf    string
+	err    error
+}
+
+func (e *itemError) Error() string { return e.err.Error() }
+
+func (e *itemError) Unwrap() error { return e.err }
+
+func layer2Error(vlanID int, err error) error {
+	return &itemError{vlanID: vlanID, err: err}
+}
+
+func vrfError(name string, err error) error {
+	return &itemError{vrf: name, err: err}
+}
+
+// applyRecorder records the stages of a configuration request with their
+// duration and the Layer2s and VRFs that failed, for the response.
+type applyRecorder struct {
+	result cra.ApplyResult
+	// isolate applies the Layer2s and VRFs independently: the ones that fail
+	// are recorded and skipped, the others are still applied.
+	isolate bool
+	// stage is the stage being run.
+	stage v1alpha1.ApplyStage
+}
+
+// run runs fn as part of stage. A stage may be run in several steps, their
+// durations are added up.
+func (a *applyRecorder) run(stage v1alpha1.ApplyStage, fn func() error) error {
+	a.stage = stage
+	failed := len(a.result.Failed)
+	started := time.Now()
+	err := fn()
+	a.record(stage, time.Since(started), err)
+	if skipped := len(a.result.Failed) - failed; err == nil && skipped > 0 {
+		a.result.Stages[a.stageIndex(stage)].Error = fmt.Sprintf("%d Layer2s/VRFs failed and were skipped", skipped)
+	}
+	return err
+}
+
+// itemFailed handles the error of a single Layer2 or VRF, created with
+// layer2Error or vrfError. If failures are isolated, the error is recorded
+// and nil is returned so the remaining Layer2s and VRFs are still applied.
+func (a *applyRecorder) itemFailed(err error) error {
+	if !a.isolate {
+		return err
+	}
+	log.Print(logSanitizer.Replace(fmt.Sprintf("Skipping failed section: %v", err)))
+	a.addFailed(a.stage, err)
+	return nil
+}
+
+func (a *applyRecorder) stageIndex(stage v1alpha1.ApplyStage) int {
+	for i := range a.result.Stages {
+		if a.result.Stages[i].Stage == stage {
+			return i
+		}
+	}
+	a.result.Stages = append(a.result.Stages, v1alpha1.StageResult{Stage: stage})
+	return len(a.result.Stages) - 1
+}
+
+func (a *applyRecorder) record(stage v1alpha1.ApplyStage, duration time.Duration, err error) {
+	result := &a.result.Stages[a.stageIndex(stage)]
+	result.Duration.Duration += duration
+	if err == nil {
+		return
+	}
+	result.Error = err.Error()
+	a.addFailed(stage, err)
+}
+
+// addFailed records the Layer2 or VRF that err is the error of, if any.
+func (a *applyRecorder) addFailed(stage v1alpha1.ApplyStage, err error) {
+	var item *itemError
+	if errors.As(err, &item) {
+		a.result.Failed = append(a.result.Failed, cra.FailedItem{
+			Stage:  stage,
+			VlanID: item.vlanID,
+			VRF:    item.vrf,
+			Error:  item.err.Error(),
+		})
+	}
+}
+
+// write writes the recorded result as response, failing the request if err
+// is not nil.
+func (a *applyRecorder) write(w http.ResponseWriter, err error) {
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusInternalServerError
+		a.result.Error = err.Error()
+	}
+
+	data, marshalErr := json.Marshal(a.result)
+	if marshalErr != nil {
+		log.Println("Failed to marshal apply result", marshalErr)
+		data = nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(data); err != nil {
+		log.Println("Failed to write response", err)
+	}
+}
+
+// dropFailedSections rewrites the FRR configuration of cfg without the
+// sections of the failed Layer2s and VRFs, so FRR is not reloaded with
+// sections whose netlink setup was skipped. It returns true if the
+// configuration was changed.
+func dropFailedSections(cfg *cra.Configuration, failed []cra.FailedItem) (bool, error) {
+	if len(failed) == 0 {
+		return false, nil
+	}
+	frrConfig := withoutFailedSections(cfg.FRRConfiguration, cfg.NetlinkConfiguration.Layer2s, failed)
+	if frrConfig == cfg.FRRConfiguration {
+		return false, nil
+	}
+	if err := writeFRRConfig(frrConfig); err != nil {
+		return false, err
+	}
+	cfg.FRRConfiguration = frrConfig
+	return true, nil
+}
+
+// withoutFailedSections removes the sections of the failed Layer2s and VRFs
+// from the FRR configuration: the vrf and router bgp blocks and the imports of
+// a VRF, the vni block of a Layer2 in the EVPN address family.
+func withoutFailedSections(frrConfig string, layer2s []nl.Layer2Information, failed []cra.FailedItem) string {
+	vrfs := map[string]bool{}
+	vnis := map[string]bool{}
+	for _, item := range failed {
+		if item.VRF != "" {
+			vrfs[item.VRF] = true
+			continue
+		}
+		for i := range layer2s {
+			if layer2s[i].VlanID == item.VlanID {
+				vnis[strconv.Itoa(layer2s[i].VNI)] = true
+			}
+		}
+	}
+
+	lines := strings.Split(frrConfig, "\n")
+	kept := make([]string, 0, len(lines))
+	// end is the line that ends the block being removed.
+	end := ""
+	inEVPN := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if end != "" {
+			if trimmed == end {
+				end = ""
+			}
+			continue
+		}
+
+		fields := strings.Fields(trimmed)
+		switch {
+		case len(fields) == 2 && fields[0] == "vrf" && vrfs[fields[1]]:
+			end = "exit-vrf"
+			continue
+		case len(fields) == 5 && fields[0] == "router" && fields[1] == "bgp" && fields[3] == "vrf" && vrfs[fields[4]]:
+			end = "exit"
+			continue
+		case len(fields) == 3 && fields[0] == "import" && fields[1] == "vrf" && vrfs[fields[2]]:
+			continue
+		case inEVPN && len(fields) == 2 && fields[0] == "vni" && vnis[fields[1]]:
+			end = "exit-vni"
+			continue
+		case trimmed == "address-family l2vpn evpn":
+			inEVPN = true
+		case trimmed == "exit-address-family":
+			inEVPN = false
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
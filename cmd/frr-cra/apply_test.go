@@ -0,0 +1,259 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/cra-frr"
+	"github.com/telekom/das-schiff-network-operator/pkg/nl"
+)
+
+func TestApplyRecorder(t *testing.T) {
+	errReload := errors.New("reload failed")
+
+	tests := []struct {
+		name    string
+		isolate bool
+		// items are the errors of the Layer2s and VRFs of the NetlinkL3 stage.
+		items []error
+		// stageErr is the error the NetlinkL3 stage fails with.
+		stageErr   error
+		wantErr    bool
+		wantStatus int
+		want       cra.ApplyResult
+	}{
+		{
+			name:       "success",
+			wantStatus: http.StatusOK,
+			want: cra.ApplyResult{Stages: []v1alpha1.StageResult{
+				{Stage: v1alpha1.ApplyStageNetlinkL2},
+				{Stage: v1alpha1.ApplyStageNetlinkL3},
+			}},
+		},
+		{
+			name:       "failed item aborts",
+			items:      []error{vrfError("m2m", errors.New("no such device"))},
+			wantErr:    true,
+			wantStatus: http.StatusInternalServerError,
+			want: cra.ApplyResult{
+				Stages: []v1alpha1.StageResult{
+					{Stage: v1alpha1.ApplyStageNetlinkL2},
+					{Stage: v1alpha1.ApplyStageNetlinkL3, Error: "no such device"},
+				},
+				Failed: []cra.FailedItem{{Stage: v1alpha1.ApplyStageNetlinkL3, VRF: "m2m", Error: "no such device"}},
+				Error:  "no such device",
+			},
+		},
+		{
+			name:    "failed items are isolated",
+			isolate: true,
+			items: []error{
+				vrfError("m2m", errors.New("no such device")),
+				layer2Error(100, errors.New("vlan exists")),
+			},
+			wantStatus: http.StatusOK,
+			want: cra.ApplyResult{
+				Stages: []v1alpha1.StageResult{
+					{Stage: v1alpha1.ApplyStageNetlinkL2},
+					{Stage: v1alpha1.ApplyStageNetlinkL3, Error: "2 Layer2s/VRFs failed and were skipped"},
+				},
+				Failed: []cra.FailedItem{
+					{Stage: v1alpha1.ApplyStageNetlinkL3, VRF: "m2m", Error: "no such device"},
+					{Stage: v1alpha1.ApplyStageNetlinkL3, VlanID: 100, Error: "vlan exists"},
+				},
+			},
+		},
+		{
+			name:       "stage error is not isolated",
+			isolate:    true,
+			stageErr:   errReload,
+			wantErr:    true,
+			wantStatus: http.StatusInternalServerError,
+			want: cra.ApplyResult{
+				Stages: []v1alpha1.StageResult{
+					{Stage: v1alpha1.ApplyStageNetlinkL2},
+					{Stage: v1alpha1.ApplyStageNetlinkL3, Error: "reload failed"},
+				},
+				Error: "reload failed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &applyRecorder{isolate: tt.isolate}
+
+			err := rec.run(v1alpha1.ApplyStageNetlinkL2, func() error { return nil })
+			require.NoError(t, err)
+			err = rec.run(v1alpha1.ApplyStageNetlinkL3, func() error {
+				for _, item := range tt.items {
+					if err := rec.itemFailed(item); err != nil {
+						return err
+					}
+				}
+				return tt.stageErr
+			})
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			w := httptest.NewRecorder()
+			rec.write(w, err)
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			var got cra.ApplyResult
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			for i := range got.Stages {
+				got.Stages[i].Duration.Duration = 0
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyRecorderAddsUpStageDurations(t *testing.T) {
+	rec := &applyRecorder{}
+	for range 2 {
+		require.NoError(t, rec.run(v1alpha1.ApplyStageFRRReload, func() error {
+			time.Sleep(time.Millisecond)
+			return nil
+		}))
+	}
+	require.Len(t, rec.result.Stages, 1)
+	assert.GreaterOrEqual(t, rec.result.Stages[0].Duration.Duration, 2*time.Millisecond)
+}
+
+const testFRRConfig = `vrf m2m
+  vni 2000
+exit-vrf
+!
+vrf c2m
+  vni 3000
+exit-vrf
+!
+router bgp 64510 vrf m2m
+  address-family ipv4 unicast
+    import vrf c2m
+    import vrf route-map rm_m2m_import
+  exit-address-family
+exit
+!
+router bgp 64510 vrf c2m
+  address-family ipv4 unicast
+    import vrf m2m
+    import vrf route-map rm_c2m_import
+  exit-address-family
+exit
+!
+router bgp 64510
+  address-family l2vpn evpn
+    advertise-all-vni
+    vni 1100
+      route-target export 64510:1100
+      route-target import 64510:1100
+    exit-vni
+    vni 1200
+      route-target export 64510:1200
+      route-target import 64510:1200
+    exit-vni
+  exit-address-family
+exit
+!`
+
+func TestWithoutFailedSections(t *testing.T) {
+	layer2s := []nl.Layer2Information{{VlanID: 100, VNI: 1100}, {VlanID: 200, VNI: 1200}}
+
+	tests := []struct {
+		name   string
+		failed []cra.FailedItem
+		want   string
+	}{
+		{
+			name: "nothing failed",
+			want: testFRRConfig,
+		},
+		{
+			name:   "deleted layer2 is not in the config",
+			failed: []cra.FailedItem{{VlanID: 300}},
+			want:   testFRRConfig,
+		},
+		{
+			name:   "failed vrf",
+			failed: []cra.FailedItem{{VRF: "c2m"}},
+			want: `vrf m2m
+  vni 2000
+exit-vrf
+!
+!
+router bgp 64510 vrf m2m
+  address-family ipv4 unicast
+    import vrf route-map rm_m2m_import
+  exit-address-family
+exit
+!
+!
+router bgp 64510
+  address-family l2vpn evpn
+    advertise-all-vni
+    vni 1100
+      route-target export 64510:1100
+      route-target import 64510:1100
+    exit-vni
+    vni 1200
+      route-target export 64510:1200
+      route-target import 64510:1200
+    exit-vni
+  exit-address-family
+exit
+!`,
+		},
+		{
+			name:   "failed layer2",
+			failed: []cra.FailedItem{{VlanID: 100}},
+			want: `vrf m2m
+  vni 2000
+exit-vrf
+!
+vrf c2m
+  vni 3000
+exit-vrf
+!
+router bgp 64510 vrf m2m
+  address-family ipv4 unicast
+    import vrf c2m
+    import vrf route-map rm_m2m_import
+  exit-address-family
+exit
+!
+router bgp 64510 vrf c2m
+  address-family ipv4 unicast
+    import vrf m2m
+    import vrf route-map rm_c2m_import
+  exit-address-family
+exit
+!
+router bgp 64510
+  address-family l2vpn evpn
+    advertise-all-vni
+    vni 1200
+      route-target export 64510:1200
+      route-target import 64510:1200
+    exit-vni
+  exit-address-family
+exit
+!`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, withoutFailedSections(testFRRConfig, layer2s, tt.failed))
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/telekom/das-schiff-network-operator/pkg/cra-frr"
+	"github.com/telekom/das-schiff-network-operator/pkg/nl"
+)
+
+const (
+	frrReloadScript  = "/usr/lib/frr/frr-reload.py"
+	frrReloadTimeout = 30 * time.Second
+
+	// driftEventDelay is the time netlink events are coalesced for before the
+	// netlink state is checked.
+	driftEventDelay = 5 * time.Second
+)
+
+// driftDetector compares the last applied configuration with the actual
+// netlink and FRR state. The full state is checked periodically, the netlink
+// state also shortly after link, address and user route events.
+type driftDetector struct {
+	// mu guards the fields below. applyMu is taken before mu.
+	mu      sync.Mutex
+	applied *cra.Configuration
+	// skipped are the Layer2s (by VLAN ID) and VRFs (by name) that failed in
+	// the last apply, they are not reported as drift.
+	skipped   map[string]bool
+	appliedAt time.Time
+	nlItems   []cra.DriftItem
+	frrItems  []cra.DriftItem
+	report    cra.DriftReport
+	// periodic is set if the state is checked periodically, otherwise it is
+	// checked when the report is requested.
+	periodic bool
+
+	trigger chan struct{}
+}
+
+var drift = &driftDetector{trigger: make(chan struct{}, 1)}
+
+// setApplied records the configuration that was applied and the Layer2s and
+// VRFs that failed to be applied.
+func (d *driftDetector) setApplied(cfg *cra.Configuration, failed []cra.FailedItem) {
+	skipped := make(map[string]bool, len(failed))
+	for _, item := range failed {
+		if item.VRF != "" {
+			skipped[vrfSection(item.VRF)] = true
+		} else {
+			skipped[layer2Section(item.VlanID)] = true
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.applied = cfg
+	d.skipped = skipped
+	d.appliedAt = time.Now()
+}
+
+// requestCheck schedules a check of the netlink state.
+func (d *driftDetector) requestCheck() {
+	select {
+	case d.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// run checks for drift every interval and after netlink events.
+func (d *driftDetector) run(interval time.Duration) {
+	d.mu.Lock()
+	d.periodic = true
+	d.mu.Unlock()
+	d.subscribe()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			d.check(true)
+		case <-d.trigger:
+			time.Sleep(driftEventDelay)
+			select {
+			case <-d.trigger:
+			default:
+			}
+			d.check(false)
+		}
+	}
+}
+
+// subscribe requests a check on netlink link and address events and on route
+// events of routes not installed by the kernel or FRR, i.e. by hand.
+func (d *driftDetector) subscribe() {
+	links := make(chan netlink.LinkUpdate)
+	if err := netlink.LinkSubscribe(links, nil); err != nil {
+		log.Println("Failed to subscribe to netlink link events:", err)
+		links = nil
+	}
+	addrs := make(chan netlink.AddrUpdate)
+	if err := netlink.AddrSubscribe(addrs, nil); err != nil {
+		log.Println("Failed to subscribe to netlink address events:", err)
+		addrs = nil
+	}
+	routes := make(chan netlink.RouteUpdate)
+	if err := netlink.RouteSubscribe(routes, nil); err != nil {
+		log.Println("Failed to subscribe to netlink route events:", err)
+		routes = nil
+	}
+
+	go func() {
+		for links != nil || addrs != nil || routes != nil {
+			select {
+			case _, ok := <-links:
+				if !ok {
+					links = nil
+					continue
+				}
+			case _, ok := <-addrs:
+				if !ok {
+					addrs = nil
+					continue
+				}
+			case update, ok := <-routes:
+				if !ok {
+					routes = nil
+					continue
+				}
+				if update.Protocol != unix.RTPROT_BOOT && update.Protocol != unix.RTPROT_STATIC {
+					continue
+				}
+			}
+			d.requestCheck()
+		}
+		log.Println("Netlink subscriptions closed, drift is only checked periodically")
+	}()
+}
+
+// check compares the applied configuration with the netlink state and, if
+// withFRR is set, with the FRR running config.
+func (d *driftDetector) check(withFRR bool) {
+	applyMu.Lock()
+	defer applyMu.Unlock()
+
+	d.mu.Lock()
+	applied, skipped := d.applied, d.skipped
+	d.mu.Unlock()
+	if applied == nil {
+		return
+	}
+
+	var errs []string
+	nlItems, err := netlinkDrift(&applied.NetlinkConfiguration)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	var frrItems []cra.DriftItem
+	if withFRR {
+		if frrItems, err = frrDrift(applied.FRRConfiguration, frrConfigPath, frrReloadScript); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.nlItems = nlItems
+	if withFRR {
+		d.frrItems = frrItems
+	}
+	d.report = cra.DriftReport{Applied: true, Checked: time.Now(), Error: strings.Join(errs, "; ")}
+	for _, item := range slices.Concat(d.nlItems, d.frrItems) {
+		if !skipped[item.Section] {
+			d.report.Items = append(d.report.Items, item)
+		}
+	}
+	if len(d.report.Items) > 0 {
+		log.Print(logSanitizer.Replace(fmt.Sprintf("Configuration drift detected: %v", d.report.Items)))
+	}
+}
+
+// serveDrift serves the DriftReport of the last check. If the configuration
+// was applied after the last full check or is not checked periodically, it is
+// checked first.
+func serveDrift(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	drift.mu.Lock()
+	stale := drift.applied != nil && (!drift.periodic || drift.report.Checked.Before(drift.appliedAt))
+	drift.mu.Unlock()
+	if stale {
+		drift.check(true)
+	}
+
+	drift.mu.Lock()
+	data, err := json.Marshal(drift.report)
+	drift.mu.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		log.Println("Failed to write response", err)
+	}
+}
+
+func layer2Section(vlanID int) string { return "layer2/" + strconv.Itoa(vlanID) }
+
+func vrfSection(name string) string { return "vrf/" + name }
+
+// netlinkDrift compares the Layer2s and VRFs of cfg with the ones on the node.
+func netlinkDrift(cfg *nl.NetlinkConfiguration) ([]cra.DriftItem, error) {
+	layer2s, err := nlManager.ListL2()
+	if err != nil {
+		return nil, fmt.Errorf("error listing L2: %w", err)
+	}
+	vrfs, err := nlManager.ListL3()
+	if err != nil {
+		return nil, fmt.Errorf("error listing L3 VRF information: %w", err)
+	}
+	return append(layer2Drift(cfg.Layer2s, layer2s), vrfDrift(cfg.VRFs, vrfs)...), nil
+}
+
+func layer2Drift(desired, actual []nl.Layer2Information) []cra.DriftItem {
+	var items []cra.DriftItem
+	for i := range desired {
+		want := &desired[i]
+		idx := slices.IndexFunc(actual, func(l2 nl.Layer2Information) bool { return l2.VlanID == want.VlanID })
+		if idx < 0 {
+			items = append(items, cra.DriftItem{Section: layer2Section(want.VlanID), Reason: "missing"})
+			continue
+		}
+		have := &actual[idx]
+
+		var reasons []string
+		if have.VNI != want.VNI {
+			reasons = append(reasons, fmt.Sprintf("VNI %d, want %d", have.VNI, want.VNI))
+		}
+		if have.VRF != want.VRF {
+			reasons = append(reasons, fmt.Sprintf("VRF %q, want %q", have.VRF, want.VRF))
+		}
+		if want.MTU != 0 && have.MTU != want.MTU {
+			reasons = append(reasons, fmt.Sprintf("MTU %d, want %d", have.MTU, want.MTU))
+		}
+		if haveGWs, wantGWs := normalizePrefixes(have.AnycastGateways), normalizePrefixes(want.AnycastGateways); !slices.Equal(haveGWs, wantGWs) {
+			reasons = append(reasons, fmt.Sprintf("anycast gateways %v, want %v", haveGWs, wantGWs))
+		}
+		if len(reasons) > 0 {
+			items = append(items, cra.DriftItem{Section: layer2Section(want.VlanID), Reason: strings.Join(reasons, ", ")})
+		}
+	}
+
+	for i := range actual {
+		if !slices.ContainsFunc(desired, func(l2 nl.Layer2Information) bool { return l2.VlanID == actual[i].VlanID }) {
+			items = append(items, cra.DriftItem{Section: layer2Section(actual[i].VlanID), Reason: "unexpected"})
+		}
+	}
+	return items
+}
+
+func vrfDrift(desired, actual []nl.VRFInformation) []cra.DriftItem {
+	var items []cra.DriftItem
+	for i := range desired {
+		want := &desired[i]
+		idx := slices.IndexFunc(actual, func(vrf nl.VRFInformation) bool { return vrf.Name == want.Name })
+		switch {
+		case idx < 0:
+			items = append(items, cra.DriftItem{Section: vrfSection(want.Name), Reason: "missing"})
+		case want.LocalOnly:
+		case actual[idx].MarkForDelete:
+			items = append(items, cra.DriftItem{Section: vrfSection(want.Name), Reason: "L3VNI bridge or VXLAN missing"})
+		case actual[idx].VNI != want.VNI:
+			items = append(items, cra.DriftItem{Section: vrfSection(want.Name), Reason: fmt.Sprintf("VNI %d, want %d", actual[idx].VNI, want.VNI)})
+		}
+	}
+
+	for i := range actual {
+		if !slices.ContainsFunc(desired, func(vrf nl.VRFInformation) bool { return vrf.Name == actual[i].Name }) {
+			items = append(items, cra.DriftItem{Section: vrfSection(actual[i].Name), Reason: "unexpected"})
+		}
+	}
+	return items
+}
+
+// normalizePrefixes returns the sorted canonical forms of the prefixes.
+func normalizePrefixes(prefixes []string) []string {
+	out := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if ip, ipNet, err := net.ParseCIDR(prefix); err == nil {
+			ipNet.IP = ip
+			prefix = ipNet.String()
+		}
+		out = append(out, prefix)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// frrDrift compares the FRR config file at configPath with the applied FRR
+// configuration and, using the frr-reload.py script at reloadScript, the FRR
+// running config with the config file.
+func frrDrift(applied, configPath, reloadScript string) ([]cra.DriftItem, error) {
+	var items []cra.DriftItem
+	onDisk, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading FRR config: %w", err)
+	}
+	if string(onDisk) != applied {
+		items = append(items, cra.DriftItem{Section: "frr", Reason: configPath + " was modified"})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), frrReloadTimeout)
+	defer cancel()
+	//nolint:gosec
+	out, err := exec.CommandContext(ctx, reloadScript, "--test", configPath).Output()
+	if err != nil {
+		return items, fmt.Errorf("error comparing FRR running config: %w", err)
+	}
+	if toDelete, toAdd := countReloadLines(out); toDelete+toAdd > 0 {
+		items = append(items, cra.DriftItem{
+			Section: "frr",
+			Reason:  fmt.Sprintf("running config differs from %s: %d lines to delete, %d to add", configPath, toDelete, toAdd),
+		})
+	}
+	return items, nil
+}
+
+// countReloadLines counts the lines frr-reload.py --test would delete from
+// and add to the running config.
+func countReloadLines(out []byte) (toDelete, toAdd int) {
+	var count *int
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "Lines To Delete":
+			count = &toDelete
+		case line == "Lines To Add":
+			count = &toAdd
+		case line == "" || strings.Trim(line, "=") == "":
+		case count != nil:
+			*count++
+		}
+	}
+	return toDelete, toAdd
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telekom/das-schiff-network-operator/pkg/cra-frr"
+	"github.com/telekom/das-schiff-network-operator/pkg/nl"
+)
+
+func TestLayer2Drift(t *testing.T) {
+	desired := nl.Layer2Information{
+		VlanID:          100,
+		VNI:             1100,
+		VRF:             "m2m",
+		MTU:             9000,
+		AnycastGateways: []string{"10.0.0.1/24", "fd00::1/64"},
+	}
+
+	tests := []struct {
+		name    string
+		desired []nl.Layer2Information
+		actual  []nl.Layer2Information
+		want    []cra.DriftItem
+	}{
+		{
+			name:    "in sync",
+			desired: []nl.Layer2Information{desired},
+			actual:  []nl.Layer2Information{desired},
+		},
+		{
+			name:    "anycast gateways in another order and form",
+			desired: []nl.Layer2Information{desired},
+			actual: []nl.Layer2Information{func() nl.Layer2Information {
+				l2 := desired
+				l2.AnycastGateways = []string{"fd00:0::1/64", "10.0.0.1/24"}
+				return l2
+			}()},
+		},
+		{
+			name:    "unset MTU is not compared",
+			desired: []nl.Layer2Information{{VlanID: 100, VNI: 1100}},
+			actual:  []nl.Layer2Information{{VlanID: 100, VNI: 1100, MTU: 1500}},
+		},
+		{
+			name:    "missing",
+			desired: []nl.Layer2Information{desired},
+			want:    []cra.DriftItem{{Section: "layer2/100", Reason: "missing"}},
+		},
+		{
+			name:   "unexpected",
+			actual: []nl.Layer2Information{desired},
+			want:   []cra.DriftItem{{Section: "layer2/100", Reason: "unexpected"}},
+		},
+		{
+			name:    "changed",
+			desired: []nl.Layer2Information{desired},
+			actual: []nl.Layer2Information{{
+				VlanID:          100,
+				VNI:             1200,
+				VRF:             "",
+				MTU:             1500,
+				AnycastGateways: []string{"10.0.0.1/24"},
+			}},
+			want: []cra.DriftItem{{
+				Section: "layer2/100",
+				Reason: `VNI 1200, want 1100, VRF "", want "m2m", MTU 1500, want 9000, ` +
+					`anycast gateways [10.0.0.1/24], want [10.0.0.1/24 fd00::1/64]`,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, layer2Drift(tt.desired, tt.actual))
+		})
+	}
+}
+
+func TestVRFDrift(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired []nl.VRFInformation
+		actual  []nl.VRFInformation
+		want    []cra.DriftItem
+	}{
+		{
+			name:    "in sync",
+			desired: []nl.VRFInformation{{Name: "m2m", VNI: 100}},
+			actual:  []nl.VRFInformation{{Name: "m2m", VNI: 100}},
+		},
+		{
+			name:    "missing",
+			desired: []nl.VRFInformation{{Name: "m2m", VNI: 100}},
+			want:    []cra.DriftItem{{Section: "vrf/m2m", Reason: "missing"}},
+		},
+		{
+			name:   "unexpected",
+			actual: []nl.VRFInformation{{Name: "m2m", VNI: 100}},
+			want:   []cra.DriftItem{{Section: "vrf/m2m", Reason: "unexpected"}},
+		},
+		{
+			name:    "L3VNI bridge or VXLAN missing",
+			desired: []nl.VRFInformation{{Name: "m2m", VNI: 100}},
+			actual:  []nl.VRFInformation{{Name: "m2m", VNI: 100, MarkForDelete: true}},
+			want:    []cra.DriftItem{{Section: "vrf/m2m", Reason: "L3VNI bridge or VXLAN missing"}},
+		},
+		{
+			name:    "changed VNI",
+			desired: []nl.VRFInformation{{Name: "m2m", VNI: 100}},
+			actual:  []nl.VRFInformation{{Name: "m2m", VNI: 200}},
+			want:    []cra.DriftItem{{Section: "vrf/m2m", Reason: "VNI 200, want 100"}},
+		},
+		{
+			name:    "local VRFs are only checked for existence",
+			desired: []nl.VRFInformation{{Name: "local", LocalOnly: true}},
+			actual:  []nl.VRFInformation{{Name: "local", VNI: 200, MarkForDelete: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, vrfDrift(tt.desired, tt.actual))
+		})
+	}
+}
+
+func TestNormalizePrefixes(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefixes []string
+		want     []string
+	}{
+		{name: "empty", prefixes: nil, want: []string{}},
+		{name: "sorted", prefixes: []string{"10.0.1.1/24", "10.0.0.1/24"}, want: []string{"10.0.0.1/24", "10.0.1.1/24"}},
+		{name: "canonical IPv6", prefixes: []string{"FD00:0:0::1/64"}, want: []string{"fd00::1/64"}},
+		{name: "host bits kept", prefixes: []string{"10.0.0.254/24"}, want: []string{"10.0.0.254/24"}},
+		{name: "invalid kept as is", prefixes: []string{"invalid"}, want: []string{"invalid"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizePrefixes(tt.prefixes))
+		})
+	}
+}
+
+const reloadOutput = `
+Lines To Delete
+===============
+router bgp 64510 vrf m2m
+ no neighbor 10.0.0.2 remote-as 64511
+
+Lines To Add
+============
+router bgp 64510 vrf m2m
+ neighbor 10.0.0.3 remote-as 64511
+ neighbor 10.0.0.4 remote-as 64511
+`
+
+func TestCountReloadLines(t *testing.T) {
+	tests := []struct {
+		name       string
+		out        string
+		wantDelete int
+		wantAdd    int
+	}{
+		{name: "empty", out: ""},
+		{name: "no changes", out: "\nLines To Delete\n===============\n\nLines To Add\n============\n"},
+		{name: "changes", out: reloadOutput, wantDelete: 2, wantAdd: 3},
+		{name: "only additions", out: "Lines To Add\n============\nip forwarding\n", wantAdd: 1},
+		{name: "lines before the headers are ignored", out: "test mode\n" + reloadOutput, wantDelete: 2, wantAdd: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toDelete, toAdd := countReloadLines([]byte(tt.out))
+			assert.Equal(t, tt.wantDelete, toDelete)
+			assert.Equal(t, tt.wantAdd, toAdd)
+		})
+	}
+}
+
+// writeReloadScript writes a fake frr-reload.py that prints out and exits
+// with exitCode.
+func writeReloadScript(t *testing.T, out string, exitCode int) string {
+	t.Helper()
+	outPath := filepath.Join(t.TempDir(), "reload-output")
+	require.NoError(t, os.WriteFile(outPath, []byte(out), 0o600))
+	script := filepath.Join(t.TempDir(), "frr-reload.py")
+	content := "#!/bin/sh\ncat " + outPath + "\nexit " + strconv.Itoa(exitCode) + "\n"
+	require.NoError(t, os.WriteFile(script, []byte(content), 0o700)) //nolint:gosec // the script must be executable
+	return script
+}
+
+func TestFRRDrift(t *testing.T) {
+	const applied = "router bgp 64510\n"
+	noChanges := "Lines To Delete\n===============\n\nLines To Add\n============\n"
+
+	tests := []struct {
+		name     string
+		onDisk   string
+		out      string
+		exitCode int
+		want     []cra.DriftItem
+		wantErr  bool
+	}{
+		{
+			name:   "in sync",
+			onDisk: applied,
+			out:    noChanges,
+		},
+		{
+			name:   "config file modified",
+			onDisk: "router bgp 64511\n",
+			out:    noChanges,
+			want:   []cra.DriftItem{{Section: "frr", Reason: "%s was modified"}},
+		},
+		{
+			name:   "running config differs",
+			onDisk: applied,
+			out:    reloadOutput,
+			want:   []cra.DriftItem{{Section: "frr", Reason: "running config differs from %s: 2 lines to delete, 3 to add"}},
+		},
+		{
+			name:     "reload script fails",
+			onDisk:   "router bgp 64511\n",
+			exitCode: 1,
+			want:     []cra.DriftItem{{Section: "frr", Reason: "%s was modified"}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "frr.conf")
+			require.NoError(t, os.WriteFile(configPath, []byte(tt.onDisk), 0o600))
+			for i := range tt.want {
+				tt.want[i].Reason = fmt.Sprintf(tt.want[i].Reason, configPath)
+			}
+
+			items, err := frrDrift(applied, configPath, writeReloadScript(t, tt.out, tt.exitCode))
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, items)
+		})
+	}
+
+	t.Run("config file missing", func(t *testing.T) {
+		_, err := frrDrift(applied, filepath.Join(t.TempDir(), "frr.conf"), writeReloadScript(t, noChanges, 0))
+		require.Error(t, err)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"flag"
@@ -18,8 +19,10 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -29,6 +32,7 @@ import (
 	"github.com/vishvananda/netlink"
 	"golang.org/x/sys/unix"
 
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
 	"github.com/telekom/das-schiff-network-operator/pkg/bpf"
 	"github.com/telekom/das-schiff-network-operator/pkg/config"
 	"github.com/telekom/das-schiff-network-operator/pkg/cra-frr"
@@ -62,7 +66,7 @@ var (
 // to prevent log injection attacks (CodeQL: Log entries created from user input).
 var logSanitizer = strings.NewReplacer("\n", "", "\r", "")
 
-func deleteLayer2(cfg *nl.NetlinkConfiguration) error {
+func deleteLayer2(cfg *nl.NetlinkConfiguration, rec *applyRecorder) error {
 	existing, err := nlManager.ListL2()
 	if err != nil {
 		return fmt.Errorf("error listing L2: %w", err)
@@ -89,7 +93,9 @@ func deleteLayer2(cfg *nl.NetlinkConfiguration) error {
 		}
 		if needsDeletion {
 			if err := nlManager.CleanupL2(&existing[i]); len(err) > 0 {
-				return fmt.Errorf("error deleting L2 (VLAN: %d): %v", existing[i].VlanID, err)
+				if err := rec.itemFailed(layer2Error(existing[i].VlanID, fmt.Errorf("error deleting L2 (VLAN: %d): %v", existing[i].VlanID, err))); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -97,7 +103,7 @@ func deleteLayer2(cfg *nl.NetlinkConfiguration) error {
 	return nil
 }
 
-func createLayer2(cfg *nl.NetlinkConfiguration) error {
+func createLayer2(cfg *nl.NetlinkConfiguration, rec *applyRecorder) error {
 	existing, err := nlManager.ListL2()
 	if err != nil {
 		return fmt.Errorf("error listing L2: %w", err)
@@ -114,11 +120,15 @@ func createLayer2(cfg *nl.NetlinkConfiguration) error {
 		}
 		if currentConfig == nil {
 			if err := nlManager.CreateL2(&cfg.Layer2s[i]); err != nil {
-				return fmt.Errorf("error creating L2 (VLAN: %d): %w", cfg.Layer2s[i].VlanID, err)
+				if err := rec.itemFailed(layer2Error(cfg.Layer2s[i].VlanID, fmt.Errorf("error creating L2 (VLAN: %d): %w", cfg.Layer2s[i].VlanID, err))); err != nil {
+					return err
+				}
 			}
 		} else {
 			if err := nlManager.ReconcileL2(currentConfig, &cfg.Layer2s[i]); err != nil {
-				return fmt.Errorf("error reconciling L2 (VLAN: %d): %w", cfg.Layer2s[i].VlanID, err)
+				if err := rec.itemFailed(layer2Error(cfg.Layer2s[i].VlanID, fmt.Errorf("error reconciling L2 (VLAN: %d): %w", cfg.Layer2s[i].VlanID, err))); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -395,7 +405,7 @@ func getVRFsToDelete(cfg *nl.NetlinkConfiguration) ([]nl.VRFInformation, error)
 // leaves bridges/VXLANs DOWN.  Callers must invoke upNewVRFs after FRR reload
 // so that the SVI-up event makes FRR read the bridge hardware address as the
 // EVPN Router-MAC (see zebra_vxlan_svi_up → process_l3vni_oper_up in FRR).
-func createVRFs(cfg *nl.NetlinkConfiguration) ([]nl.VRFInformation, error) {
+func createVRFs(cfg *nl.NetlinkConfiguration, rec *applyRecorder) ([]nl.VRFInformation, error) {
 	existing, err := nlManager.ListL3()
 	if err != nil {
 		return nil, fmt.Errorf("error listing L3 VRF information: %w", err)
@@ -414,7 +424,10 @@ func createVRFs(cfg *nl.NetlinkConfiguration) ([]nl.VRFInformation, error) {
 		if !alreadyExists {
 			log.Print(logSanitizer.Replace(fmt.Sprintf("Creating VRF %s", cfg.VRFs[i].Name)))
 			if err := nlManager.CreateL3(cfg.VRFs[i]); err != nil {
-				return created, fmt.Errorf("error creating L3 (VRF: %s): %w", cfg.VRFs[i].Name, err)
+				if err := rec.itemFailed(vrfError(cfg.VRFs[i].Name, fmt.Errorf("error creating L3 (VRF: %s): %w", cfg.VRFs[i].Name, err))); err != nil {
+					return created, err
+				}
+				continue
 			}
 			created = append(created, cfg.VRFs[i])
 		}
@@ -427,11 +440,13 @@ func createVRFs(cfg *nl.NetlinkConfiguration) ([]nl.VRFInformation, error) {
 // Must be called AFTER FRR reload so that FRR already knows the L3VNI→VRF
 // mapping.  The SVI-up netlink event causes zebra to read the bridge MAC and
 // advertise the correct EVPN Router-MAC.
-func upNewVRFs(vrfs []nl.VRFInformation) error {
+func upNewVRFs(vrfs []nl.VRFInformation, rec *applyRecorder) error {
 	for i := range vrfs {
 		log.Print(logSanitizer.Replace(fmt.Sprintf("Bringing up VRF interfaces %s", vrfs[i].Name)))
 		if err := nlManager.UpL3(vrfs[i]); err != nil {
-			return fmt.Errorf("error setting up L3 (VRF: %s): %w", vrfs[i].Name, err)
+			if err := rec.itemFailed(vrfError(vrfs[i].Name, fmt.Errorf("error setting up L3 (VRF: %s): %w", vrfs[i].Name, err))); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -440,7 +455,7 @@ func upNewVRFs(vrfs []nl.VRFInformation) error {
 // reconcileLayer3 deletes stale VRFs and creates new ones (bridges/VXLANs
 // DOWN).  Returns the list of newly created VRFs so the caller can bring them
 // UP after FRR reload.
-func reconcileLayer3(cfg *nl.NetlinkConfiguration) ([]nl.VRFInformation, error) {
+func reconcileLayer3(cfg *nl.NetlinkConfiguration, rec *applyRecorder) ([]nl.VRFInformation, error) {
 	vrfsToDelete, err := getVRFsToDelete(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("error getting VRFs to delete: %w", err)
@@ -449,7 +464,9 @@ func reconcileLayer3(cfg *nl.NetlinkConfiguration) ([]nl.VRFInformation, error)
 	for i := range vrfsToDelete {
 		errors := nlManager.CleanupL3(vrfsToDelete[i])
 		if len(errors) > 0 {
-			return nil, fmt.Errorf("error cleaning up L3 (VRF: %s): %v", vrfsToDelete[i].Name, errors)
+			if err := rec.itemFailed(vrfError(vrfsToDelete[i].Name, fmt.Errorf("error cleaning up L3 (VRF: %s): %v", vrfsToDelete[i].Name, errors))); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -460,7 +477,7 @@ func reconcileLayer3(cfg *nl.NetlinkConfiguration) ([]nl.VRFInformation, error)
 	// (broadcast storm with hairpin).  Bringing them UP after reload ensures
 	// FRR has the VNI config and the SVI-up event triggers correct RMAC
 	// advertisement.
-	created, err := createVRFs(cfg)
+	created, err := createVRFs(cfg, rec)
 	if err != nil {
 		return created, fmt.Errorf("error creating VRFs: %w", err)
 	}
@@ -541,33 +558,44 @@ func applyConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := writeFRRConfig(craConfiguration.FRRConfiguration); err != nil {
+	rec := &applyRecorder{isolate: craConfiguration.IsolateFailures}
+
+	if err := rec.run(v1alpha1.ApplyStageFRRReload, func() error {
+		return writeFRRConfig(craConfiguration.FRRConfiguration)
+	}); err != nil {
 		log.Print(logSanitizer.Replace(fmt.Sprintf("Failed to write FRR config: %v", err)))
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		rec.write(w, err)
 		return
 	}
 
-	if err := reconcileNetlink(&craConfiguration.NetlinkConfiguration); err != nil {
+	if err := reconcileNetlink(&craConfiguration, rec); err != nil {
 		log.Print(logSanitizer.Replace(fmt.Sprintf("Failed to reconcile netlink: %v", err)))
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		rec.write(w, err)
 		return
 	}
 
 	// Reconcile SBR policy routes as ip rules
-	if err := reconcilePolicyRoutes(craConfiguration.PolicyRoutes); err != nil {
+	if err := rec.run(v1alpha1.ApplyStagePolicyRoutes, func() error {
+		return reconcilePolicyRoutes(craConfiguration.PolicyRoutes)
+	}); err != nil {
 		log.Print(logSanitizer.Replace(fmt.Sprintf("Failed to reconcile policy routes: %v", err)))
-		http.Error(w, fmt.Sprintf("Failed to reconcile policy routes: %v", err), http.StatusInternalServerError)
+		rec.write(w, fmt.Errorf("failed to reconcile policy routes: %w", err))
 		return
 	}
 
 	// Reconcile traffic mirroring (loopbacks, GRE tunnels, tc filters).
 	// Mirroring is an additive, non-disruptive observability feature, so a mirror
-	// programming error must not fail the whole node configuration.
-	if err := nlManager.ReconcileMirror(&craConfiguration.NetlinkConfiguration); err != nil {
+	// programming error must not fail the whole node configuration. It is still
+	// reported as a failed stage.
+	if err := rec.run(v1alpha1.ApplyStageMirror, func() error {
+		return nlManager.ReconcileMirror(&craConfiguration.NetlinkConfiguration)
+	}); err != nil {
 		log.Println("Warning: failed to reconcile mirror configuration (continuing):", err)
 	}
 
-	w.WriteHeader(http.StatusOK)
+	drift.setApplied(&craConfiguration, rec.result.Failed)
+	configured.Store(true)
+	rec.write(w, nil)
 }
 
 // parseApplyRequest reads and unmarshals the HTTP request body into cfg.
@@ -600,53 +628,113 @@ func writeFRRConfig(frrConfig string) error {
 	return nil
 }
 
-// reconcileNetlink drives the ordered netlink/FRR reconcile sequence.
-func reconcileNetlink(cfg *nl.NetlinkConfiguration) error {
-	if err := deleteLayer2(cfg); err != nil {
+// reconcileNetlink drives the ordered netlink/FRR reconcile sequence. The
+// steps are recorded as the NetlinkL2, NetlinkL3 and FRRReload stages. The
+// sections of the Layer2s and VRFs that failed are removed from the FRR
+// configuration before it is reloaded, Layer2s and VRFs that fail after the
+// reload lead to a second reload without them.
+func reconcileNetlink(craConfig *cra.Configuration, rec *applyRecorder) error {
+	cfg := &craConfig.NetlinkConfiguration
+	if err := rec.run(v1alpha1.ApplyStageNetlinkL2, func() error { return deleteLayer2(cfg, rec) }); err != nil {
 		return fmt.Errorf("failed to reconcile Layer2 (delete): %w", err)
 	}
 
 	// Phase 1: Create VRF devices and L3VNI bridges/VXLANs (DOWN).
 	// Bridges must exist (with correct MAC) before FRR reload so zebra can
 	// map VNI→SVI, but must stay DOWN to avoid L2VNI broadcast storms.
-	newVRFs, err := reconcileLayer3(cfg)
-	if err != nil {
+	var newVRFs []nl.VRFInformation
+	if err := rec.run(v1alpha1.ApplyStageNetlinkL3, func() (err error) {
+		newVRFs, err = reconcileLayer3(cfg, rec)
+		return err
+	}); err != nil {
 		return fmt.Errorf("failed to reconcile Layer3: %w", err)
 	}
 
 	// Phase 2: Reload FRR so it learns VNI→VRF mapping from the config file.
 	// At this point bridges exist (zebra knows the interfaces) but are DOWN,
 	// so is_l3vni_oper_up() is false and no RMAC is advertised yet.
-	if err := reloadFRR(); err != nil {
+	if err := rec.run(v1alpha1.ApplyStageFRRReload, func() error {
+		if _, err := dropFailedSections(craConfig, rec.result.Failed); err != nil {
+			return err
+		}
+		if err := reloadFRR(); err != nil {
+			return err
+		}
+		// Give zebra time to process the reload and learn VNI→VRF mappings.
+		// Without this, the SVI-up event from Phase 3 may arrive before zebra
+		// has associated the L3VNI, causing it to treat the VNI as L2.
+		if len(newVRFs) > 0 {
+			waitForL3VNIs(newVRFs)
+		}
+		return nil
+	}); err != nil {
 		return fmt.Errorf("failed to reload FRR: %w", err)
 	}
 
-	// Give zebra time to process the reload and learn VNI→VRF mappings.
-	// Without this, the SVI-up event from Phase 3 may arrive before zebra
-	// has associated the L3VNI, causing it to treat the VNI as L2.
-	if len(newVRFs) > 0 {
-		waitForL3VNIs(newVRFs)
-	}
-
 	// Phase 3: Bring new bridges/VXLANs UP.  The SVI-up netlink event makes
 	// zebra call zebra_vxlan_svi_up → process_l3vni_oper_up which reads the
 	// bridge hardware address and advertises the correct EVPN Router-MAC.
 	if len(newVRFs) > 0 {
-		if err := upNewVRFs(newVRFs); err != nil {
+		if err := rec.run(v1alpha1.ApplyStageNetlinkL3, func() error { return upNewVRFs(newVRFs, rec) }); err != nil {
 			return fmt.Errorf("failed to bring up new VRFs: %w", err)
 		}
 	}
 
 	time.Sleep(defaultSleep)
 
-	if err := createLayer2(cfg); err != nil {
+	if err := rec.run(v1alpha1.ApplyStageNetlinkL2, func() error { return createLayer2(cfg, rec) }); err != nil {
 		return fmt.Errorf("failed to reconcile Layer2 (create): %w", err)
 	}
 
+	if err := rec.run(v1alpha1.ApplyStageFRRReload, func() error {
+		if dropped, err := dropFailedSections(craConfig, rec.result.Failed); err != nil || !dropped {
+			return err
+		}
+		return reloadFRR()
+	}); err != nil {
+		return fmt.Errorf("failed to reload FRR without the failed sections: %w", err)
+	}
+
 	reconcileNeighborSync(cfg)
 	return nil
 }
 
+// boot identifies this run of the CRA, the agent replays the configuration
+// when the boot ID changes.
+var boot = cra.BootInfo{BootID: newBootID(), Started: time.Now()}
+
+// configured is set once a configuration was applied since the CRA started.
+// It is tracked apart from the drift detector, which may be disabled.
+var configured atomic.Bool
+
+func newBootID() string {
+	id := make([]byte, 16) //nolint:mnd
+	if _, err := rand.Read(id); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16) //nolint:mnd
+	}
+	return hex.EncodeToString(id)
+}
+
+func serveBootID(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	info := boot
+	info.Applied = configured.Load()
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		log.Println("Failed to write response", err)
+	}
+}
+
 func executeFrr(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -803,9 +891,13 @@ func createListener(ip net.IP, port int, bindInterface string) (net.Listener, er
 }
 
 func main() {
+	log.Printf("Starting FRR-CRA with boot ID %s", boot.BootID)
+
 	ip := flag.String("ip", "fd00:7:caa5::", "IP to listen on and generate certificate for")
 	bindInterface := flag.String("bind-interface", "cluster", "Bind interface to use for netlink")
 	port := flag.Int("port", 8443, "Port to listen on") //nolint:mnd
+	driftCheckInterval := flag.Duration("drift-check-interval", 0,
+		"Interval to compare the applied configuration with the netlink and FRR state at (0 checks only when the drift report is requested)")
 	flag.Parse()
 
 	parsedIP := net.ParseIP(*ip)
@@ -831,6 +923,10 @@ func main() {
 		log.Println("BPF and neighbor sync initialized")
 	}
 
+	if *driftCheckInterval > 0 {
+		go drift.run(*driftCheckInterval)
+	}
+
 	registry, err := setupPrometheusRegistry()
 	if err != nil {
 		log.Fatal("Failed to setup Prometheus registry", err)
@@ -838,6 +934,10 @@ func main() {
 
 	http.HandleFunc("/frr/configuration", applyConfig)
 	http.HandleFunc("/frr/command", executeFrr)
+	http.HandleFunc("/frr/drift", serveDrift)
+	http.HandleFunc("/frr/boot-id", serveBootID)
+	http.HandleFunc("/frr/interfaces", serveInventory(nlManager.ListInterfaces))
+	http.HandleFunc("/frr/routes", serveInventory(nlManager.ListRoutes))
 	http.Handle("/frr/metrics", promhttp.HandlerFor(
 		registry,
 		promhttp.HandlerOpts{
@@ -11,9 +11,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 
 	"github.com/telekom/das-schiff-network-operator/pkg/frr"
 	"github.com/telekom/das-schiff-network-operator/pkg/monitoring"
@@ -123,5 +125,23 @@ func setupMonitoringEndpoint() (*monitoring.Endpoint, error) {
 	}
 	setupLog.Info("loaded status service config")
 
-	return monitoring.NewEndpoint(c, frr.NewCli(), svcName, svcNamespace), nil
+	applyClient, err := monitoring.NewApplyClient(clientConfig, os.Getenv(monitoring.ApplyAPICAFileEnv))
+	if err != nil {
+		return nil, fmt.Errorf("error creating apply API client: %w", err)
+	}
+
+	httpClient, err := rest.HTTPClientFor(clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubernetes HTTP client: %w", err)
+	}
+	auth, err := filters.WithAuthenticationAndAuthorization(clientConfig, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("error creating apply API auth filter: %w", err)
+	}
+
+	e := monitoring.NewEndpoint(c, frr.NewCli(), svcName, svcNamespace)
+	if err := e.SetApplyClient(applyClient, auth); err != nil {
+		return nil, fmt.Errorf("error configuring apply API: %w", err)
+	}
+	return e, nil
 }
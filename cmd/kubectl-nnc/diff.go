@@ -0,0 +1,187 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/cmd/kubectl-nnc/renderer"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/common"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/nncdiff"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/operator"
+)
+
+// diffOptions selects what a node's NodeNetworkConfig is compared with.
+type diffOptions struct {
+	revision                     string
+	currentConfig                string
+	operatorConfig               string
+	processImportsAsStaticRoutes bool
+	namespace                    string
+}
+
+func newDiffCmd() *cobra.Command {
+	opts := &diffOptions{}
+	cmd := &cobra.Command{
+		Use:   "diff <node-a> <node-b> | <node> --revision <revision> | <node> --current-config <file>",
+		Short: "Show the differences between NodeNetworkConfigs",
+		Long: "Compares the NodeNetworkConfig of a node with the one of another node, with the config the node " +
+			"gets from a NetworkConfigRevision, or with the config its agent last applied (the agent's " +
+			"current-config.yaml, copied from the node). Layer2s, VRFs and BGP peers are compared one by one " +
+			"and the values that differ are shown together with the intent CRDs the sections originate from.",
+		Args: cobra.RangeArgs(1, 2), //nolint:mnd
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd, args, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.revision, "revision", "", "compare with the config the node gets from this NetworkConfigRevision (name or hash)")
+	cmd.Flags().StringVar(&opts.currentConfig, "current-config", "", "compare with the config in this copy of the agent's current-config.yaml")
+	cmd.Flags().StringVar(&opts.operatorConfig, "operator-config", "", "path to the operator's VRF config, needed to build the config of a legacy revision")
+	cmd.Flags().BoolVar(&opts.processImportsAsStaticRoutes, "process-imports-as-static-routes", false,
+		"build the config of a legacy revision like an operator running with this flag")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "", "namespace of the intent CRDs, for intent revisions (default: all namespaces)")
+	return cmd
+}
+
+func runDiff(cmd *cobra.Command, args []string, opts *diffOptions) error {
+	ctx := context.Background()
+
+	targets := 0
+	for _, set := range []bool{len(args) == 2, opts.revision != "", opts.currentConfig != ""} { //nolint:mnd
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return errors.New("specify exactly one of a second node, --revision or --current-config")
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	nnc := &networkv1alpha1.NodeNetworkConfig{}
+	if err := c.Get(ctx, client.ObjectKey{Name: args[0]}, nnc); err != nil {
+		return fmt.Errorf("getting NodeNetworkConfig for node %q: %w", args[0], err)
+	}
+	from := nncdiff.Config{Spec: &nnc.Spec, Origins: renderer.ParseOrigins(nnc.Annotations)}
+	fromName := "node/" + nnc.Name
+
+	var to nncdiff.Config
+	var toName string
+	switch {
+	case len(args) == 2: //nolint:mnd
+		other := &networkv1alpha1.NodeNetworkConfig{}
+		if err := c.Get(ctx, client.ObjectKey{Name: args[1]}, other); err != nil {
+			return fmt.Errorf("getting NodeNetworkConfig for node %q: %w", args[1], err)
+		}
+		to = nncdiff.Config{Spec: &other.Spec, Origins: renderer.ParseOrigins(other.Annotations)}
+		toName = "node/" + other.Name
+	case opts.revision != "":
+		to, toName, err = revisionConfig(ctx, c, nnc.Name, opts)
+		if err != nil {
+			return err
+		}
+	default:
+		// The agent's config is what is live on the node, the NNC what is desired.
+		current, err := common.ReadNodeNetworkConfig(opts.currentConfig)
+		if err != nil {
+			return err
+		}
+		to = from
+		toName = fromName
+		from = nncdiff.Config{Spec: &current.Spec, Origins: renderer.ParseOrigins(current.Annotations)}
+		fromName = "current-config/" + nnc.Name
+	}
+
+	r := renderer.New(cmd.OutOrStdout(), !noColor)
+	r.RenderDiff(fromName, toName, nncdiff.Diff(from, to))
+	return nil
+}
+
+// revisionConfig returns the config the node gets from the given revision.
+// Legacy revisions are built like the operator does; intent revisions only
+// record the per-node config revisions, so the node's config is rendered from
+// the intent CRDs and must match the recorded revision.
+func revisionConfig(ctx context.Context, c client.Client, node string, opts *diffOptions) (nncdiff.Config, string, error) {
+	revision, err := getRevision(ctx, c, opts.revision)
+	if err != nil {
+		return nncdiff.Config{}, "", err
+	}
+	name := "revision/" + revision.Name
+
+	if revision.Labels[networkv1alpha1.ManagedByLabel] == networkv1alpha1.ManagedByIntent {
+		cfg, err := intentRevisionConfig(ctx, c, node, revision, opts.namespace)
+		return cfg, name, err
+	}
+
+	if opts.operatorConfig == "" {
+		return nncdiff.Config{}, "", fmt.Errorf("--operator-config is required to build the config of legacy revision %s", revision.Name)
+	}
+	importMode := operator.ImportModeImport
+	if opts.processImportsAsStaticRoutes {
+		importMode = operator.ImportModeStaticRoute
+	}
+
+	nodeObj := &corev1.Node{}
+	if err := c.Get(ctx, client.ObjectKey{Name: node}, nodeObj); err != nil {
+		return nncdiff.Config{}, "", fmt.Errorf("getting node %q: %w", node, err)
+	}
+	built, err := operator.RenderNodeNetworkConfig(ctx, c, nodeObj, revision, opts.operatorConfig, importMode)
+	if err != nil {
+		return nncdiff.Config{}, "", fmt.Errorf("building config of node %s from revision %s: %w", node, revision.Name, err)
+	}
+	return nncdiff.Config{Spec: &built.Spec}, name, nil
+}
+
+func intentRevisionConfig(ctx context.Context, c client.Client, node string, revision *networkv1alpha1.NetworkConfigRevision, namespace string) (nncdiff.Config, error) {
+	nodeRevision, ok := revision.Spec.NodeRevisions[node]
+	if !ok {
+		return nncdiff.Config{}, fmt.Errorf("node %s is not part of revision %s", node, revision.Name)
+	}
+
+	fetched, err := intent.FetchAll(ctx, c, namespace, logr.Discard())
+	if err != nil {
+		return nncdiff.Config{}, fmt.Errorf("fetching intent CRDs: %w", err)
+	}
+	configs, _, err := intent.Render(ctx, intent.WithoutDryRun(fetched), logr.Discard())
+	if err != nil {
+		return nncdiff.Config{}, fmt.Errorf("rendering intent CRDs: %w", err)
+	}
+	cfg, ok := configs[node]
+	if !ok {
+		return nncdiff.Config{}, fmt.Errorf("node %s not found", node)
+	}
+	if cfg.Err != nil {
+		return nncdiff.Config{}, fmt.Errorf("rendering config of node %s: %w", node, cfg.Err)
+	}
+	if cfg.Spec.Revision != nodeRevision {
+		return nncdiff.Config{}, fmt.Errorf("revision %s no longer matches the intent CRDs: "+
+			"only the config of the latest intent revision can be rebuilt", revision.Name)
+	}
+	return nncdiff.Config{Spec: cfg.Spec, Origins: cfg.Origins}, nil
+}
@@ -27,16 +27,27 @@ import (
 	"github.com/telekom/das-schiff-network-operator/cmd/kubectl-nnc/renderer"
 )
 
+var listOutput string
+
 func newListCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all NodeNetworkConfigs with summary",
-		Args:  cobra.NoArgs,
-		RunE:  runList,
+		Long: "Lists the NodeNetworkConfigs as a table, as JSON or YAML including their parsed origins, " +
+			"or as a DOT or Mermaid graph of the VRFs, L2 VNIs, VRF imports and BGP peers aggregated " +
+			"across all nodes.",
+		Args: cobra.NoArgs,
+		RunE: runList,
 	}
+	cmd.Flags().StringVarP(&listOutput, "output", "o", "", outputUsage)
+	return cmd
 }
 
 func runList(_ *cobra.Command, _ []string) error {
+	if err := validateOutput(listOutput); err != nil {
+		return err
+	}
+
 	c, err := newClient()
 	if err != nil {
 		return err
@@ -47,6 +58,13 @@ func runList(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("listing NodeNetworkConfigs: %w", err)
 	}
 
+	done, err := renderStructured(os.Stdout, listOutput, renderer.NewNNCDocumentList(nncList), func() *renderer.Graph {
+		return renderer.BuildGraph(nncList.Items)
+	})
+	if done {
+		return err
+	}
+
 	r := renderer.New(os.Stdout, !noColor)
 	r.RenderList(nncList)
 	return nil
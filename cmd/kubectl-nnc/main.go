@@ -23,11 +23,14 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	nc "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
 )
 
 var (
@@ -40,12 +43,13 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(networkv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(nc.AddToScheme(scheme))
 }
 
 func main() {
 	rootCmd := &cobra.Command{
 		Use:   "kubectl-nnc",
-		Short: "Visualize NodeNetworkConfig resources",
+		Short: "Visualize and manage NodeNetworkConfig resources",
 		Long:  "kubectl plugin to inspect and visualize NodeNetworkConfig resources with tree + table output.",
 	}
 
@@ -55,6 +59,13 @@ func main() {
 
 	rootCmd.AddCommand(newShowCmd())
 	rootCmd.AddCommand(newListCmd())
+	rootCmd.AddCommand(newRollbackCmd())
+	rootCmd.AddCommand(newPlanCmd())
+	rootCmd.AddCommand(newRenderCmd())
+	rootCmd.AddCommand(newDiffCmd())
+	rootCmd.AddCommand(newTraceCmd())
+	rootCmd.AddCommand(newRolloutCmd())
+	rootCmd.AddCommand(newSupportBundleCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -63,6 +74,36 @@ func main() {
 }
 
 func newClient() (client.Client, error) {
+	config, err := restConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("creating client: %w", err)
+	}
+
+	return c, nil
+}
+
+// newClientset returns a client-go clientset for the APIs the controller-runtime
+// client does not cover, such as pod logs and service proxies.
+func newClientset() (kubernetes.Interface, error) {
+	config, err := restConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating clientset: %w", err)
+	}
+
+	return cs, nil
+}
+
+func restConfig() (*rest.Config, error) {
 	rules := clientcmd.NewDefaultClientConfigLoadingRules()
 	if kubeconfig != "" {
 		rules.ExplicitPath = kubeconfig
@@ -78,10 +119,5 @@ func newClient() (client.Client, error) {
 		return nil, fmt.Errorf("building kubeconfig: %w", err)
 	}
 
-	c, err := client.New(config, client.Options{Scheme: scheme})
-	if err != nil {
-		return nil, fmt.Errorf("creating client: %w", err)
-	}
-
-	return c, nil
+	return config, nil
 }
@@ -0,0 +1,68 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/telekom/das-schiff-network-operator/cmd/kubectl-nnc/renderer"
+)
+
+// Output formats of show and list. The default is the tree or table view.
+const (
+	outputJSON    = "json"
+	outputYAML    = "yaml"
+	outputDOT     = "dot"
+	outputMermaid = "mermaid"
+)
+
+var outputFormats = []string{outputJSON, outputYAML, outputDOT, outputMermaid}
+
+const outputUsage = "output format: json, yaml, dot or mermaid (default: tree/table view)"
+
+func validateOutput(format string) error {
+	if format == "" {
+		return nil
+	}
+	for _, f := range outputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q, expected one of %s", format, strings.Join(outputFormats, ", "))
+}
+
+// renderStructured renders doc as JSON or YAML, or graph in a graph format.
+// It returns false for the default view.
+func renderStructured(w io.Writer, format string, doc any, graph func() *renderer.Graph) (bool, error) {
+	r := renderer.New(w, false)
+	switch format {
+	case outputJSON:
+		return true, r.RenderJSON(doc)
+	case outputYAML:
+		return true, r.RenderYAML(doc)
+	case outputDOT:
+		r.RenderDOT(graph())
+		return true, nil
+	case outputMermaid:
+		r.RenderMermaid(graph())
+		return true, nil
+	}
+	return false, nil
+}
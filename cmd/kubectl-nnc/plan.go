@@ -0,0 +1,108 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+
+	nc "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
+	"github.com/telekom/das-schiff-network-operator/cmd/kubectl-nnc/renderer"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent"
+)
+
+var (
+	planFiles     []string
+	planNamespace string
+)
+
+func newPlanCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan [-f <file|dir>]...",
+		Short: "Show the NodeNetworkConfig changes of proposed intent CRDs",
+		Long: "Renders the NodeNetworkConfig of every node from the applied intent CRDs and from the proposal, " +
+			"and lists the Layer2s, VRFs and BGP peers that would be added, removed or changed on each node " +
+			"together with the intent CRDs they originate from. The proposal consists of the intent CRDs annotated with " +
+			nc.AnnotationDryRun + "=true and the objects in the given files, which replace applied objects of the same " +
+			"kind, namespace and name. Nothing is applied.",
+		Args: cobra.NoArgs,
+		RunE: runPlan,
+	}
+	cmd.Flags().StringArrayVarP(&planFiles, "filename", "f", nil, "manifest file or directory with proposed intent CRDs (repeatable)")
+	cmd.Flags().StringVarP(&planNamespace, "namespace", "n", "", "namespace of the intent CRDs (default: all namespaces)")
+	return cmd
+}
+
+func runPlan(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	fetched, err := intent.FetchAll(ctx, c, planNamespace, logr.Discard())
+	if err != nil {
+		return fmt.Errorf("fetching intent CRDs: %w", err)
+	}
+
+	defaultNamespace := planNamespace
+	if defaultNamespace == "" {
+		defaultNamespace = "default"
+	}
+	objs, err := loadManifests(planFiles, defaultNamespace)
+	if err != nil {
+		return err
+	}
+	proposed, err := intent.Overlay(fetched, objs)
+	if err != nil {
+		return err
+	}
+
+	plan, err := intent.ComputePlan(ctx, intent.WithoutDryRun(fetched), proposed, logr.Discard())
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	for _, issue := range plan.Issues {
+		fmt.Fprintf(out, "Warning: %s %s/%s is skipped (%s): %s\n", issue.Kind, issue.Namespace, issue.Name, issue.Reason, issue.Message)
+	}
+	if len(plan.Issues) > 0 {
+		fmt.Fprintln(out)
+	}
+
+	r := renderer.New(out, !noColor)
+	failed := 0
+	for i := range plan.Nodes {
+		if plan.Nodes[i].Err != nil {
+			fmt.Fprintf(out, "NodeNetworkConfig: %s cannot be rendered: %v\n\n", plan.Nodes[i].Node, plan.Nodes[i].Err)
+			failed++
+			continue
+		}
+		r.RenderChanges(plan.Nodes[i].Node, plan.Nodes[i].Changes)
+	}
+
+	fmt.Fprintf(out, "Plan: %d of %d nodes change.\n", len(plan.Nodes)-failed, len(proposed.Nodes))
+	if failed > 0 {
+		return fmt.Errorf("%d nodes cannot be rendered", failed)
+	}
+	return nil
+}
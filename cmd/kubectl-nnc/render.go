@@ -0,0 +1,245 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/config"
+	frr "github.com/telekom/das-schiff-network-operator/pkg/cra-frr"
+	vsr "github.com/telekom/das-schiff-network-operator/pkg/cra-vsr"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent/resolver"
+)
+
+const (
+	renderNNCFile = "nodenetworkconfig.yaml"
+	renderFRRFile = "frr.conf"
+	renderVSRFile = "vsr.xml"
+)
+
+// renderOptions configures the offline rendering of intent CRDs.
+type renderOptions struct {
+	files       []string
+	namespace   string
+	baseConfig  string
+	frrTemplate string
+	vsrStartup  string
+	outputDir   string
+}
+
+// renderedFile is one rendered config of a node.
+type renderedFile struct {
+	name    string
+	content []byte
+}
+
+func newRenderCmd() *cobra.Command {
+	opts := &renderOptions{}
+	cmd := &cobra.Command{
+		Use:   "render -f <file|dir>... --base-config <file> [--frr-template <file>] [--vsr-startup <file>]",
+		Short: "Render NodeNetworkConfigs and router configs from intent CRD manifests without a cluster",
+		Long: "Runs the intent builders and the assembler on the intent CRDs and Node objects in the given " +
+			"manifests and writes the NodeNetworkConfig of every node. With --frr-template the FRR config " +
+			"agent-cra-frr would write is rendered as well, with --vsr-startup the NETCONF config agent-cra-vsr " +
+			"would merge into the given vSR startup config. No cluster is accessed, so the output can be " +
+			"committed as golden files and checked in CI.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRender(cmd.OutOrStdout(), cmd.ErrOrStderr(), opts)
+		},
+	}
+	cmd.Flags().StringArrayVarP(&opts.files, "filename", "f", nil, "manifest file or directory with intent CRDs and Nodes (repeatable)")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default", "namespace of the intent CRDs without one")
+	cmd.Flags().StringVar(&opts.baseConfig, "base-config", "", "path to the node agents' base config")
+	cmd.Flags().StringVar(&opts.frrTemplate, "frr-template", "", "path to the FRR config template of agent-cra-frr")
+	cmd.Flags().StringVar(&opts.vsrStartup, "vsr-startup", "", "path to the vSR startup config XML")
+	cmd.Flags().StringVar(&opts.outputDir, "output-dir", "", "write the configs to <dir>/<node>/ instead of stdout")
+	_ = cmd.MarkFlagRequired("filename")
+	_ = cmd.MarkFlagRequired("base-config")
+	return cmd
+}
+
+func runRender(out, errOut io.Writer, opts *renderOptions) error {
+	renderers, err := opts.renderers()
+	if err != nil {
+		return err
+	}
+
+	objs, err := loadManifests(opts.files, opts.namespace)
+	if err != nil {
+		return err
+	}
+	fetched, err := intent.Overlay(&resolver.FetchedResources{}, objs)
+	if err != nil {
+		return err
+	}
+	if len(fetched.Nodes) == 0 {
+		return errors.New("no Node objects found in the manifests")
+	}
+
+	configs, issues, err := intent.Render(context.Background(), fetched, logr.Discard())
+	if err != nil {
+		return fmt.Errorf("rendering intent: %w", err)
+	}
+	for _, issue := range issues {
+		fmt.Fprintf(errOut, "Warning: %s %s/%s is skipped (%s): %s\n", issue.Kind, issue.Namespace, issue.Name, issue.Reason, issue.Message)
+	}
+
+	nodes := make([]string, 0, len(configs))
+	for node := range configs {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	var failed []string
+	for _, node := range nodes {
+		files, err := renderNode(node, configs[node], renderers)
+		if err != nil {
+			fmt.Fprintf(errOut, "NodeNetworkConfig: %s cannot be rendered: %v\n", node, err)
+			failed = append(failed, node)
+			continue
+		}
+		if err := writeRendered(out, opts.outputDir, node, files); err != nil {
+			return err
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d nodes cannot be rendered: %v", len(failed), failed)
+	}
+	return nil
+}
+
+// nodeRenderer renders one router config from a node's NodeNetworkConfig.
+type nodeRenderer struct {
+	file   string
+	render func(spec *networkv1alpha1.NodeNetworkConfigSpec) ([]byte, error)
+}
+
+func (o *renderOptions) renderers() ([]nodeRenderer, error) {
+	baseConfig, err := config.LoadBaseConfig(o.baseConfig)
+	if err != nil {
+		return nil, fmt.Errorf("loading base config: %w", err)
+	}
+
+	var renderers []nodeRenderer
+	if o.frrTemplate != "" {
+		tpl := frr.FRRTemplate{FRRTemplatePath: o.frrTemplate}
+		renderers = append(renderers, nodeRenderer{
+			file: renderFRRFile,
+			render: func(spec *networkv1alpha1.NodeNetworkConfigSpec) ([]byte, error) {
+				conf, err := tpl.TemplateFRR(baseConfig, spec)
+				if err != nil {
+					return nil, fmt.Errorf("templating FRR config: %w", err)
+				}
+				return []byte(conf), nil
+			},
+		})
+	}
+	if o.vsrStartup != "" {
+		startupXML, err := os.ReadFile(o.vsrStartup)
+		if err != nil {
+			return nil, fmt.Errorf("reading vSR startup config: %w", err)
+		}
+		manager, err := vsr.NewOfflineManager(baseConfig, startupXML)
+		if err != nil {
+			return nil, fmt.Errorf("loading vSR startup config: %w", err)
+		}
+		renderers = append(renderers, nodeRenderer{
+			file: renderVSRFile,
+			render: func(spec *networkv1alpha1.NodeNetworkConfigSpec) ([]byte, error) {
+				conf, err := manager.RenderConfig(spec)
+				if err != nil {
+					return nil, fmt.Errorf("rendering vSR config: %w", err)
+				}
+				return append(conf, '\n'), nil
+			},
+		})
+	}
+	return renderers, nil
+}
+
+func renderNode(node string, cfg *intent.RenderedConfig, renderers []nodeRenderer) ([]renderedFile, error) {
+	if cfg.Err != nil {
+		return nil, cfg.Err
+	}
+
+	nnc := &networkv1alpha1.NodeNetworkConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: networkv1alpha1.GroupVersion.String(),
+			Kind:       "NodeNetworkConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: node},
+		Spec:       *cfg.Spec,
+	}
+	intent.SetOriginsAnnotation(nnc, cfg.Origins)
+	// The status is left out: it is written by the node agents.
+	nncYAML, err := yaml.Marshal(struct {
+		metav1.TypeMeta   `json:",inline"`
+		metav1.ObjectMeta `json:"metadata"`
+		Spec              networkv1alpha1.NodeNetworkConfigSpec `json:"spec"`
+	}{nnc.TypeMeta, nnc.ObjectMeta, nnc.Spec})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling NodeNetworkConfig: %w", err)
+	}
+
+	files := []renderedFile{{name: renderNNCFile, content: nncYAML}}
+	for _, r := range renderers {
+		content, err := r.render(cfg.Spec)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, renderedFile{name: r.file, content: content})
+	}
+	return files, nil
+}
+
+func writeRendered(out io.Writer, outputDir, node string, files []renderedFile) error {
+	if outputDir == "" {
+		for _, f := range files {
+			fmt.Fprintf(out, "# --- %s: %s ---\n", node, f.name)
+			if _, err := out.Write(f.content); err != nil {
+				return fmt.Errorf("writing %s of %s: %w", f.name, node, err)
+			}
+		}
+		return nil
+	}
+
+	dir := filepath.Join(outputDir, node)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", dir, err)
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.name), f.content, 0o600); err != nil {
+			return fmt.Errorf("writing %s: %w", filepath.Join(dir, f.name), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+const renderTestdata = "testdata/render"
+
+func renderTestOptions(outputDir string) *renderOptions {
+	return &renderOptions{
+		files:       []string{filepath.Join(renderTestdata, "input")},
+		namespace:   "default",
+		baseConfig:  filepath.Join(renderTestdata, "base-config.yaml"),
+		frrTemplate: "../../config/agent-cra-frr/frr.conf.tpl",
+		vsrStartup:  filepath.Join(renderTestdata, "vsr-startup.xml"),
+		outputDir:   outputDir,
+	}
+}
+
+func TestRender_Golden(t *testing.T) {
+	golden := filepath.Join(renderTestdata, "golden")
+	if *updateGolden {
+		require.NoError(t, os.RemoveAll(golden))
+		require.NoError(t, runRender(&bytes.Buffer{}, io.Discard, renderTestOptions(golden)))
+	}
+
+	out := t.TempDir()
+	require.NoError(t, runRender(&bytes.Buffer{}, io.Discard, renderTestOptions(out)))
+
+	for _, node := range []string{"worker-1", "worker-2"} {
+		for _, file := range []string{renderNNCFile, renderFRRFile, renderVSRFile} {
+			expected, err := os.ReadFile(filepath.Join(golden, node, file))
+			require.NoError(t, err, "golden file missing, run go test -update")
+			actual, err := os.ReadFile(filepath.Join(out, node, file))
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual), "%s/%s differs from the golden file", node, file)
+		}
+	}
+}
+
+func TestRender_Stdout(t *testing.T) {
+	opts := renderTestOptions("")
+	opts.frrTemplate = ""
+	opts.vsrStartup = ""
+
+	var out bytes.Buffer
+	require.NoError(t, runRender(&out, io.Discard, opts))
+	assert.Contains(t, out.String(), "# --- worker-1: nodenetworkconfig.yaml ---")
+	assert.Contains(t, out.String(), "# --- worker-2: nodenetworkconfig.yaml ---")
+	assert.NotContains(t, out.String(), renderFRRFile)
+	assert.Contains(t, out.String(), "Layer2Attachment/storage")
+}
+
+func TestRender_RequiresNodes(t *testing.T) {
+	opts := renderTestOptions("")
+	opts.files = []string{filepath.Join(renderTestdata, "input", "intent.yaml")}
+	assert.ErrorContains(t, runRender(&bytes.Buffer{}, io.Discard, opts), "no Node objects")
+}
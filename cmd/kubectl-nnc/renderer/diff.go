@@ -0,0 +1,144 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/nncdiff"
+)
+
+// diffGroups are the top-level NNC sections in rendering order with their titles.
+var diffGroups = []struct{ key, title string }{
+	{"layer2s", "Layer2s"},
+	{"fabricVRFs", "FabricVRFs"},
+	{"localVRFs", "LocalVRFs"},
+	{"clusterVRF", "ClusterVRF"},
+}
+
+// diffEntity collects the changes of one Layer2 or VRF: the change of the
+// entity itself (nil if only its BGP peers changed) and of its BGP peers.
+type diffEntity struct {
+	key   string
+	self  *nncdiff.Change
+	peers []nncdiff.Change
+}
+
+// RenderDiff renders a section-aware tree of the differences between two
+// NodeNetworkConfigs: added (+), removed (-) and changed (~) Layer2s, VRFs and
+// BGP peers with their origins, and the values that differ within changed ones.
+func (r *Renderer) RenderDiff(from, to string, changes []nncdiff.Change) {
+	fmt.Fprintf(r.w, "%s: %s → %s\n", r.bold("Diff"), from, to)
+	if len(changes) == 0 {
+		fmt.Fprintln(r.w, "  No differences.")
+		return
+	}
+	fmt.Fprintln(r.w)
+
+	groups := groupChanges(changes)
+	for _, g := range diffGroups {
+		entities := groups[g.key]
+		if len(entities) == 0 {
+			continue
+		}
+		fmt.Fprintf(r.w, "%s:\n", r.bold(g.title))
+		for idx, e := range entities {
+			isLast := idx == len(entities)-1
+			branch, prefix := treeBranch, treePipe
+			if isLast {
+				branch, prefix = treeLast, treeSpace
+			}
+			r.renderDiffEntity(branch, "  "+prefix+"  ", e)
+		}
+		fmt.Fprintln(r.w)
+	}
+
+	var added, removed, changed int
+	for _, c := range changes {
+		switch c.Op {
+		case nncdiff.Added:
+			added++
+		case nncdiff.Removed:
+			removed++
+		default:
+			changed++
+		}
+	}
+	fmt.Fprintf(r.w, "%d added, %d removed, %d changed.\n", added, removed, changed)
+}
+
+func (r *Renderer) renderDiffEntity(branch, indent string, e *diffEntity) {
+	if e.self == nil {
+		fmt.Fprintf(r.w, "  %s %s\n", branch, r.cyan(e.key))
+	} else {
+		fmt.Fprintf(r.w, "  %s %s %s%s\n", branch, r.colorOp(e.self.Op), r.cyan(e.key), r.sourceSuffix(e.self.Origin))
+		r.renderFields(indent, e.self.Fields)
+	}
+	for _, peer := range e.peers {
+		key := peer.Section[strings.Index(peer.Section, "/bgpPeers/")+len("/bgpPeers/"):]
+		fmt.Fprintf(r.w, "%s%s BGPPeer %s%s\n", indent, r.colorOp(peer.Op), key, r.sourceSuffix(peer.Origin))
+		r.renderFields(indent+"    ", peer.Fields)
+	}
+}
+
+func (r *Renderer) renderFields(indent string, fields []nncdiff.Field) {
+	for _, f := range fields {
+		switch f.Op {
+		case nncdiff.Added:
+			fmt.Fprintf(r.w, "%s%s %s: %s\n", indent, r.colorOp(f.Op), f.Path, f.To)
+		case nncdiff.Removed:
+			fmt.Fprintf(r.w, "%s%s %s: %s\n", indent, r.colorOp(f.Op), f.Path, f.From)
+		default:
+			fmt.Fprintf(r.w, "%s%s %s: %s → %s\n", indent, r.colorOp(f.Op), f.Path, f.From, f.To)
+		}
+	}
+}
+
+// groupChanges groups the changes by top-level section and entity, keeping
+// the order of the (sorted) changes.
+func groupChanges(changes []nncdiff.Change) map[string][]*diffEntity {
+	groups := make(map[string][]*diffEntity)
+	index := make(map[string]*diffEntity)
+	for i := range changes {
+		c := &changes[i]
+		group, key := c.Section, c.Section
+		peer := strings.Contains(c.Section, "/bgpPeers/")
+		if group != "clusterVRF" && !strings.HasPrefix(group, "clusterVRF/") {
+			parts := strings.SplitN(c.Section, "/", 3) //nolint:mnd // group/key/rest
+			group = parts[0]
+			if len(parts) > 1 {
+				key = parts[1]
+			}
+		} else {
+			group, key = "clusterVRF", "clusterVRF"
+		}
+
+		e, ok := index[group+"/"+key]
+		if !ok {
+			e = &diffEntity{key: key}
+			index[group+"/"+key] = e
+			groups[group] = append(groups[group], e)
+		}
+		if peer {
+			e.peers = append(e.peers, *c)
+		} else {
+			e.self = c
+		}
+	}
+	return groups
+}
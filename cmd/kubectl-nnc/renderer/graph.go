@@ -0,0 +1,213 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renderer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+// Kinds of graph vertices.
+const (
+	VertexVRF    = "vrf"
+	VertexLayer2 = "layer2"
+	VertexPeer   = "peer"
+)
+
+// clusterVRFName is the name the cluster VRF is imported by.
+const clusterVRFName = "cluster"
+
+// Vertex is a VRF, L2 VNI or BGP peer of a topology graph.
+type Vertex struct {
+	ID    string
+	Kind  string
+	Lines []string
+	// Nodes are the cluster nodes carrying the vertex.
+	Nodes map[string]bool
+}
+
+// Edge connects two vertices: a Layer2's IRB to its VRF ("irb"), an imported
+// VRF to the importing one ("import") or a VRF to a BGP peer ("bgp").
+type Edge struct {
+	From, To, Label string
+}
+
+// Graph is the topology of VRFs, L2 VNIs, VRF imports and BGP peers of one or
+// more NodeNetworkConfigs. Vertices and edges present on several nodes are
+// merged.
+type Graph struct {
+	// Total is the number of NodeNetworkConfigs the graph is built from.
+	Total    int
+	vertices map[string]*Vertex
+	edges    map[Edge]bool
+}
+
+// BuildGraph returns the topology graph of the given NodeNetworkConfigs.
+func BuildGraph(nncs []networkv1alpha1.NodeNetworkConfig) *Graph {
+	g := &Graph{Total: len(nncs), vertices: make(map[string]*Vertex), edges: make(map[Edge]bool)}
+	for i := range nncs {
+		g.addNNC(&nncs[i])
+	}
+	return g
+}
+
+func (g *Graph) addNNC(nnc *networkv1alpha1.NodeNetworkConfig) {
+	node := nnc.Name
+	for _, key := range sortedKeys(nnc.Spec.Layer2s) {
+		l2 := nnc.Spec.Layer2s[key]
+		id := g.vertex(node, VertexLayer2, fmt.Sprintf("l2_%d", l2.VNI), fmt.Sprintf("L2 VNI %d", l2.VNI), fmt.Sprintf("VLAN %d", l2.VLAN))
+		if l2.IRB != nil && l2.IRB.VRF != "" {
+			g.edge(id, g.vrfVertex(node, l2.IRB.VRF), "irb")
+		}
+	}
+	for _, name := range sortedKeys(nnc.Spec.FabricVRFs) {
+		vrf := nnc.Spec.FabricVRFs[name]
+		g.vertex(node, VertexVRF, vrfID(name), "VRF "+name, fmt.Sprintf("VNI %d", vrf.VNI))
+		g.addVRF(node, name, &vrf.VRF)
+	}
+	for _, name := range sortedKeys(nnc.Spec.LocalVRFs) {
+		vrf := nnc.Spec.LocalVRFs[name]
+		g.vertex(node, VertexVRF, vrfID(name), "VRF "+name, "local")
+		g.addVRF(node, name, &vrf)
+	}
+	if nnc.Spec.ClusterVRF != nil {
+		g.vrfVertex(node, clusterVRFName)
+		g.addVRF(node, clusterVRFName, nnc.Spec.ClusterVRF)
+	}
+}
+
+func (g *Graph) addVRF(node, name string, vrf *networkv1alpha1.VRF) {
+	for _, imp := range vrf.VRFImports {
+		g.edge(g.vrfVertex(node, imp.FromVRF), vrfID(name), "import")
+	}
+	for i := range vrf.BGPPeers {
+		peer := &vrf.BGPPeers[i]
+		addr := ptrOrDash(peer.Address)
+		if peer.ListenRange != nil {
+			addr = *peer.ListenRange
+		}
+		id := g.vertex(node, VertexPeer, sanitizeID("peer_"+name+"_"+addr), "peer "+addr, fmt.Sprintf("AS %d", peer.RemoteASN))
+		g.edge(vrfID(name), id, "bgp")
+	}
+}
+
+// vrfVertex adds a VRF that is only referenced, not defined, keeping the
+// lines of a defined one.
+func (g *Graph) vrfVertex(node, name string) string {
+	return g.vertex(node, VertexVRF, vrfID(name), "VRF "+name)
+}
+
+func (g *Graph) vertex(node, kind, id string, lines ...string) string {
+	v, ok := g.vertices[id]
+	if !ok {
+		v = &Vertex{ID: id, Kind: kind, Nodes: make(map[string]bool)}
+		g.vertices[id] = v
+	}
+	if len(lines) > len(v.Lines) {
+		v.Lines = lines
+	}
+	v.Nodes[node] = true
+	return id
+}
+
+func (g *Graph) edge(from, to, label string) {
+	g.edges[Edge{From: from, To: to, Label: label}] = true
+}
+
+// Vertices returns the vertices sorted by ID.
+func (g *Graph) Vertices() []*Vertex {
+	out := make([]*Vertex, 0, len(g.vertices))
+	for _, id := range sortedKeys(g.vertices) {
+		out = append(out, g.vertices[id])
+	}
+	return out
+}
+
+// Edges returns the edges sorted by source, target and label.
+func (g *Graph) Edges() []Edge {
+	out := make([]Edge, 0, len(g.edges))
+	for e := range g.edges {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].From != out[j].From {
+			return out[i].From < out[j].From
+		}
+		if out[i].To != out[j].To {
+			return out[i].To < out[j].To
+		}
+		return out[i].Label < out[j].Label
+	})
+	return out
+}
+
+// labelLines returns the lines of a vertex label. Graphs of several nodes note
+// on how many of them a vertex exists if it is not on all.
+func (g *Graph) labelLines(v *Vertex) []string {
+	if g.Total <= 1 || len(v.Nodes) == g.Total {
+		return v.Lines
+	}
+	return append(append([]string{}, v.Lines...), fmt.Sprintf("%d/%d nodes", len(v.Nodes), g.Total))
+}
+
+// RenderDOT renders the graph in Graphviz DOT format.
+func (r *Renderer) RenderDOT(g *Graph) {
+	shapes := map[string]string{VertexVRF: "box", VertexLayer2: "ellipse", VertexPeer: "hexagon"}
+
+	fmt.Fprintln(r.w, "digraph nnc {")
+	fmt.Fprintln(r.w, "  rankdir=LR;")
+	for _, v := range g.Vertices() {
+		fmt.Fprintf(r.w, "  %s [label=%s, shape=%s];\n", v.ID, strconv.Quote(strings.Join(g.labelLines(v), "\n")), shapes[v.Kind])
+	}
+	for _, e := range g.Edges() {
+		fmt.Fprintf(r.w, "  %s -> %s [label=%s];\n", e.From, e.To, strconv.Quote(e.Label))
+	}
+	fmt.Fprintln(r.w, "}")
+}
+
+// RenderMermaid renders the graph as a Mermaid flowchart.
+func (r *Renderer) RenderMermaid(g *Graph) {
+	shapes := map[string][2]string{VertexVRF: {"[", "]"}, VertexLayer2: {"(", ")"}, VertexPeer: {"{{", "}}"}}
+
+	fmt.Fprintln(r.w, "flowchart LR")
+	for _, v := range g.Vertices() {
+		label := strings.ReplaceAll(strings.Join(g.labelLines(v), "<br/>"), `"`, "#quot;")
+		fmt.Fprintf(r.w, "  %s%s\"%s\"%s\n", v.ID, shapes[v.Kind][0], label, shapes[v.Kind][1])
+	}
+	for _, e := range g.Edges() {
+		fmt.Fprintf(r.w, "  %s -->|%s| %s\n", e.From, e.Label, e.To)
+	}
+}
+
+func vrfID(name string) string {
+	return sanitizeID("vrf_" + name)
+}
+
+// sanitizeID replaces all characters DOT and Mermaid do not allow in
+// identifiers.
+func sanitizeID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, id)
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package renderer
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
@@ -28,11 +27,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/nncdiff"
 )
 
 const (
-	originAnnotation = "network-connector.sylvaproject.org/origins"
-
 	// ANSI color codes.
 	colorReset  = "\033[0m"
 	colorGreen  = "\033[32m"
@@ -62,18 +61,7 @@ type Origins map[string]string
 
 // ParseOrigins extracts the origins annotation from NNC annotations.
 func ParseOrigins(annotations map[string]string) Origins {
-	if annotations == nil {
-		return nil
-	}
-	raw, ok := annotations[originAnnotation]
-	if !ok {
-		return nil
-	}
-	var origins Origins
-	if err := json.Unmarshal([]byte(raw), &origins); err != nil {
-		return nil
-	}
-	return origins
+	return intent.ParseOrigins(annotations)
 }
 
 // Renderer handles tree + table output for NNC visualization.
@@ -96,6 +84,16 @@ func (r *Renderer) RenderNNC(nnc *networkv1alpha1.NodeNetworkConfig, origins Ori
 	r.renderClusterVRF(nnc.Spec.ClusterVRF, origins)
 }
 
+// RenderChanges renders the added (+), removed (-) and changed (~) sections
+// of a node's NodeNetworkConfig together with their origins.
+func (r *Renderer) RenderChanges(node string, changes []nncdiff.Change) {
+	fmt.Fprintf(r.w, "%s: %s (%d changes)\n", r.bold("NodeNetworkConfig"), node, len(changes))
+	for _, c := range changes {
+		fmt.Fprintf(r.w, "  %s %s%s\n", r.colorOp(c.Op), c.Section, r.sourceSuffix(c.Origin))
+	}
+	fmt.Fprintln(r.w)
+}
+
 // RenderList renders a summary table of all NNCs.
 func (r *Renderer) RenderList(list *networkv1alpha1.NodeNetworkConfigList) {
 	if len(list.Items) == 0 {
@@ -289,13 +287,25 @@ func (r *Renderer) renderStaticRoutes(indent string, routes []networkv1alpha1.St
 	tw := tabwriter.NewWriter(r.w, tabwriterMinWidth, tabwriterTabWidth, tabwriterPadding, ' ', 0)
 	fmt.Fprintf(tw, "%s  PREFIX\tNEXTHOP\n", indent)
 	for _, sr := range routes {
-		nh := "-"
-		if sr.NextHop != nil {
-			if sr.NextHop.Vrf != nil {
-				nh = "vrf:" + *sr.NextHop.Vrf
-			} else if sr.NextHop.Address != nil {
-				nh = *sr.NextHop.Address
+		var nhs []string
+		for _, hop := range sr.AllNextHops() {
+			nh := "-"
+			if hop.Vrf != nil {
+				nh = "vrf:" + *hop.Vrf
+			} else if hop.Address != nil {
+				nh = *hop.Address
 			}
+			if hop.Weight != nil {
+				nh += fmt.Sprintf("(weight %d)", *hop.Weight)
+			}
+			if hop.BFD {
+				nh += "(bfd)"
+			}
+			nhs = append(nhs, nh)
+		}
+		nh := "-"
+		if len(nhs) > 0 {
+			nh = strings.Join(nhs, ",")
 		}
 		origin := r.originSuffix(origins, originPrefix+"/staticRoutes/"+sr.Prefix)
 		fmt.Fprintf(tw, "%s  %s\t%s%s\n", indent, sr.Prefix, nh, origin)
@@ -382,6 +392,22 @@ func (r *Renderer) colorStatus(status string) string {
 	}
 }
 
+func (r *Renderer) colorOp(op nncdiff.Op) string {
+	var sign, color string
+	switch op {
+	case nncdiff.Added:
+		sign, color = "+", colorGreen
+	case nncdiff.Removed:
+		sign, color = "-", colorRed
+	default:
+		sign, color = "~", colorYellow
+	}
+	if !r.color {
+		return sign
+	}
+	return color + sign + colorReset
+}
+
 func (r *Renderer) bold(s string) string {
 	if !r.color {
 		return s
@@ -397,11 +423,11 @@ func (r *Renderer) cyan(s string) string {
 }
 
 func (r *Renderer) originSuffix(origins Origins, key string) string {
-	if origins == nil {
-		return ""
-	}
-	src, ok := origins[key]
-	if !ok {
+	return r.sourceSuffix(origins[key])
+}
+
+func (r *Renderer) sourceSuffix(src string) string {
+	if src == "" {
 		return ""
 	}
 	if r.color {
@@ -18,13 +18,17 @@ package renderer
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/nncdiff"
 )
 
 func ptrString(s string) *string { return &s }
@@ -186,7 +190,7 @@ func TestRenderList_Empty(t *testing.T) {
 func TestParseOrigins(t *testing.T) {
 	t.Run("valid annotation", func(t *testing.T) {
 		annotations := map[string]string{
-			originAnnotation: `{"layer2s/prod": "Layer2Attachment/my-l2a"}`,
+			intent.OriginsAnnotation: `{"layer2s/prod": "Layer2Attachment/my-l2a"}`,
 		}
 		origins := ParseOrigins(annotations)
 		assert.Equal(t, "Layer2Attachment/my-l2a", origins["layer2s/prod"])
@@ -198,7 +202,7 @@ func TestParseOrigins(t *testing.T) {
 	})
 
 	t.Run("invalid JSON", func(t *testing.T) {
-		annotations := map[string]string{originAnnotation: "not json"}
+		annotations := map[string]string{intent.OriginsAnnotation: "not json"}
 		origins := ParseOrigins(annotations)
 		assert.Nil(t, origins)
 	})
@@ -219,3 +223,146 @@ func TestTruncate(t *testing.T) {
 	assert.Equal(t, "abc", truncate("abc", 10))
 	assert.Equal(t, "abcdefghij…", truncate("abcdefghijklmnop", 10))
 }
+
+func TestRenderChanges(t *testing.T) {
+	var buf bytes.Buffer
+	r := New(&buf, false)
+
+	r.RenderChanges("worker-1", []nncdiff.Change{
+		{Op: nncdiff.Added, Section: "layer2s/100", Origin: "Layer2Attachment/my-l2a"},
+		{Op: nncdiff.Changed, Section: "fabricVRFs/internet"},
+		{Op: nncdiff.Removed, Section: "fabricVRFs/internet/bgpPeers/10.0.0.1", Origin: "BGPPeering/peer"},
+	})
+	output := buf.String()
+
+	assert.Contains(t, output, "NodeNetworkConfig: worker-1 (3 changes)")
+	assert.Contains(t, output, "  + layer2s/100  ← Layer2Attachment/my-l2a\n")
+	assert.Contains(t, output, "  ~ fabricVRFs/internet\n")
+	assert.Contains(t, output, "  - fabricVRFs/internet/bgpPeers/10.0.0.1  ← BGPPeering/peer\n")
+}
+
+func TestRenderDiff(t *testing.T) {
+	var buf bytes.Buffer
+	r := New(&buf, false)
+
+	r.RenderDiff("node/worker-1", "node/worker-2", []nncdiff.Change{
+		{Op: nncdiff.Added, Section: "clusterVRF/bgpPeers/as65001", Origin: "BGPPeering/loopback"},
+		{Op: nncdiff.Changed, Section: "fabricVRFs/internet", Fields: []nncdiff.Field{
+			{Op: nncdiff.Changed, Path: "vni", From: "100", To: "200"},
+		}},
+		{Op: nncdiff.Removed, Section: "fabricVRFs/internet/bgpPeers/10.0.0.1", Origin: "BGPPeering/peer"},
+		{Op: nncdiff.Added, Section: "layer2s/100", Origin: "Layer2Attachment/my-l2a"},
+		{Op: nncdiff.Changed, Section: "layer2s/200", Fields: []nncdiff.Field{
+			{Op: nncdiff.Added, Path: "irb.ipAddresses", To: "10.0.0.1/24"},
+		}},
+	})
+	output := buf.String()
+
+	assert.Contains(t, output, "Diff: node/worker-1 → node/worker-2\n")
+	assert.Contains(t, output, "Layer2s:\n  ├─ + 100  ← Layer2Attachment/my-l2a\n  └─ ~ 200\n      + irb.ipAddresses: 10.0.0.1/24\n")
+	assert.Contains(t, output, "FabricVRFs:\n  └─ ~ internet\n      ~ vni: 100 → 200\n      - BGPPeer 10.0.0.1  ← BGPPeering/peer\n")
+	assert.Contains(t, output, "ClusterVRF:\n  └─ clusterVRF\n      + BGPPeer as65001  ← BGPPeering/loopback\n")
+	assert.Contains(t, output, "2 added, 1 removed, 2 changed.")
+	assert.Less(t, strings.Index(output, "Layer2s:"), strings.Index(output, "FabricVRFs:"))
+}
+
+func TestRenderDiff_NoChanges(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, false).RenderDiff("a", "b", nil)
+	assert.Equal(t, "Diff: a → b\n  No differences.\n", buf.String())
+}
+
+func TestRenderTrace(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, false).RenderTrace(&intent.Trace{
+		Source: "Layer2Attachment/my-l2a",
+		Found:  true,
+		Nodes: []intent.NodeTrace{
+			{Node: "worker-1", Sections: []string{"fabricVRFs/m2m", "layer2s/100"}},
+			{
+				Node: "worker-2", Sections: []string{"layer2s/100"},
+				Missing: []string{"fabricVRFs/m2m"}, Stale: []string{"layer2s/100"},
+				Reason: "rollout pending",
+			},
+		},
+	})
+	output := buf.String()
+
+	assert.Contains(t, output, "Trace: Layer2Attachment/my-l2a\n\n")
+	assert.Contains(t, output, "  ├─ worker-1\n  │     fabricVRFs/m2m\n  │     layer2s/100\n")
+	assert.Contains(t, output, "  └─ worker-2\n      + fabricVRFs/m2m\n      - layer2s/100\n      Reason: rollout pending\n")
+	assert.Contains(t, output, "2 nodes, 1 not up to date.")
+}
+
+func TestRenderTrace_NotFound(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, false).RenderTrace(&intent.Trace{Source: "VRF/missing"})
+	assert.Equal(t, "Trace: VRF/missing\n  Not found.\n  No node carries sections of it.\n", buf.String())
+}
+
+func TestRenderDOT(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, false).RenderDOT(BuildGraph([]networkv1alpha1.NodeNetworkConfig{*testNNC()}))
+	output := buf.String()
+
+	assert.True(t, strings.HasPrefix(output, "digraph nnc {\n"))
+	assert.Contains(t, output, "  l2_100 [label=\"L2 VNI 100\\nVLAN 100\", shape=ellipse];\n")
+	assert.Contains(t, output, "  vrf_internet [label=\"VRF internet\\nVNI 1000\", shape=box];\n")
+	assert.Contains(t, output, "  vrf_s_internet [label=\"VRF s-internet\\nlocal\", shape=box];\n")
+	assert.Contains(t, output, "  peer_internet_10_0_0_1 [label=\"peer 10.0.0.1\\nAS 65001\", shape=hexagon];\n")
+	assert.Contains(t, output, "  l2_100 -> vrf_internet [label=\"irb\"];\n")
+	assert.Contains(t, output, "  vrf_cluster -> vrf_s_internet [label=\"import\"];\n")
+	assert.Contains(t, output, "  vrf_internet -> peer_internet_10_0_0_1 [label=\"bgp\"];\n")
+	assert.NotContains(t, output, "nodes")
+}
+
+func TestRenderMermaid_Aggregated(t *testing.T) {
+	other := testNNC()
+	other.Name = "worker-2"
+	delete(other.Spec.Layer2s, "prod-vlan100")
+
+	var buf bytes.Buffer
+	New(&buf, false).RenderMermaid(BuildGraph([]networkv1alpha1.NodeNetworkConfig{*testNNC(), *other}))
+	output := buf.String()
+
+	assert.True(t, strings.HasPrefix(output, "flowchart LR\n"))
+	assert.Contains(t, output, "  l2_100(\"L2 VNI 100<br/>VLAN 100<br/>1/2 nodes\")\n")
+	assert.Contains(t, output, "  vrf_internet[\"VRF internet<br/>VNI 1000\"]\n")
+	assert.Contains(t, output, "  peer_internet_10_0_0_1{{\"peer 10.0.0.1<br/>AS 65001\"}}\n")
+	assert.Contains(t, output, "  l2_100 -->|irb| vrf_internet\n")
+	assert.Equal(t, 1, strings.Count(output, "vrf_internet -->|bgp|"))
+}
+
+func TestRenderJSON_IncludesOrigins(t *testing.T) {
+	nnc := testNNC()
+	nnc.Annotations = map[string]string{intent.OriginsAnnotation: `{"layer2s/prod-vlan100":"Layer2Attachment/my-l2a"}`}
+
+	var buf bytes.Buffer
+	require.NoError(t, New(&buf, false).RenderJSON(NewNNCDocumentList(&networkv1alpha1.NodeNetworkConfigList{
+		Items: []networkv1alpha1.NodeNetworkConfig{*nnc},
+	})))
+
+	var decoded struct {
+		Kind  string `json:"kind"`
+		Items []struct {
+			Kind     string            `json:"kind"`
+			Metadata metav1.ObjectMeta `json:"metadata"`
+			Spec     map[string]any    `json:"spec"`
+			Origins  map[string]string `json:"origins"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "List", decoded.Kind)
+	require.Len(t, decoded.Items, 1)
+	assert.Equal(t, "NodeNetworkConfig", decoded.Items[0].Kind)
+	assert.Equal(t, "worker-1", decoded.Items[0].Metadata.Name)
+	assert.Contains(t, decoded.Items[0].Spec, "layer2s")
+	assert.Equal(t, "Layer2Attachment/my-l2a", decoded.Items[0].Origins["layer2s/prod-vlan100"])
+}
+
+func TestRenderYAML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, New(&buf, false).RenderYAML(NewNNCDocument(testNNC())))
+	assert.Contains(t, buf.String(), "kind: NodeNetworkConfig\n")
+	assert.Contains(t, buf.String(), "  name: worker-1\n")
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+// NNCDocument is the machine-readable form of a NodeNetworkConfig: the object
+// as stored in the cluster together with its parsed origins.
+type NNCDocument struct {
+	networkv1alpha1.NodeNetworkConfig `json:",inline"`
+	Origins                           Origins `json:"origins,omitempty"`
+}
+
+// NNCDocumentList is the machine-readable form of a list of NodeNetworkConfigs.
+type NNCDocumentList struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Items      []NNCDocument `json:"items"`
+}
+
+// NewNNCDocument returns the machine-readable form of nnc. Managed fields are
+// dropped, the type meta is set as client lists leave it empty.
+func NewNNCDocument(nnc *networkv1alpha1.NodeNetworkConfig) NNCDocument {
+	doc := NNCDocument{NodeNetworkConfig: *nnc.DeepCopy(), Origins: ParseOrigins(nnc.Annotations)}
+	doc.APIVersion = networkv1alpha1.GroupVersion.String()
+	doc.Kind = "NodeNetworkConfig"
+	doc.ManagedFields = nil
+	return doc
+}
+
+// NewNNCDocumentList returns the machine-readable form of list.
+func NewNNCDocumentList(list *networkv1alpha1.NodeNetworkConfigList) NNCDocumentList {
+	docs := NNCDocumentList{APIVersion: "v1", Kind: "List", Items: make([]NNCDocument, 0, len(list.Items))}
+	for i := range list.Items {
+		docs.Items = append(docs.Items, NewNNCDocument(&list.Items[i]))
+	}
+	return docs
+}
+
+// RenderJSON renders v as indented JSON.
+func (r *Renderer) RenderJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling JSON: %w", err)
+	}
+	fmt.Fprintln(r.w, string(data))
+	return nil
+}
+
+// RenderYAML renders v as YAML.
+func (r *Renderer) RenderYAML(v any) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshaling YAML: %w", err)
+	}
+	fmt.Fprint(r.w, string(data))
+	return nil
+}
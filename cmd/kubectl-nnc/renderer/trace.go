@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/nncdiff"
+)
+
+// RenderTrace renders the nodes whose NodeNetworkConfigs carry sections of a
+// traced intent CRD. Sections a node lacks are marked with +, sections it
+// still carries but no longer should with -, each node with the reason.
+func (r *Renderer) RenderTrace(trace *intent.Trace) {
+	fmt.Fprintf(r.w, "%s: %s\n", r.bold("Trace"), trace.Source)
+	switch {
+	case !trace.Found:
+		fmt.Fprintln(r.w, "  Not found.")
+	case trace.DryRun:
+		fmt.Fprintln(r.w, "  Dry-run proposal, not applied to any node (see kubectl nnc plan).")
+	}
+	for _, issue := range trace.Issues {
+		fmt.Fprintf(r.w, "  Skipped (%s): %s\n", issue.Reason, issue.Message)
+	}
+	if len(trace.Nodes) == 0 {
+		fmt.Fprintln(r.w, "  No node carries sections of it.")
+		return
+	}
+	fmt.Fprintln(r.w)
+
+	pending := 0
+	for idx := range trace.Nodes {
+		nt := &trace.Nodes[idx]
+		branch, prefix := treeBranch, treePipe
+		if idx == len(trace.Nodes)-1 {
+			branch, prefix = treeLast, treeSpace
+		}
+		indent := "  " + prefix + "  "
+
+		fmt.Fprintf(r.w, "  %s %s\n", branch, r.cyan(nt.Node))
+		for _, section := range without(nt.Sections, nt.Stale) {
+			fmt.Fprintf(r.w, "%s  %s\n", indent, section)
+		}
+		for _, section := range nt.Missing {
+			fmt.Fprintf(r.w, "%s%s %s\n", indent, r.colorOp(nncdiff.Added), section)
+		}
+		for _, section := range nt.Stale {
+			fmt.Fprintf(r.w, "%s%s %s\n", indent, r.colorOp(nncdiff.Removed), section)
+		}
+		if nt.Reason != "" {
+			pending++
+			fmt.Fprintf(r.w, "%sReason: %s\n", indent, nt.Reason)
+		}
+	}
+
+	fmt.Fprintf(r.w, "\n%d nodes, %d not up to date.\n", len(trace.Nodes), pending)
+}
+
+// without returns the items of a not in b, keeping the order of a.
+func without(a, b []string) []string {
+	skip := make(map[string]bool, len(b))
+	for _, item := range b {
+		skip[item] = true
+	}
+	var out []string
+	for _, item := range a {
+		if !skip[item] {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+func newRollbackCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rollback <revision>",
+		Short: "Roll the nodes back to a previous NetworkConfigRevision",
+		Long: "Requests a rollback to the NetworkConfigRevision with the given name or revision hash. " +
+			"The operator invalidates all newer revisions, so the nodes are updated to the given revision again.",
+		Args: cobra.ExactArgs(1),
+		RunE: runRollback,
+	}
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	revision, err := getRevision(ctx, c, args[0])
+	if err != nil {
+		return err
+	}
+	if revision.Labels[networkv1alpha1.ManagedByLabel] == networkv1alpha1.ManagedByIntent {
+		return fmt.Errorf("revision %s is managed by the intent reconciler and cannot be rolled back to", revision.Name)
+	}
+	if revision.Status.IsInvalid {
+		return fmt.Errorf("revision %s is invalid: %s", revision.Name, revision.Status.FailedMessage)
+	}
+
+	patch := client.MergeFrom(revision.DeepCopy())
+	if revision.Annotations == nil {
+		revision.Annotations = map[string]string{}
+	}
+	revision.Annotations[networkv1alpha1.RollbackAnnotation] = "true"
+	if err := c.Patch(ctx, revision, patch); err != nil {
+		return fmt.Errorf("annotating NetworkConfigRevision %s: %w", revision.Name, err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "rollback to revision %s requested\n", revision.Name)
+	return nil
+}
+
+// getRevision returns the NetworkConfigRevision with the given name or revision hash.
+func getRevision(ctx context.Context, c client.Client, name string) (*networkv1alpha1.NetworkConfigRevision, error) {
+	revision := &networkv1alpha1.NetworkConfigRevision{}
+	err := c.Get(ctx, client.ObjectKey{Name: name}, revision)
+	if err == nil {
+		return revision, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("getting NetworkConfigRevision %q: %w", name, err)
+	}
+
+	revisions := &networkv1alpha1.NetworkConfigRevisionList{}
+	if err := c.List(ctx, revisions); err != nil {
+		return nil, fmt.Errorf("listing NetworkConfigRevisions: %w", err)
+	}
+	for i := range revisions.Items {
+		if revisions.Items[i].Spec.Revision == name {
+			return &revisions.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("NetworkConfigRevision %q not found", name)
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/operator"
+)
+
+// stateQueued is the rollout state of a node that does not have the config of
+// the revision yet. The other states are the NodeNetworkConfig's status.
+const stateQueued = "queued"
+
+const defaultRolloutInterval = 2 * time.Second
+
+type rolloutOptions struct {
+	watch    bool
+	follow   bool
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// nodeRollout is the rollout state of a node.
+type nodeRollout struct {
+	state   string
+	message string
+}
+
+func newRolloutCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollout",
+		Short: "Follow the rollout of NetworkConfigRevisions",
+	}
+
+	opts := &rolloutOptions{}
+	status := &cobra.Command{
+		Use:   "status [revision]",
+		Short: "Show the rollout status of the latest NetworkConfigRevision",
+		Long: "Follows the rollout of the latest NetworkConfigRevision (or the given one, by name or revision " +
+			"hash) until all nodes provisioned it, printing every node's transitions (queued → provisioning → " +
+			"provisioned/invalid), the rollout counters and the estimated time left. Exits non-zero if the " +
+			"revision is invalidated, e.g. because a node failed to provision its config.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRollout(cmd, args, opts)
+		},
+	}
+	status.Flags().BoolVarP(&opts.watch, "watch", "w", true, "watch the rollout until it finishes; otherwise print the current status once")
+	addRolloutFlags(status, opts)
+
+	watchOpts := &rolloutOptions{watch: true, follow: true}
+	watch := &cobra.Command{
+		Use:   "watch",
+		Short: "Follow the rollouts of all new NetworkConfigRevisions",
+		Long: "Like rollout status, but keeps following the NetworkConfigRevisions created after the latest " +
+			"one finished rolling out. Exits non-zero once a revision is invalidated.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRollout(cmd, args, watchOpts)
+		},
+	}
+	addRolloutFlags(watch, watchOpts)
+
+	cmd.AddCommand(status, watch)
+	return cmd
+}
+
+func addRolloutFlags(cmd *cobra.Command, opts *rolloutOptions) {
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 0, "time to wait before giving up (0 waits forever)")
+	cmd.Flags().DurationVar(&opts.interval, "interval", defaultRolloutInterval, "time between two status checks")
+}
+
+func runRollout(cmd *cobra.Command, args []string, opts *rolloutOptions) error {
+	ctx := context.Background()
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	w := newRolloutWatcher(cmd.OutOrStdout())
+	for {
+		var revision *networkv1alpha1.NetworkConfigRevision
+		if len(args) == 1 {
+			revision, err = getRevision(ctx, c, args[0])
+		} else {
+			revision, err = latestRevision(ctx, c)
+		}
+		if err != nil {
+			return err
+		}
+
+		nncs := &networkv1alpha1.NodeNetworkConfigList{}
+		if err := c.List(ctx, nncs); err != nil {
+			return fmt.Errorf("listing NodeNetworkConfigs: %w", err)
+		}
+		nodes := &corev1.NodeList{}
+		if err := c.List(ctx, nodes); err != nil {
+			return fmt.Errorf("listing nodes: %w", err)
+		}
+
+		done, err := w.observe(revision, revisionNodeStates(revision, nodes.Items, nncs.Items), time.Now())
+		switch {
+		case err != nil:
+			return err
+		case !opts.watch:
+			return nil
+		case done && !opts.follow:
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the rollout of revision %s", revision.Name)
+		case <-time.After(opts.interval):
+		}
+	}
+}
+
+// latestRevision returns the most recently created NetworkConfigRevision,
+// which is the one rolled out unless it is invalid.
+func latestRevision(ctx context.Context, c client.Client) (*networkv1alpha1.NetworkConfigRevision, error) {
+	revisions := &networkv1alpha1.NetworkConfigRevisionList{}
+	if err := c.List(ctx, revisions); err != nil {
+		return nil, fmt.Errorf("listing NetworkConfigRevisions: %w", err)
+	}
+	if len(revisions.Items) == 0 {
+		return nil, errors.New("no NetworkConfigRevision found")
+	}
+	sort.Slice(revisions.Items, func(i, j int) bool {
+		return revisions.Items[j].CreationTimestamp.Before(&revisions.Items[i].CreationTimestamp)
+	})
+	return &revisions.Items[0], nil
+}
+
+// revisionNodeStates returns the rollout state of every node the revision is
+// rolled out to: all nodes for legacy revisions, the nodes it records a config
+// for for intent revisions. Quarantined nodes are excluded from the rollout.
+func revisionNodeStates(revision *networkv1alpha1.NetworkConfigRevision, nodes []corev1.Node,
+	nncs []networkv1alpha1.NodeNetworkConfig) map[string]nodeRollout {
+	want := make(map[string]string)
+	if revision.Labels[networkv1alpha1.ManagedByLabel] == networkv1alpha1.ManagedByIntent {
+		for node, rev := range revision.Spec.NodeRevisions {
+			want[node] = rev
+		}
+	} else {
+		for i := range nodes {
+			want[nodes[i].Name] = revision.Spec.Revision
+		}
+	}
+	for _, node := range revision.Status.QuarantinedNodes {
+		delete(want, node)
+	}
+
+	actual := make(map[string]*networkv1alpha1.NodeNetworkConfig, len(nncs))
+	for i := range nncs {
+		actual[nncs[i].Name] = &nncs[i]
+	}
+
+	states := make(map[string]nodeRollout, len(want))
+	for node, rev := range want {
+		nnc, ok := actual[node]
+		switch {
+		case !ok || nnc.Spec.Revision != rev:
+			states[node] = nodeRollout{state: stateQueued}
+		case nnc.Status.ConfigStatus == "":
+			// the agent did not pick up the config yet
+			states[node] = nodeRollout{state: operator.StatusProvisioning}
+		default:
+			states[node] = nodeRollout{state: nnc.Status.ConfigStatus, message: nnc.Status.ErrorMessage}
+		}
+	}
+	return states
+}
+
+// rolloutWatcher prints the changes between consecutive observations of a
+// rollout.
+type rolloutWatcher struct {
+	out      io.Writer
+	revision string
+	states   map[string]nodeRollout
+	summary  string
+	finished bool
+	// start and startReady are the time and the number of provisioned nodes
+	// of the first observation of the revision, to estimate the time left.
+	start      time.Time
+	startReady int
+}
+
+func newRolloutWatcher(out io.Writer) *rolloutWatcher {
+	return &rolloutWatcher{out: out}
+}
+
+// observe prints the node transitions and counters since the last observation
+// and reports whether the rollout of the revision finished. An error is
+// returned if the revision is invalid.
+func (w *rolloutWatcher) observe(revision *networkv1alpha1.NetworkConfigRevision, states map[string]nodeRollout, now time.Time) (bool, error) {
+	if revision.Name != w.revision {
+		fmt.Fprintf(w.out, "Waiting for rollout of revision %s to %d nodes...\n", revision.Name, len(states))
+		*w = rolloutWatcher{out: w.out, revision: revision.Name, start: now, startReady: countState(states, operator.StatusProvisioned)}
+	}
+	if w.finished {
+		return true, nil
+	}
+
+	nodes := make([]string, 0, len(states))
+	for node := range states {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		cur := states[node]
+		prev, seen := w.states[node]
+		if seen && prev == cur {
+			continue
+		}
+		line := cur.state
+		if seen {
+			line = prev.state + " → " + cur.state
+		}
+		if cur.state == operator.StatusInvalid && cur.message != "" {
+			line += ": " + cur.message
+		}
+		fmt.Fprintf(w.out, "  %s: %s\n", node, line)
+	}
+	w.states = states
+
+	ready := countState(states, operator.StatusProvisioned)
+	summary := fmt.Sprintf("%d/%d provisioned, %d provisioning, %d queued, %d invalid", ready, len(states),
+		countState(states, operator.StatusProvisioning), countState(states, stateQueued), countState(states, operator.StatusInvalid))
+	if revision.Status.Gate != "" && revision.Status.Gate != networkv1alpha1.RolloutGateOpen {
+		summary += ", rollout " + strings.ToLower(string(revision.Status.Gate))
+	}
+	if summary != w.summary {
+		w.summary = summary
+		if eta := estimateRemaining(w.start, now, w.startReady, ready, len(states)); eta > 0 {
+			summary += fmt.Sprintf(", ETA %s", eta)
+		}
+		fmt.Fprintf(w.out, "Revision %s: %s\n", revision.Name, summary)
+	}
+
+	switch {
+	case revision.Status.IsInvalid:
+		w.finished = true
+		return true, fmt.Errorf("rollout of revision %s failed on node %s: %s", revision.Name, revision.Status.FailedNode, revision.Status.FailedMessage)
+	case len(states) > 0 && ready == len(states):
+		w.finished = true
+		fmt.Fprintf(w.out, "Revision %s successfully rolled out.\n", revision.Name)
+		return true, nil
+	}
+	return false, nil
+}
+
+// estimateRemaining extrapolates the time left from the nodes provisioned
+// since start. It is 0 if no node was provisioned since then.
+func estimateRemaining(start, now time.Time, startReady, ready, total int) time.Duration {
+	if ready <= startReady || ready >= total {
+		return 0
+	}
+	perNode := now.Sub(start) / time.Duration(ready-startReady)
+	return (perNode * time.Duration(total-ready)).Round(time.Second)
+}
+
+func countState(states map[string]nodeRollout, state string) int {
+	n := 0
+	for _, s := range states {
+		if s.state == state {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+func rolloutNNC(node, revision, status, message string) networkv1alpha1.NodeNetworkConfig {
+	return networkv1alpha1.NodeNetworkConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: node},
+		Spec:       networkv1alpha1.NodeNetworkConfigSpec{Revision: revision},
+		Status:     networkv1alpha1.NodeNetworkConfigStatus{ConfigStatus: status, ErrorMessage: message},
+	}
+}
+
+func TestRevisionNodeStates_Legacy(t *testing.T) {
+	revision := &networkv1alpha1.NetworkConfigRevision{
+		Spec:   networkv1alpha1.NetworkConfigRevisionSpec{Revision: "new"},
+		Status: networkv1alpha1.NetworkConfigRevisionStatus{QuarantinedNodes: []string{"worker-4"}},
+	}
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-3"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-4"}},
+	}
+	nncs := []networkv1alpha1.NodeNetworkConfig{
+		rolloutNNC("worker-1", "new", "provisioned", ""),
+		rolloutNNC("worker-2", "new", "", ""),
+		rolloutNNC("worker-3", "old", "provisioned", ""),
+	}
+
+	assert.Equal(t, map[string]nodeRollout{
+		"worker-1": {state: "provisioned"},
+		"worker-2": {state: "provisioning"},
+		"worker-3": {state: stateQueued},
+	}, revisionNodeStates(revision, nodes, nncs))
+}
+
+func TestRevisionNodeStates_Intent(t *testing.T) {
+	revision := &networkv1alpha1.NetworkConfigRevision{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{networkv1alpha1.ManagedByLabel: networkv1alpha1.ManagedByIntent}},
+		Spec: networkv1alpha1.NetworkConfigRevisionSpec{
+			Revision:      "intent",
+			NodeRevisions: map[string]string{"worker-1": "a", "worker-2": "b"},
+		},
+	}
+	nncs := []networkv1alpha1.NodeNetworkConfig{
+		rolloutNNC("worker-1", "a", "invalid", "frr reload failed"),
+		rolloutNNC("worker-2", "old", "provisioned", ""),
+		rolloutNNC("worker-3", "c", "provisioned", ""),
+	}
+
+	assert.Equal(t, map[string]nodeRollout{
+		"worker-1": {state: "invalid", message: "frr reload failed"},
+		"worker-2": {state: stateQueued},
+	}, revisionNodeStates(revision, nil, nncs))
+}
+
+func TestRolloutWatcher(t *testing.T) {
+	var out bytes.Buffer
+	w := newRolloutWatcher(&out)
+	revision := &networkv1alpha1.NetworkConfigRevision{ObjectMeta: metav1.ObjectMeta{Name: "abc"}}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	done, err := w.observe(revision, map[string]nodeRollout{
+		"worker-1": {state: "provisioning"}, "worker-2": {state: stateQueued}, "worker-3": {state: stateQueued},
+	}, start)
+	require.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, "Waiting for rollout of revision abc to 3 nodes...\n"+
+		"  worker-1: provisioning\n  worker-2: queued\n  worker-3: queued\n"+
+		"Revision abc: 0/3 provisioned, 1 provisioning, 2 queued, 0 invalid\n", out.String())
+
+	out.Reset()
+	done, err = w.observe(revision, map[string]nodeRollout{
+		"worker-1": {state: "provisioned"}, "worker-2": {state: "provisioning"}, "worker-3": {state: stateQueued},
+	}, start.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, "  worker-1: provisioning → provisioned\n  worker-2: queued → provisioning\n"+
+		"Revision abc: 1/3 provisioned, 1 provisioning, 1 queued, 0 invalid, ETA 2m0s\n", out.String())
+
+	// nothing changed
+	out.Reset()
+	_, err = w.observe(revision, w.states, start.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+
+	out.Reset()
+	done, err = w.observe(revision, map[string]nodeRollout{
+		"worker-1": {state: "provisioned"}, "worker-2": {state: "provisioned"}, "worker-3": {state: "provisioned"},
+	}, start.Add(3*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Contains(t, out.String(), "Revision abc: 3/3 provisioned, 0 provisioning, 0 queued, 0 invalid\n")
+	assert.Contains(t, out.String(), "Revision abc successfully rolled out.\n")
+}
+
+func TestRolloutWatcher_Failure(t *testing.T) {
+	var out bytes.Buffer
+	w := newRolloutWatcher(&out)
+	revision := &networkv1alpha1.NetworkConfigRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "abc"},
+		Status: networkv1alpha1.NetworkConfigRevisionStatus{
+			IsInvalid: true, FailedNode: "worker-1", FailedMessage: "frr reload failed",
+		},
+	}
+
+	done, err := w.observe(revision, map[string]nodeRollout{
+		"worker-1": {state: "invalid", message: "frr reload failed"},
+	}, time.Now())
+	assert.True(t, done)
+	require.EqualError(t, err, "rollout of revision abc failed on node worker-1: frr reload failed")
+	assert.Contains(t, out.String(), "  worker-1: invalid: frr reload failed\n")
+}
@@ -28,17 +28,26 @@ import (
 	"github.com/telekom/das-schiff-network-operator/cmd/kubectl-nnc/renderer"
 )
 
+var showOutput string
+
 func newShowCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "show <node-name>",
 		Short: "Show detailed NodeNetworkConfig for a node",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runShow,
+		Long: "Shows the NodeNetworkConfig of a node as a tree, as JSON or YAML including its parsed origins, " +
+			"or as a DOT or Mermaid graph of its VRFs, L2 VNIs, VRF imports and BGP peers.",
+		Args: cobra.ExactArgs(1),
+		RunE: runShow,
 	}
+	cmd.Flags().StringVarP(&showOutput, "output", "o", "", outputUsage)
+	return cmd
 }
 
 func runShow(_ *cobra.Command, args []string) error {
 	nodeName := args[0]
+	if err := validateOutput(showOutput); err != nil {
+		return err
+	}
 
 	c, err := newClient()
 	if err != nil {
@@ -50,6 +59,13 @@ func runShow(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("getting NodeNetworkConfig for node %q: %w", nodeName, err)
 	}
 
+	done, err := renderStructured(os.Stdout, showOutput, renderer.NewNNCDocument(nnc), func() *renderer.Graph {
+		return renderer.BuildGraph([]networkv1alpha1.NodeNetworkConfig{*nnc})
+	})
+	if done {
+		return err
+	}
+
 	// Parse origins annotation if present.
 	origins := renderer.ParseOrigins(nnc.Annotations)
 
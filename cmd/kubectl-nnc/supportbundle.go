@@ -0,0 +1,417 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	nc "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
+)
+
+const (
+	redacted = "REDACTED"
+
+	defaultAgentNamespace    = "kube-system"
+	defaultAgentSelector     = "app.kubernetes.io/component in (operator,agent-cra-frr,agent-cra-vsr,agent-netplan,agent-hbn-l2)"
+	defaultMonitoringService = "network-operator-status"
+	defaultMonitoringPort    = "7080"
+	defaultLogLines          = 10000
+
+	fileMode = 0o644
+)
+
+var (
+	// secretKeyParts are the parts of the names of fields holding secrets.
+	secretKeyParts = []string{"password", "secret", "token"}
+
+	// logPasswordPatterns match secrets in JSON logs and passwords in FRR
+	// config lines ("neighbor 10.0.0.1 password secret").
+	logPasswordPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)("[^"]*(?:password|secret|token)[^"]*"\s*:\s*")(?:[^"\\]|\\.)*(")`),
+		regexp.MustCompile(`(?i)(\bpassword\s+)\S+()`),
+	}
+)
+
+type supportBundleOptions struct {
+	output            string
+	namespace         string
+	agentNamespace    string
+	agentSelector     string
+	monitoringService string
+	monitoringPort    string
+	logLines          int64
+}
+
+// frrQuery is a monitoring endpoint query whose per-node results are stored
+// as frr/<node>/<name>.json.
+type frrQuery struct {
+	name   string
+	path   string
+	params map[string]string
+}
+
+func newSupportBundleCmd() *cobra.Command {
+	opts := &supportBundleOptions{}
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collect the network state of the cluster into a tarball",
+		Long: "Writes a gzipped tarball with the intent CRDs including their status, the NodeNetworkConfigs, " +
+			"NodeNetplanConfigs, NetworkConfigRevisions and other network.t-caas.telekom.com resources, the logs " +
+			"of the operator and agent pods, and per node the FRR BGP summary, EVPN state and routes of every VRF, " +
+			"fetched through the monitoring endpoint's /all/show fan-out. Passwords are redacted. Data that cannot " +
+			"be collected is listed in errors.txt in the bundle.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSupportBundle(cmd, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.output, "output-file", "", "path of the tarball (default: nnc-support-bundle-<time>.tar.gz)")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "", "namespace of the intent CRDs (default: all namespaces)")
+	cmd.Flags().StringVar(&opts.agentNamespace, "agent-namespace", defaultAgentNamespace, "namespace of the operator, agent and monitoring pods")
+	cmd.Flags().StringVar(&opts.agentSelector, "agent-selector", defaultAgentSelector, "label selector of the pods to collect logs from")
+	cmd.Flags().StringVar(&opts.monitoringService, "monitoring-service", defaultMonitoringService, "service of the monitoring endpoint")
+	cmd.Flags().StringVar(&opts.monitoringPort, "monitoring-port", defaultMonitoringPort, "port of the monitoring endpoint service")
+	cmd.Flags().Int64Var(&opts.logLines, "log-lines", defaultLogLines, "number of most recent log lines to collect per container")
+	return cmd
+}
+
+func runSupportBundle(cmd *cobra.Command, opts *supportBundleOptions) error {
+	ctx := context.Background()
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+	cs, err := newClientset()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if opts.output == "" {
+		opts.output = fmt.Sprintf("nnc-support-bundle-%s.tar.gz", now.UTC().Format("20060102-150405"))
+	}
+	f, err := os.Create(opts.output)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", opts.output, err)
+	}
+	defer f.Close()
+
+	b := newBundle(f, strings.TrimSuffix(path.Base(opts.output), ".tar.gz"), now)
+	if err := collectResources(ctx, c, b, opts.namespace); err != nil {
+		return err
+	}
+	if err := collectLogs(ctx, cs, b, opts); err != nil {
+		return err
+	}
+	vrfs, err := bundleVRFs(ctx, c)
+	if err != nil {
+		b.warn("listing VRFs for route dumps: %v", err)
+	}
+	if err := collectFRR(ctx, cs, b, opts, vrfs); err != nil {
+		return err
+	}
+	if err := b.close(); err != nil {
+		return fmt.Errorf("writing %s: %w", opts.output, err)
+	}
+
+	for _, e := range b.errs {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", e)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote support bundle to %s (%d warnings).\n", opts.output, len(b.errs))
+	return nil
+}
+
+// bundle writes files into a gzipped tarball below a common directory and
+// collects the errors of data that could not be gathered.
+type bundle struct {
+	gz   *gzip.Writer
+	tw   *tar.Writer
+	dir  string
+	now  time.Time
+	errs []string
+}
+
+func newBundle(w io.Writer, dir string, now time.Time) *bundle {
+	gz := gzip.NewWriter(w)
+	return &bundle{gz: gz, tw: tar.NewWriter(gz), dir: dir, now: now}
+}
+
+func (b *bundle) add(name string, data []byte) error {
+	if err := b.tw.WriteHeader(&tar.Header{
+		Name:    path.Join(b.dir, name),
+		Mode:    fileMode,
+		Size:    int64(len(data)),
+		ModTime: b.now,
+	}); err != nil {
+		return fmt.Errorf("writing header of %s: %w", name, err)
+	}
+	if _, err := b.tw.Write(data); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+func (b *bundle) warn(format string, args ...any) {
+	b.errs = append(b.errs, fmt.Sprintf(format, args...))
+}
+
+// close writes errors.txt and flushes the tarball.
+func (b *bundle) close() error {
+	if len(b.errs) > 0 {
+		if err := b.add("errors.txt", []byte(strings.Join(b.errs, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+	if err := b.tw.Close(); err != nil {
+		return fmt.Errorf("closing tar: %w", err)
+	}
+	if err := b.gz.Close(); err != nil {
+		return fmt.Errorf("closing gzip: %w", err)
+	}
+	return nil
+}
+
+// collectResources stores all objects of the network-connector and
+// network.t-caas.telekom.com kinds known to the scheme, one file per kind.
+// Kinds whose CRD is not installed are skipped.
+func collectResources(ctx context.Context, c client.Client, b *bundle, namespace string) error {
+	groups := map[string]bool{nc.GroupVersion.Group: true, networkv1alpha1.GroupVersion.Group: true}
+
+	var lists []string
+	for gvk := range scheme.AllKnownTypes() {
+		if groups[gvk.Group] && strings.HasSuffix(gvk.Kind, "List") {
+			lists = append(lists, gvk.GroupVersion().String()+"/"+gvk.Kind)
+		}
+	}
+	sort.Strings(lists)
+
+	for _, key := range lists {
+		idx := strings.LastIndex(key, "/")
+		gv, listKind := key[:idx], key[idx+1:]
+
+		list := &unstructured.UnstructuredList{}
+		list.SetAPIVersion(gv)
+		list.SetKind(listKind)
+		if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+				continue
+			}
+			b.warn("listing %s: %v", listKind, err)
+			continue
+		}
+		if len(list.Items) == 0 {
+			continue
+		}
+		for i := range list.Items {
+			unstructured.RemoveNestedField(list.Items[i].Object, "metadata", "managedFields")
+			// the last applied configuration embeds the whole spec, secrets included
+			unstructured.RemoveNestedField(list.Items[i].Object, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+			redactSecrets(list.Items[i].Object)
+		}
+
+		data, err := yaml.Marshal(list.UnstructuredContent())
+		if err != nil {
+			return fmt.Errorf("marshaling %s: %w", listKind, err)
+		}
+		group := strings.Split(gv, "/")[0]
+		if err := b.add(path.Join("resources", group, strings.TrimSuffix(listKind, "List")+".yaml"), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redactSecrets replaces the values of all fields whose name indicates a
+// secret, such as password, secretKey or token.
+func redactSecrets(v any) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, item := range t {
+			if s, ok := item.(string); ok && s != "" && isSecretKey(k) {
+				t[k] = redacted
+				continue
+			}
+			redactSecrets(item)
+		}
+	case []any:
+		for _, item := range t {
+			redactSecrets(item)
+		}
+	}
+}
+
+// isSecretKey returns true if the field name k indicates a secret.
+func isSecretKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, part := range secretKeyParts {
+		if strings.Contains(k, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactLog replaces secrets in log lines.
+func redactLog(data []byte) []byte {
+	for _, re := range logPasswordPatterns {
+		data = re.ReplaceAll(data, []byte("${1}"+redacted+"${2}"))
+	}
+	return data
+}
+
+// collectLogs stores the logs of all containers of the selected pods, and the
+// logs of their previous instance if they restarted.
+func collectLogs(ctx context.Context, cs kubernetes.Interface, b *bundle, opts *supportBundleOptions) error {
+	pods, err := cs.CoreV1().Pods(opts.agentNamespace).List(ctx, metav1.ListOptions{LabelSelector: opts.agentSelector})
+	if err != nil {
+		b.warn("listing pods in %s: %v", opts.agentNamespace, err)
+		return nil
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		restarted := make(map[string]bool)
+		for _, st := range pod.Status.ContainerStatuses {
+			restarted[st.Name] = st.RestartCount > 0
+		}
+		for _, container := range pod.Spec.Containers {
+			previous := []bool{false}
+			if restarted[container.Name] {
+				previous = append(previous, true)
+			}
+			for _, prev := range previous {
+				logOpts := &corev1.PodLogOptions{Container: container.Name, Previous: prev}
+				if opts.logLines > 0 {
+					logOpts.TailLines = &opts.logLines
+				}
+				data, err := cs.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOpts).DoRaw(ctx)
+				if err != nil {
+					b.warn("getting logs of %s/%s: %v", pod.Name, container.Name, err)
+					continue
+				}
+				name := container.Name + ".log"
+				if prev {
+					name = container.Name + ".previous.log"
+				}
+				if err := b.add(path.Join("logs", pod.Spec.NodeName, pod.Name, name), redactLog(data)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// bundleVRFs returns the names of all VRFs configured on any node, including
+// the cluster VRF ("default" in FRR).
+func bundleVRFs(ctx context.Context, c client.Client) ([]string, error) {
+	nncs := &networkv1alpha1.NodeNetworkConfigList{}
+	if err := c.List(ctx, nncs); err != nil {
+		return nil, fmt.Errorf("listing NodeNetworkConfigs: %w", err)
+	}
+	names := map[string]bool{"default": true}
+	for i := range nncs.Items {
+		for name := range nncs.Items[i].Spec.FabricVRFs {
+			names[name] = true
+		}
+		for name := range nncs.Items[i].Spec.LocalVRFs {
+			names[name] = true
+		}
+	}
+	vrfs := make([]string, 0, len(names))
+	for name := range names {
+		vrfs = append(vrfs, name)
+	}
+	sort.Strings(vrfs)
+	return vrfs, nil
+}
+
+// frrQueries returns the monitoring endpoint queries of the bundle.
+func frrQueries(vrfs []string) []frrQuery {
+	queries := []frrQuery{
+		{name: "bgp-summary", path: "/all/show/bgp/summary"},
+		{name: "evpn-vni", path: "/all/show/evpn"},
+		{name: "evpn-rmac", path: "/all/show/evpn", params: map[string]string{"type": "rmac"}},
+		{name: "evpn-mac", path: "/all/show/evpn", params: map[string]string{"type": "mac"}},
+		{name: "evpn-next-hops", path: "/all/show/evpn", params: map[string]string{"type": "next-hops"}},
+	}
+	for _, vrf := range vrfs {
+		queries = append(queries,
+			frrQuery{name: "route-" + vrf + "-ipv4", path: "/all/show/route", params: map[string]string{"vrf": vrf, "protocol": "ip"}},
+			frrQuery{name: "route-" + vrf + "-ipv6", path: "/all/show/route", params: map[string]string{"vrf": vrf, "protocol": "ipv6"}},
+		)
+	}
+	return queries
+}
+
+// collectFRR queries the monitoring endpoint through the API server's service
+// proxy. Its /all fan-out returns the results of all nodes, which are stored
+// per node.
+func collectFRR(ctx context.Context, cs kubernetes.Interface, b *bundle, opts *supportBundleOptions, vrfs []string) error {
+	for _, q := range frrQueries(vrfs) {
+		data, err := cs.CoreV1().Services(opts.agentNamespace).
+			ProxyGet("http", opts.monitoringService, opts.monitoringPort, q.path, q.params).DoRaw(ctx)
+		if err != nil {
+			b.warn("querying %s: %v", q.name, err)
+			continue
+		}
+		if err := addPerNode(b, q.name, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addPerNode splits a fan-out response, a list of objects keyed by node name,
+// into one file per node. Responses of another shape are stored as is.
+func addPerNode(b *bundle, name string, data []byte) error {
+	var responses []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &responses); err != nil {
+		return b.add(path.Join("frr", name+".json"), data)
+	}
+	for _, response := range responses {
+		for node, raw := range response {
+			if err := b.add(path.Join("frr", node, name+".json"), raw); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	nc "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
+)
+
+// readBundle returns the files of a bundle by their path below its directory.
+func readBundle(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(content)
+	}
+}
+
+func TestSupportBundle_Resources(t *testing.T) {
+	password, peerAddress := "s3cret", "10.0.0.1"
+	nnc := &networkv1alpha1.NodeNetworkConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Annotations: map[string]string{
+			corev1.LastAppliedConfigAnnotation: `{"spec":{"password":"` + password + `"}}`,
+		}},
+		Spec: networkv1alpha1.NodeNetworkConfigSpec{
+			FabricVRFs: map[string]networkv1alpha1.FabricVRF{
+				"m2m": {VNI: 2000, VRF: networkv1alpha1.VRF{BGPPeers: []networkv1alpha1.BGPPeer{
+					{Address: &peerAddress, RemoteASN: 65000, Password: &password},
+				}}},
+			},
+		},
+		Status: networkv1alpha1.NodeNetworkConfigStatus{ConfigStatus: "provisioned"},
+	}
+	vrf := &nc.VRF{ObjectMeta: metav1.ObjectMeta{Name: "m2m", Namespace: "tenant"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nnc, vrf).Build()
+
+	var buf bytes.Buffer
+	b := newBundle(&buf, "bundle", time.Now())
+	require.NoError(t, collectResources(context.Background(), c, b, ""))
+	vrfs, err := bundleVRFs(context.Background(), c)
+	require.NoError(t, err)
+	require.NoError(t, b.close())
+
+	files := readBundle(t, buf.Bytes())
+	nncs := files["bundle/resources/network.t-caas.telekom.com/NodeNetworkConfig.yaml"]
+	assert.Contains(t, nncs, "name: worker-1")
+	assert.Contains(t, nncs, "configStatus: provisioned")
+	assert.Contains(t, nncs, "password: REDACTED")
+	assert.NotContains(t, nncs, password)
+	assert.NotContains(t, nncs, corev1.LastAppliedConfigAnnotation)
+	assert.Contains(t, files["bundle/resources/network-connector.sylvaproject.org/VRF.yaml"], "namespace: tenant")
+	assert.NotContains(t, files, "bundle/errors.txt")
+	assert.Equal(t, []string{"default", "m2m"}, vrfs)
+}
+
+func TestSupportBundle_Logs(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "agent-cra-frr-abc", Namespace: "kube-system",
+			Labels: map[string]string{"app.kubernetes.io/component": "agent-cra-frr"},
+		},
+		Spec: corev1.PodSpec{NodeName: "worker-1", Containers: []corev1.Container{{Name: "agent"}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "agent", RestartCount: 1},
+		}},
+	}
+	cs := k8sfake.NewClientset(pod)
+
+	var buf bytes.Buffer
+	b := newBundle(&buf, "bundle", time.Now())
+	opts := &supportBundleOptions{agentNamespace: "kube-system", agentSelector: defaultAgentSelector, logLines: defaultLogLines}
+	require.NoError(t, collectLogs(context.Background(), cs, b, opts))
+	require.NoError(t, b.close())
+
+	files := readBundle(t, buf.Bytes())
+	assert.Contains(t, files, "bundle/logs/worker-1/agent-cra-frr-abc/agent.log")
+	assert.Contains(t, files, "bundle/logs/worker-1/agent-cra-frr-abc/agent.previous.log")
+}
+
+func TestRedactSecrets(t *testing.T) {
+	obj := map[string]any{
+		"spec": map[string]any{
+			"password":   "s3cret",
+			"authToken":  "t0ken",
+			"items":      []any{map[string]any{"clientSecret": "s3cret", "name": "peer"}},
+			"passwordOf": "",
+			"remoteASN":  int64(65000),
+		},
+	}
+	redactSecrets(obj)
+	assert.Equal(t, map[string]any{
+		"spec": map[string]any{
+			"password":   redacted,
+			"authToken":  redacted,
+			"items":      []any{map[string]any{"clientSecret": redacted, "name": "peer"}},
+			"passwordOf": "",
+			"remoteASN":  int64(65000),
+		},
+	}, obj)
+}
+
+func TestRedactLog(t *testing.T) {
+	in := `{"msg":"applying","config":{"password":"s3cr\"et","remoteAsn":65000}}` + "\n" +
+		`{"msg":"login","bearerToken":"t0ken"}` + "\n" +
+		" neighbor 10.0.0.1 password s3cret\n"
+	out := string(redactLog([]byte(in)))
+	assert.Equal(t, `{"msg":"applying","config":{"password":"REDACTED","remoteAsn":65000}}`+"\n"+
+		`{"msg":"login","bearerToken":"REDACTED"}`+"\n"+
+		" neighbor 10.0.0.1 password REDACTED\n", out)
+}
+
+func TestAddPerNode(t *testing.T) {
+	var buf bytes.Buffer
+	b := newBundle(&buf, "bundle", time.Now())
+	require.NoError(t, addPerNode(b, "bgp-summary", []byte(`[{"worker-1":{"ipv4Unicast":{}}},{"worker-2":{}}]`)))
+	require.NoError(t, addPerNode(b, "evpn-vni", []byte("error: not json")))
+	require.NoError(t, b.close())
+
+	files := readBundle(t, buf.Bytes())
+	assert.Equal(t, `{"ipv4Unicast":{}}`, files["bundle/frr/worker-1/bgp-summary.json"])
+	assert.Equal(t, `{}`, files["bundle/frr/worker-2/bgp-summary.json"])
+	assert.Equal(t, "error: not json", files["bundle/frr/evpn-vni.json"])
+}
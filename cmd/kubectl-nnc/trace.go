@@ -0,0 +1,74 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/cmd/kubectl-nnc/renderer"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent"
+)
+
+var traceNamespace string
+
+func newTraceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trace <Kind/name>",
+		Short: "Show the NodeNetworkConfig sections an intent CRD produces on each node",
+		Long: "Lists the nodes whose NodeNetworkConfigs carry sections originating from the given intent CRD " +
+			"(e.g. Layer2Attachment/storage or VRF/m2m), according to their origins annotations. Nodes the " +
+			"intent CRD selects that do not carry its sections yet, or still carry sections it no longer " +
+			"produces, are listed with the reason: a pending rollout, an invalid config, a quarantined node " +
+			"or a config that cannot be rendered.",
+		Args: cobra.ExactArgs(1),
+		RunE: runTrace,
+	}
+	cmd.Flags().StringVarP(&traceNamespace, "namespace", "n", "", "namespace of the intent CRDs (default: all namespaces)")
+	return cmd
+}
+
+func runTrace(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	fetched, err := intent.FetchAll(ctx, c, traceNamespace, logr.Discard())
+	if err != nil {
+		return fmt.Errorf("fetching intent CRDs: %w", err)
+	}
+
+	nncs := &networkv1alpha1.NodeNetworkConfigList{}
+	if err := c.List(ctx, nncs); err != nil {
+		return fmt.Errorf("listing NodeNetworkConfigs: %w", err)
+	}
+
+	trace, err := intent.TraceSource(ctx, fetched, nncs.Items, args[0], logr.Discard())
+	if err != nil {
+		return fmt.Errorf("tracing %s: %w", args[0], err)
+	}
+
+	renderer.New(cmd.OutOrStdout(), !noColor).RenderTrace(trace)
+	return nil
+}
@@ -73,6 +73,11 @@ type operatorConfig struct {
 	configTimeout                string
 	preconfigTimeout             string
 	maxUpdating                  int
+	healthWindow                 string
+	healthConditions             string
+	revisionHistoryLimit         int
+	revisionHistoryMaxAge        string
+	quarantineAfter              int
 	disableCertRotation          bool
 	disableRestartOnCertRefresh  bool
 	processImportsAsStaticRoutes bool
@@ -100,6 +105,16 @@ func parseFlags() *operatorConfig {
 		"Timeout for NodeConfig reconciliation process, when agent picked the work")
 	flag.IntVar(&cfg.maxUpdating, "max-updating", 1,
 		"Configures how many nodes can be updated simultaneously when rolling update is performed.")
+	flag.StringVar(&cfg.healthWindow, "health-window", "0s",
+		"Time after a node was updated during which a health regression rolls the revision back to the last valid one (0s disables it).")
+	flag.StringVar(&cfg.healthConditions, "health-conditions", operator.DefaultHealthConditions,
+		"Comma separated list of Node conditions that must not turn False during the health window.")
+	flag.IntVar(&cfg.revisionHistoryLimit, "revision-history-limit", 0,
+		"Number of superseded NetworkConfigRevisions to keep for rollbacks.")
+	flag.StringVar(&cfg.revisionHistoryMaxAge, "revision-history-max-age", "0s",
+		"Keep all superseded NetworkConfigRevisions younger than this for rollbacks (0s disables it).")
+	flag.IntVar(&cfg.quarantineAfter, "quarantine-after", 0,
+		"Number of consecutive NetworkConfigRevisions a node may fail before it is quarantined and excluded from the rollout (0 disables it).")
 	flag.BoolVar(&cfg.disableCertRotation, "disable-cert-rotation", false,
 		"Disables certificate rotation if set true.")
 	flag.BoolVar(&cfg.disableRestartOnCertRefresh, "disable-restart-on-cert-rotation", false,
@@ -277,7 +292,22 @@ func setupReconcilers(mgr manager.Manager, cfg *operatorConfig) error {
 }
 
 func setupIntentReconciler(mgr manager.Manager, apiTimeout time.Duration, cfg *operatorConfig) error {
-	ir, err := intentreconciler.NewReconciler(mgr.GetClient(), mgr.GetLogger().WithName("IntentReconciler"), apiTimeout, cfg.intentNamespace)
+	configTimeoutVal, err := time.ParseDuration(cfg.configTimeout)
+	if err != nil {
+		return fmt.Errorf("error parsing config timeout value %s: %w", cfg.configTimeout, err)
+	}
+
+	preconfigTimeoutVal, err := time.ParseDuration(cfg.preconfigTimeout)
+	if err != nil {
+		return fmt.Errorf("error parsing preconfig timeout value %s: %w", cfg.preconfigTimeout, err)
+	}
+
+	ir, err := intentreconciler.NewReconciler(mgr.GetClient(), mgr.GetLogger().WithName("IntentReconciler"), apiTimeout, cfg.intentNamespace,
+		intentreconciler.RolloutConfig{
+			MaxUpdating:      cfg.maxUpdating,
+			ConfigTimeout:    configTimeoutVal,
+			PreconfigTimeout: preconfigTimeoutVal,
+		})
 	if err != nil {
 		return fmt.Errorf("unable to create intent reconciler: %w", err)
 	}
@@ -341,6 +371,9 @@ func setupIntentReconciler(mgr manager.Manager, apiTimeout time.Duration, cfg *o
 	if err = (&networkconnector.InterfaceConfig{}).SetupWebhookWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create webhook for InterfaceConfig: %w", err)
 	}
+	if err = (&networkconnector.NodeAttachment{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create webhook for NodeAttachment: %w", err)
+	}
 
 	setupLog.Info("intent reconciler enabled — legacy ConfigReconciler disabled")
 	return nil
@@ -357,6 +390,16 @@ func setupLegacyReconcilers(mgr manager.Manager, apiTimeout time.Duration, cfg *
 		return fmt.Errorf("error parsing preconfig timeout value %s: %w", cfg.preconfigTimeout, err)
 	}
 
+	healthWindowVal, err := time.ParseDuration(cfg.healthWindow)
+	if err != nil {
+		return fmt.Errorf("error parsing health window value %s: %w", cfg.healthWindow, err)
+	}
+
+	revisionHistoryMaxAgeVal, err := time.ParseDuration(cfg.revisionHistoryMaxAge)
+	if err != nil {
+		return fmt.Errorf("error parsing revision history max age value %s: %w", cfg.revisionHistoryMaxAge, err)
+	}
+
 	cr, err := operator.NewConfigReconciler(mgr.GetClient(), mgr.GetLogger().WithName("ConfigReconciler"), apiTimeout)
 	if err != nil {
 		return fmt.Errorf("unable to create config reconciler reconciler: %w", err)
@@ -367,7 +410,16 @@ func setupLegacyReconcilers(mgr manager.Manager, apiTimeout time.Duration, cfg *
 		importMode = operator.ImportModeStaticRoute
 	}
 
-	ncr, err := operator.NewNodeConfigReconciler(mgr.GetClient(), mgr.GetLogger().WithName("NodeConfigReconciler"), apiTimeout, configTimeoutVal, preconfigTimeoutVal, mgr.GetScheme(), cfg.maxUpdating, importMode)
+	ncr, err := operator.NewNodeConfigReconciler(mgr.GetClient(), mgr.GetLogger().WithName("NodeConfigReconciler"), mgr.GetScheme(), operator.NodeConfigReconcilerOptions{
+		APITimeout:       apiTimeout,
+		ConfigTimeout:    configTimeoutVal,
+		PreconfigTimeout: preconfigTimeoutVal,
+		MaxUpdating:      cfg.maxUpdating,
+		ImportMode:       importMode,
+		HealthMonitoring: operator.HealthMonitoring{Window: healthWindowVal, Conditions: operator.ParseHealthConditions(cfg.healthConditions)},
+		RevisionHistory:  operator.RevisionHistory{Limit: cfg.revisionHistoryLimit, MaxAge: revisionHistoryMaxAgeVal},
+		QuarantineAfter:  cfg.quarantineAfter,
+	})
 	if err != nil {
 		return fmt.Errorf("unable to create node reconciler: %w", err)
 	}
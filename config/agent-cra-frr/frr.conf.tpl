@@ -1,12 +1,13 @@
 {{- /*gotype:github.com/telekom/das-schiff-network-operator/pkg/cra-frr.frrTemplateData*/ -}}
 {{ define "staticRoutes" }}
 {{ range $route := . }}
-{{ if $route.NextHop }}
-{{ if $route.NextHop.Address }}
-{{ if isIPv4 $route.Prefix }}ip{{ else }}ipv6{{ end }} route {{ $route.Prefix }} {{ $route.NextHop.Address }}
+{{/* staticd has no weighted next hops, the next hops are equal-cost */}}
+{{ range $nh := $route.AllNextHops }}
+{{ if $nh.Address }}
+{{ if isIPv4 $route.Prefix }}ip{{ else }}ipv6{{ end }} route {{ $route.Prefix }} {{ $nh.Address }}{{ if $nh.BFD }} bfd{{ with $route.BFDProfile }} profile {{ bfdProfileName .MinInterval }}{{ end }}{{ end }}
 {{ end }}
-{{ if $route.NextHop.Vrf }}
-{{ if isIPv4 $route.Prefix }}ip{{ else }}ipv6{{ end }} route {{ $route.Prefix }} {{ $route.NextHop.Vrf }} nexthop-vrf {{ $route.NextHop.Vrf }}
+{{ if $nh.Vrf }}
+{{ if isIPv4 $route.Prefix }}ip{{ else }}ipv6{{ end }} route {{ $route.Prefix }} {{ $nh.Vrf }} nexthop-vrf {{ $nh.Vrf }}
 {{ end }}
 {{ else }}
 {{ if isIPv4 $route.Prefix }}ip{{ else }}ipv6{{ end }} route {{ $route.Prefix }} blackhole
@@ -26,8 +27,17 @@
 {{ if $item.Matcher.Prefix }}
 {{ template "prefixListEntry" dict "Matcher" $item.Matcher.Prefix "Name" (printf "pl_%s_%d" $param.Name $j) "Action" "permit" "Seq" 5 }}
 {{ end }}
-{{ if $item.Matcher.BGPCommunity }}
-bgp community-list standard cm_{{ $param.Name }}_{{ $j }} permit {{ $item.Matcher.BGPCommunity }}
+{{ with $item.Matcher.BGPCommunity }}
+{{ if eq .Type "large" }}
+bgp large-community-list standard cm_{{ $param.Name }}_{{ $j }} permit {{ .Community }}
+{{ else if eq .Type "extended" }}
+bgp extcommunity-list standard cm_{{ $param.Name }}_{{ $j }} permit {{ .Community }}
+{{ else }}
+bgp community-list standard cm_{{ $param.Name }}_{{ $j }} permit {{ .Community }}
+{{ end }}
+{{ end }}
+{{ if $item.Matcher.ASPath }}
+bgp as-path access-list asp_{{ $param.Name }}_{{ $j }} permit {{ $item.Matcher.ASPath.Regex }}
 {{ end }}
 {{ if $item.Action.ModifyRoute }}
 {{ if $item.Action.ModifyRoute.RemoveCommunities }}
@@ -45,20 +55,60 @@ match ip address prefix-list pl_{{ $param.Name }}_{{ $j }}
 match ipv6 address prefix-list pl_{{ $param.Name }}_{{ $j }}
 {{ end }}
 {{ end }}
-{{ if $item.Matcher.BGPCommunity }}
-match community cm_{{ $param.Name }}_{{ $j }}
+{{ with $item.Matcher.BGPCommunity }}
+{{ if eq .Type "large" }}
+match large-community cm_{{ $param.Name }}_{{ $j }}{{ if .ExactMatch }} exact-match{{ end }}
+{{ else if eq .Type "extended" }}
+match extcommunity cm_{{ $param.Name }}_{{ $j }}
+{{ else }}
+match community cm_{{ $param.Name }}_{{ $j }}{{ if .ExactMatch }} exact-match{{ end }}
+{{ end }}
+{{ end }}
+{{ if $item.Matcher.ASPath }}
+match as-path asp_{{ $param.Name }}_{{ $j }}
 {{ end }}
 {{ if $item.Action.ModifyRoute }}
 {{ if $item.Action.ModifyRoute }}
 {{ if $item.Action.ModifyRoute.AddCommunities }}
 set community {{ join $item.Action.ModifyRoute.AddCommunities " " }}{{ if $item.Action.ModifyRoute.AdditiveCommunities }} additive{{ end }}
 {{ end }}
+{{ if $item.Action.ModifyRoute.AddLargeCommunities }}
+set large-community {{ join $item.Action.ModifyRoute.AddLargeCommunities " " }}{{ if $item.Action.ModifyRoute.AdditiveCommunities }} additive{{ end }}
+{{ end }}
+{{ range $kind, $values := extCommunities $item.Action.ModifyRoute.AddExtCommunities }}
+set extcommunity {{ $kind }} {{ join $values " " }}
+{{ end }}
 {{ if $item.Action.ModifyRoute.RemoveCommunities }}
 set comm-list cm_remove_{{ $param.Name }}_{{ $j }} delete
 {{ end }}
 {{ if $item.Action.ModifyRoute.RemoveAllCommunities }}
 set community none
 {{ end }}
+{{ if $item.Action.ModifyRoute.ASPathPrepend }}
+set as-path prepend {{ asPathPrepend $item.Action.ModifyRoute.ASPathPrepend }}
+{{ end }}
+{{ if $item.Action.ModifyRoute.SetLocalPreference }}
+set local-preference {{ $item.Action.ModifyRoute.SetLocalPreference }}
+{{ end }}
+{{ if $item.Action.ModifyRoute.SetMED }}
+set metric {{ $item.Action.ModifyRoute.SetMED }}
+{{ end }}
+{{ if $item.Action.ModifyRoute.SetWeight }}
+set weight {{ $item.Action.ModifyRoute.SetWeight }}
+{{ end }}
+{{ with $item.Action.ModifyRoute.SetNextHop }}
+{{ $nh := deref . }}
+{{ if eq $nh "peer-address" }}
+set ip next-hop peer-address
+set ipv6 next-hop peer-address
+{{ else if eq $nh "unchanged" }}
+set ip next-hop unchanged
+{{ else if isIPv4 $nh }}
+set ip next-hop {{ $nh }}
+{{ else }}
+set ipv6 next-hop global {{ $nh }}
+{{ end }}
+{{ end }}
 {{ if eq $item.Action.Type "next" }}
 on-match next
 {{ end }}
@@ -256,6 +306,9 @@ router bgp {{ $.Config.LocalASN }} vrf {{ $name }}
     import vrf {{ $vrfImport.FromVRF }}
     {{ end }}
 	  import vrf route-map rm_{{ $name }}_import
+    {{- if $vrf.EVPNImportFilter }}
+    table-map rm_{{ $name }}_table
+    {{- end }}
   exit-address-family
 
   address-family ipv6 unicast
@@ -266,6 +319,9 @@ router bgp {{ $.Config.LocalASN }} vrf {{ $name }}
     import vrf {{ $vrfImport.FromVRF }}
     {{ end }}
     import vrf route-map rm_{{ $name }}_import
+    {{- if $vrf.EVPNImportFilter }}
+    table-map rm_{{ $name }}_table
+    {{- end }}
   exit-address-family
 
   address-family l2vpn evpn
@@ -281,6 +337,36 @@ router bgp {{ $.Config.LocalASN }} vrf {{ $name }}
 exit
 !
 {{ template "vrfFilters" dict "Vrf" $name "Imports" $vrf.VRFImports "BGPPeers" $vrf.BGPPeers }}
+{{- if $vrf.EVPNImportFilter }}
+{{/* FRR has no route-map on the EVPN import of a VRF, so the EVPN import filter
+     is applied by the table-map to the paths carrying one of the VRF's import
+     route targets (or the auto-derived one). Paths leaked from other VRFs are
+     left to their import filters, all other paths (BGP peers of the VRF,
+     redistributed routes) are installed unfiltered. */}}
+{{- if $vrf.EVPNImportRouteTargets }}
+{{- range $rt := $vrf.EVPNImportRouteTargets }}
+bgp extcommunity-list standard cm_{{ $name }}_evpn_rt permit rt {{ $rt }}
+{{- end }}
+{{- else }}
+bgp extcommunity-list standard cm_{{ $name }}_evpn_rt permit rt {{ $.Config.LocalASN }}:{{ $vrf.VNI }}
+{{- end }}
+!
+{{- range $i, $import := $vrf.VRFImports }}
+route-map rm_{{ $name }}_table permit {{ add $i 10 }}
+match source-vrf {{ $import.FromVRF }}
+end
+!
+{{- end }}
+route-map rm_{{ $name }}_table permit 1000
+match extcommunity cm_{{ $name }}_evpn_rt
+call rm_{{ $name }}_evpn_import
+end
+!
+route-map rm_{{ $name }}_table permit 1010
+end
+!
+{{ template "filter" dict "Filter" $vrf.EVPNImportFilter "Name" (printf "%s_evpn_import" $name) }}
+{{- end }}
 {{ end }}
 {{ end }}
 !
@@ -541,5 +627,11 @@ route-map rm_export_local permit 20
 exit
 !
 bfd
+{{ range $profile := staticBFDProfiles $.NodeConfig }}
+  profile {{ bfdProfileName $profile.MinInterval }}
+    receive-interval {{ $profile.MinInterval }}
+    transmit-interval {{ $profile.MinInterval }}
+  exit
+{{ end }}
 exit
 !
\ No newline at end of file
@@ -19,21 +19,28 @@ package intent
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
 	nc "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
 	intentreconciler "github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent"
 )
 
+// rolloutCheckInterval is the interval in which the rollout is re-checked even
+// if nothing changed, so that nodes exceeding the config timeouts are detected.
+const rolloutCheckInterval = time.Minute
+
 // Controller watches all intent CRDs and fans out to the intent reconciler.
 type Controller struct {
 	client.Client
@@ -69,6 +76,8 @@ type Controller struct {
 //+kubebuilder:rbac:groups=network-connector.sylvaproject.org,resources=announcementpolicies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=network-connector.sylvaproject.org,resources=nodeattachments,verbs=get;list;watch
 //+kubebuilder:rbac:groups=network-connector.sylvaproject.org,resources=nodeattachments/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=network.t-caas.telekom.com,resources=networkconfigrevisions,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=network.t-caas.telekom.com,resources=networkconfigrevisions/status,verbs=get;update;patch
 
 // Reconcile handles any intent CRD change by triggering the debounced reconciler.
 func (r *Controller) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
@@ -113,6 +122,7 @@ func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&nc.NodeAttachment{}, h, intentPred).
 		Watches(&corev1.Node{}, h, nodePred).
 		Watches(&networkv1alpha1.NodeNetworkConfig{}, h, builder.WithPredicates(nncStatusPredicate())).
+		WatchesRawSource(periodicTrigger(rolloutCheckInterval)).
 		Complete(r)
 	if err != nil {
 		return fmt.Errorf("error creating intent controller: %w", err)
@@ -120,3 +130,24 @@ func (r *Controller) SetupWithManager(mgr ctrl.Manager) error {
 
 	return nil
 }
+
+// periodicTrigger is a source that enqueues the single reconcile request every
+// interval, so the rollout of the NodeNetworkConfigs progresses even if nothing
+// changed.
+func periodicTrigger(interval time.Duration) source.Source {
+	return source.Func(func(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					queue.Add(reconcile.Request{})
+				}
+			}
+		}()
+		return nil
+	})
+}
@@ -8,9 +8,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
 	nc "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
@@ -31,7 +33,7 @@ func newTestController(t *testing.T) *Controller {
 	fakeClient := fake.NewClientBuilder().WithScheme(s).Build()
 	logger := zap.New(zap.UseDevMode(true))
 
-	reconciler, err := intentreconciler.NewReconciler(fakeClient, logger, 60*time.Second, "")
+	reconciler, err := intentreconciler.NewReconciler(fakeClient, logger, 60*time.Second, "", intentreconciler.RolloutConfig{MaxUpdating: 100})
 	if err != nil {
 		t.Fatalf("failed to create intent reconciler: %v", err)
 	}
@@ -54,6 +56,25 @@ func TestIntentReconcile_DoesNotRequeue(t *testing.T) {
 	}
 }
 
+func TestPeriodicTrigger_EnqueuesSingleRequest(t *testing.T) {
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := periodicTrigger(10*time.Millisecond).Start(ctx, queue); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if queue.Len() != 1 {
+		t.Fatalf("expected the ticks to collapse into 1 request, got %d", queue.Len())
+	}
+	if req, _ := queue.Get(); req != (reconcile.Request{}) {
+		t.Errorf("expected the empty request, got %v", req)
+	}
+}
+
 func TestIntentReconcile_NoErrorOnEmptyCluster(t *testing.T) {
 	r := newTestController(t)
 	result, err := r.Reconcile(context.Background(), ctrl.Request{})
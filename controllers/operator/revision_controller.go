@@ -50,6 +50,7 @@ type RevisionReconciler struct {
 //+kubebuilder:rbac:groups=network.t-caas.telekom.com,resources=networkconfigrevisions,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=network.t-caas.telekom.com,resources=networkconfigrevisions/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=network.t-caas.telekom.com,resources=networkconfigrevisions/finalizers,verbs=update
+//+kubebuilder:rbac:groups=network.t-caas.telekom.com,resources=rolloutpolicies,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -70,6 +71,7 @@ func (r *RevisionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&networkv1alpha1.NetworkConfigRevision{}).
 		Watches(&corev1.Node{}, &handler.EnqueueRequestForObject{}).
+		Watches(&networkv1alpha1.RolloutPolicy{}, &handler.EnqueueRequestForObject{}).
 		Owns(&networkv1alpha1.NodeNetworkConfig{}, builder.MatchEveryOwner).
 		Complete(r)
 	if err != nil {
@@ -0,0 +1,158 @@
+// Package bgpcommunity classifies and validates BGP community strings by their
+// format, so a single list of communities can carry standard, large and
+// extended communities:
+//   - standard communities are "AA:NN" with 16-bit parts, or a well-known
+//     community name such as "no-export";
+//   - large communities are "GA:LD1:LD2" with 32-bit parts (RFC 8092), which is
+//     the only way to tag routes with a 4-byte AS number;
+//   - extended communities are a route target or site of origin in FRR
+//     syntax, "rt ASN:NN" or "soo ASN:NN", where ASN may also be an IPv4
+//     address.
+package bgpcommunity
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Type is the type of a BGP community.
+type Type string
+
+const (
+	// Standard is a standard community (RFC 1997).
+	Standard Type = "standard"
+	// Large is a large community (RFC 8092).
+	Large Type = "large"
+	// Extended is an extended community (RFC 4360).
+	Extended Type = "extended"
+)
+
+// Extended community kinds, the first word of an extended community.
+const (
+	RouteTarget  = "rt"
+	SiteOfOrigin = "soo"
+)
+
+// wellKnown are the well-known standard communities FRR accepts by name.
+var wellKnown = map[string]bool{
+	"internet":          true,
+	"graceful-shutdown": true,
+	"accept-own":        true,
+	"llgr-stale":        true,
+	"no-llgr":           true,
+	"blackhole":         true,
+	"no-export":         true,
+	"no-advertise":      true,
+	"local-AS":          true,
+	"no-peer":           true,
+}
+
+// TypeOf returns the type of community by its format. Communities that are
+// neither large nor extended are standard, Validate checks their format.
+func TypeOf(community string) Type {
+	switch {
+	case strings.Contains(community, " "):
+		return Extended
+	case strings.Count(community, ":") == 2: //nolint:mnd
+		return Large
+	default:
+		return Standard
+	}
+}
+
+// Validate returns an error if community is not a valid standard, large or
+// extended community.
+func Validate(community string) error {
+	switch TypeOf(community) {
+	case Extended:
+		return validateExtended(community)
+	case Large:
+		for _, part := range strings.Split(community, ":") {
+			if _, err := strconv.ParseUint(part, 10, 32); err != nil {
+				return fmt.Errorf("invalid large community %q: parts must be 32-bit numbers", community)
+			}
+		}
+		return nil
+	default:
+		if wellKnown[community] {
+			return nil
+		}
+		as, value, ok := strings.Cut(community, ":")
+		if !ok {
+			return fmt.Errorf("invalid community %q: must be AA:NN, GA:LD1:LD2, rt ASN:NN, soo ASN:NN or a well-known community", community)
+		}
+		if _, err := strconv.ParseUint(as, 10, 16); err != nil {
+			return fmt.Errorf("invalid standard community %q: parts must be 16-bit numbers", community)
+		}
+		if _, err := strconv.ParseUint(value, 10, 16); err != nil {
+			return fmt.Errorf("invalid standard community %q: parts must be 16-bit numbers", community)
+		}
+		return nil
+	}
+}
+
+// validateExtended validates a "rt ASN:NN" or "soo ASN:NN" extended community.
+// As in FRR, the administrator may be a 2-byte AS with a 32-bit value, or a
+// 4-byte AS or IPv4 address with a 16-bit value.
+func validateExtended(community string) error {
+	kind, value, _ := strings.Cut(community, " ")
+	if kind != RouteTarget && kind != SiteOfOrigin {
+		return fmt.Errorf("invalid extended community %q: must start with %q or %q", community, RouteTarget, SiteOfOrigin)
+	}
+	admin, assigned, ok := strings.Cut(value, ":")
+	if !ok {
+		return fmt.Errorf("invalid extended community %q: value must be ASN:NN or IP:NN", community)
+	}
+
+	maxAssigned := uint64(math.MaxUint32)
+	if ip := net.ParseIP(admin); ip != nil {
+		if ip.To4() == nil {
+			return fmt.Errorf("invalid extended community %q: administrator must be an IPv4 address", community)
+		}
+		maxAssigned = math.MaxUint16
+	} else {
+		asn, err := strconv.ParseUint(admin, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid extended community %q: administrator must be an AS number or IPv4 address", community)
+		}
+		if asn > math.MaxUint16 {
+			maxAssigned = math.MaxUint16
+		}
+	}
+	if n, err := strconv.ParseUint(assigned, 10, 32); err != nil || n > maxAssigned {
+		return fmt.Errorf("invalid extended community %q: assigned number must be at most %d", community, maxAssigned)
+	}
+	return nil
+}
+
+// Split splits communities by type, keeping their order.
+func Split(communities []string) (standard, large, extended []string) {
+	for _, community := range communities {
+		switch TypeOf(community) {
+		case Large:
+			large = append(large, community)
+		case Extended:
+			extended = append(extended, community)
+		default:
+			standard = append(standard, community)
+		}
+	}
+	return standard, large, extended
+}
+
+// GroupExtended groups extended communities by kind, e.g. "rt 65000:1" and
+// "rt 65000:2" become {"rt": ["65000:1", "65000:2"]}.
+func GroupExtended(communities []string) map[string][]string {
+	if len(communities) == 0 {
+		return nil
+	}
+	groups := make(map[string][]string)
+	for _, community := range communities {
+		kind, value, _ := strings.Cut(community, " ")
+		groups[kind] = append(groups[kind], value)
+	}
+	return groups
+}
@@ -0,0 +1,89 @@
+package bgpcommunity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTypeOf(t *testing.T) {
+	cases := map[string]Type{
+		"65000:100":      Standard,
+		"no-export":      Standard,
+		"4200000000:1:2": Large,
+		"rt 65000:100":   Extended,
+		"soo 10.0.0.1:5": Extended,
+	}
+	for community, want := range cases {
+		if got := TypeOf(community); got != want {
+			t.Errorf("TypeOf(%q) = %q, want %q", community, got, want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := []string{
+		"65000:100",
+		"0:0",
+		"no-export",
+		"local-AS",
+		"4200000000:1:2",
+		"4294967295:4294967295:4294967295",
+		"rt 65000:100",
+		"rt 65000:4294967295",
+		"rt 4200000000:100",
+		"soo 10.0.0.1:5",
+	}
+	for _, community := range valid {
+		if err := Validate(community); err != nil {
+			t.Errorf("Validate(%q) = %v, want nil", community, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"65000",
+		"65536:1",
+		"65000:65536",
+		"no-such-community",
+		"4294967296:1:2",
+		"1:2:x",
+		"target 65000:100",
+		"rt 65000",
+		"rt 4200000000:65536",
+		"soo 10.0.0.1:65536",
+		"rt 2001:db8::1:1",
+		"rt x:1",
+	}
+	for _, community := range invalid {
+		if err := Validate(community); err == nil {
+			t.Errorf("Validate(%q) = nil, want error", community)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	standard, large, extended := Split([]string{"65000:1", "rt 65000:2", "4200000000:0:3", "no-export", "soo 65000:4"})
+	if want := []string{"65000:1", "no-export"}; !reflect.DeepEqual(standard, want) {
+		t.Errorf("standard = %v, want %v", standard, want)
+	}
+	if want := []string{"4200000000:0:3"}; !reflect.DeepEqual(large, want) {
+		t.Errorf("large = %v, want %v", large, want)
+	}
+	if want := []string{"rt 65000:2", "soo 65000:4"}; !reflect.DeepEqual(extended, want) {
+		t.Errorf("extended = %v, want %v", extended, want)
+	}
+}
+
+func TestGroupExtended(t *testing.T) {
+	got := GroupExtended([]string{"rt 65000:1", "soo 65000:3", "rt 65000:2"})
+	want := map[string][]string{
+		RouteTarget:  {"65000:1", "65000:2"},
+		SiteOfOrigin: {"65000:3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupExtended = %v, want %v", got, want)
+	}
+	if got := GroupExtended(nil); got != nil {
+		t.Errorf("GroupExtended(nil) = %v, want nil", got)
+	}
+}
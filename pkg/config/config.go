@@ -32,13 +32,28 @@ func LoadConfig() (*Config, error) {
 	return config, nil
 }
 
+// LoadConfigFile loads the config from the file at path.
+func LoadConfigFile(path string) (*Config, error) {
+	config := &Config{}
+
+	if err := config.load(path); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
 func (c *Config) ReloadConfig() error {
 	vniFile := vniMapFile
 	if val := os.Getenv("OPERATOR_CONFIG"); val != "" {
 		vniFile = val
 	}
 
-	read, err := os.ReadFile(vniFile)
+	return c.load(vniFile)
+}
+
+func (c *Config) load(path string) error {
+	read, err := os.ReadFile(path)
 	if err != nil {
 		return fmt.Errorf("error reading config file: %w", err)
 	}
@@ -57,3 +57,27 @@ var _ = Describe("LoadConfig()", func() {
 		}
 	})
 })
+
+var _ = Describe("LoadConfigFile()", func() {
+	It("returns error if cannot read config", func() {
+		_, err := LoadConfigFile("some-invalid-path")
+		Expect(err).To(HaveOccurred())
+	})
+	It("returns error if cannot unmarshal config", func() {
+		_, err := LoadConfigFile("./testdata/invalidConfig.yaml")
+		Expect(err).To(HaveOccurred())
+	})
+	It("ignores the environment", func() {
+		oldEnv, isSet := os.LookupEnv(operatorConfigEnv)
+		os.Setenv(operatorConfigEnv, "some-invalid-path")
+		_, err := LoadConfigFile("./testdata/config.yaml")
+		Expect(err).ToNot(HaveOccurred())
+		if isSet {
+			err = os.Setenv(operatorConfigEnv, oldEnv)
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			err = os.Unsetenv(operatorConfigEnv)
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+})
@@ -2,6 +2,7 @@ package cra
 
 import (
 	"bytes"
+	"cmp"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
@@ -9,10 +10,12 @@ import (
 	"net"
 	"os"
 	"reflect"
+	"slices"
 	"strings"
 	"text/template"
 
 	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/bgpcommunity"
 	"github.com/telekom/das-schiff-network-operator/pkg/config"
 )
 
@@ -111,6 +114,17 @@ func (tpl FRRTemplate) TemplateFRR(cfg *config.BaseConfig, nodeConfig *v1alpha1.
 			return i + j
 		},
 		"join": strings.Join,
+		"asPathPrepend": func(prepend *v1alpha1.ASPathPrepend) string {
+			return prepend.Path(cfg.LocalASN)
+		},
+		"deref": func(s *string) string {
+			return *s
+		},
+		"extCommunities":    bgpcommunity.GroupExtended,
+		"staticBFDProfiles": staticBFDProfiles,
+		"bfdProfileName": func(minInterval uint32) string {
+			return fmt.Sprintf("static-%d", minInterval)
+		},
 	}).Parse(string(frrTemplate))
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
@@ -122,3 +136,44 @@ func (tpl FRRTemplate) TemplateFRR(cfg *config.BaseConfig, nodeConfig *v1alpha1.
 	}
 	return result.String(), nil
 }
+
+// staticBFDProfiles returns the BFD profiles of the static routes with BFD
+// tracked next hops, one per interval, sorted by interval.
+func staticBFDProfiles(nodeConfig *v1alpha1.NodeNetworkConfigSpec) []v1alpha1.BFDProfile {
+	if nodeConfig == nil {
+		return nil
+	}
+
+	vrfs := make([]v1alpha1.VRF, 0, len(nodeConfig.FabricVRFs)+len(nodeConfig.LocalVRFs)+1)
+	if nodeConfig.ClusterVRF != nil {
+		vrfs = append(vrfs, *nodeConfig.ClusterVRF)
+	}
+	for name := range nodeConfig.FabricVRFs {
+		vrfs = append(vrfs, nodeConfig.FabricVRFs[name].VRF)
+	}
+	for name := range nodeConfig.LocalVRFs {
+		vrfs = append(vrfs, nodeConfig.LocalVRFs[name])
+	}
+
+	seen := make(map[uint32]bool)
+	var profiles []v1alpha1.BFDProfile
+	for i := range vrfs {
+		for j := range vrfs[i].StaticRoutes {
+			route := &vrfs[i].StaticRoutes[j]
+			if route.BFDProfile == nil || seen[route.BFDProfile.MinInterval] {
+				continue
+			}
+			for _, nh := range route.AllNextHops() {
+				if nh.BFD {
+					seen[route.BFDProfile.MinInterval] = true
+					profiles = append(profiles, *route.BFDProfile)
+					break
+				}
+			}
+		}
+	}
+	slices.SortFunc(profiles, func(a, b v1alpha1.BFDProfile) int {
+		return cmp.Compare(a.MinInterval, b.MinInterval)
+	})
+	return profiles
+}
@@ -85,7 +85,7 @@ func (m *Manager) postRequest(ctx context.Context, path string, body []byte) ([]
 
 		// Fail directly if a response is received, regardless of status code
 		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("unexpected status code (%d): %s", res.StatusCode, resBody)
+			return resBody, fmt.Errorf("unexpected status code (%d): %s", res.StatusCode, resBody)
 		}
 
 		// Success, return nil
@@ -96,23 +96,106 @@ func (m *Manager) postRequest(ctx context.Context, path string, body []byte) ([]
 	return nil, fmt.Errorf("all CRA URLs failed due to connection issues")
 }
 
-func (m *Manager) ApplyConfiguration(ctx context.Context, netlinkConfig *nl.NetlinkConfiguration, frrConfig string, policyRoutes []PolicyRoute) error {
-	craConfig := Configuration{
-		NetlinkConfiguration: *netlinkConfig,
-		FRRConfiguration:     frrConfig,
-		PolicyRoutes:         policyRoutes,
-	}
+// ApplyConfiguration applies the configuration on the CRA. The returned
+// result reports the stages the CRA ran and the Layer2s and VRFs that failed,
+// it is nil if the CRA did not report them.
+func (m *Manager) ApplyConfiguration(ctx context.Context, craConfig *Configuration) (*ApplyResult, error) {
 	jsonBody, err := json.Marshal(craConfig)
 	if err != nil {
-		return fmt.Errorf("error marshalling netlink configuration: %w", err)
+		return nil, fmt.Errorf("error marshalling netlink configuration: %w", err)
+	}
+
+	resBody, err := m.postRequest(ctx, "/frr/configuration", jsonBody)
+
+	// CRAs that predate apply results reply with an empty body or a plain error.
+	var result *ApplyResult
+	if len(resBody) > 0 {
+		result = &ApplyResult{}
+		if jsonErr := json.Unmarshal(resBody, result); jsonErr != nil {
+			result = nil
+		}
+	}
+	if err != nil && result != nil && result.Error != "" {
+		return result, fmt.Errorf("error applying configuration: %s", result.Error)
+	}
+	return result, err
+}
+
+// GetDrift returns the result of the CRA's last drift check.
+func (m *Manager) GetDrift(ctx context.Context) (*DriftReport, error) {
+	report := &DriftReport{}
+	if err := m.getJSON(ctx, "/frr/drift", report); err != nil {
+		return nil, fmt.Errorf("error getting drift report: %w", err)
+	}
+	return report, nil
+}
+
+// GetBootInfo returns the boot ID of the running CRA instance.
+func (m *Manager) GetBootInfo(ctx context.Context) (*BootInfo, error) {
+	info := &BootInfo{}
+	if err := m.getJSON(ctx, "/frr/boot-id", info); err != nil {
+		return nil, fmt.Errorf("error getting boot ID: %w", err)
+	}
+	return info, nil
+}
+
+// ListInterfaces returns the interfaces of the CRA's network namespace. It
+// implements nodestatus.Lister.
+func (m *Manager) ListInterfaces() ([]nl.InterfaceInformation, error) {
+	var interfaces []nl.InterfaceInformation
+	if err := m.getJSON(context.Background(), "/frr/interfaces", &interfaces); err != nil {
+		return nil, fmt.Errorf("error getting interfaces: %w", err)
+	}
+	return interfaces, nil
+}
+
+// ListRoutes returns the routes of the CRA's network namespace. It implements
+// nodestatus.Lister.
+func (m *Manager) ListRoutes() ([]nl.RouteEntry, error) {
+	var routes []nl.RouteEntry
+	if err := m.getJSON(context.Background(), "/frr/routes", &routes); err != nil {
+		return nil, fmt.Errorf("error getting routes: %w", err)
+	}
+	return routes, nil
+}
+
+// getJSON unmarshals the response of a GET request to path into out.
+func (m *Manager) getJSON(ctx context.Context, path string, out any) error {
+	for _, baseURL := range m.craURLs {
+		req, err := http.NewRequest(http.MethodGet, baseURL+path, http.NoBody)
+		if err != nil {
+			return fmt.Errorf("error creating request: %w", err)
+		}
+
+		res, err := m.client.Do(req.WithContext(ctx))
+		if err != nil {
+			continue
+		}
+
+		resBody, readErr := func() ([]byte, error) {
+			defer res.Body.Close()
+			return io.ReadAll(res.Body)
+		}()
+		if readErr != nil {
+			return fmt.Errorf("error reading response body: %w", readErr)
+		}
+		if res.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status code (%d): %s", res.StatusCode, resBody)
+		}
+
+		if err := json.Unmarshal(resBody, out); err != nil {
+			return fmt.Errorf("error unmarshalling response: %w", err)
+		}
+		return nil
 	}
 
-	_, err = m.postRequest(ctx, "/frr/configuration", jsonBody)
-	return err
+	return fmt.Errorf("all CRA URLs failed due to connection issues")
 }
 
+// ExecuteWithJSON runs a vtysh command on the CRA and returns its JSON output
+// (nil on error). Like frr.Cli.ExecuteWithJSON, "json" is appended to the command.
 func (m *Manager) ExecuteWithJSON(args []string) []byte {
-	command := strings.Join(args, " ")
+	command := strings.Join(append(args, "json"), " ")
 
 	resBody, err := m.postRequest(context.Background(), "/frr/command", []byte(command))
 	if err != nil {
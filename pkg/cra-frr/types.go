@@ -1,6 +1,11 @@
 package cra
 
-import "github.com/telekom/das-schiff-network-operator/pkg/nl"
+import (
+	"time"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/nl"
+)
 
 // PolicyRoute defines a source-based routing rule to be installed via netlink.
 type PolicyRoute struct {
@@ -16,4 +21,55 @@ type Configuration struct {
 	NetlinkConfiguration nl.NetlinkConfiguration `json:"netlink"`
 	FRRConfiguration     string                  `json:"frr"`
 	PolicyRoutes         []PolicyRoute           `json:"policyRoutes,omitempty"`
+	// IsolateFailures applies the Layer2s and VRFs independently: the ones
+	// that fail are skipped and reported in the ApplyResult, the others are
+	// still applied.
+	IsolateFailures bool `json:"isolateFailures,omitempty"`
+}
+
+// FailedItem is a Layer2 (identified by its VLAN ID) or VRF (identified by
+// its name) the CRA failed to apply.
+type FailedItem struct {
+	Stage  v1alpha1.ApplyStage `json:"stage"`
+	VlanID int                 `json:"vlanID,omitempty"`
+	VRF    string              `json:"vrf,omitempty"`
+	Error  string              `json:"error"`
+}
+
+// ApplyResult is the response of the CRA to a configuration request: the
+// stages it ran with their duration, the Layer2s and VRFs that failed and the
+// error that failed the request, if any.
+type ApplyResult struct {
+	Stages []v1alpha1.StageResult `json:"stages,omitempty"`
+	Failed []FailedItem           `json:"failed,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+}
+
+// DriftItem is a difference between the configuration last applied by the CRA
+// and the actual netlink or FRR state.
+type DriftItem struct {
+	// Section is "layer2/<vlan>", "vrf/<name>" or "frr".
+	Section string `json:"section"`
+	Reason  string `json:"reason"`
+}
+
+// DriftReport is the result of the CRA's last drift check.
+type DriftReport struct {
+	// Applied is false if the CRA has not applied a configuration since it
+	// started, there is nothing to compare with then.
+	Applied bool        `json:"applied"`
+	Checked time.Time   `json:"checked"`
+	Items   []DriftItem `json:"items,omitempty"`
+	// Error is set if the state could not be compared.
+	Error string `json:"error,omitempty"`
+}
+
+// BootInfo identifies the running CRA instance.
+type BootInfo struct {
+	// BootID changes whenever the CRA restarts.
+	BootID  string    `json:"bootID"`
+	Started time.Time `json:"started"`
+	// Applied is false if the CRA has not applied a configuration since it
+	// started.
+	Applied bool `json:"applied"`
 }
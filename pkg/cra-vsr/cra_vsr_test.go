@@ -360,8 +360,9 @@ var _ = Describe("CRA-VSR", func() {
 			WithScheme(scheme).
 			Build()
 
-		reconciler, err := operator.NewNodeConfigReconciler(
-			client, logr.Logger{}, 3, 3, 3, scheme, 3, operator.ImportModeImport)
+		reconciler, err := operator.NewNodeConfigReconciler(client, logr.Logger{}, scheme, operator.NodeConfigReconcilerOptions{
+			APITimeout: 3, ConfigTimeout: 3, PreconfigTimeout: 3, MaxUpdating: 3, ImportMode: operator.ImportModeImport,
+		})
 		Expect(err).ToNot(HaveOccurred())
 
 		node := &corev1.Node{}
@@ -473,8 +474,9 @@ var _ = Describe("CRA-VSR", func() {
 			WithScheme(scheme).
 			Build()
 
-		reconciler, err := operator.NewNodeConfigReconciler(
-			client, logr.Logger{}, 3, 3, 3, scheme, 3, operator.ImportModeImport)
+		reconciler, err := operator.NewNodeConfigReconciler(client, logr.Logger{}, scheme, operator.NodeConfigReconcilerOptions{
+			APITimeout: 3, ConfigTimeout: 3, PreconfigTimeout: 3, MaxUpdating: 3, ImportMode: operator.ImportModeImport,
+		})
 		Expect(err).ToNot(HaveOccurred())
 
 		node := &corev1.Node{}
@@ -579,6 +581,45 @@ var _ = Describe("CRA-VSR", func() {
 		Expect(bgp.Listen.Ranges).ToNot(BeEmpty(), "management VRF BGP should have a listen-range entry")
 		Expect(bgp.Listen.Ranges[0].Range).To(Equal(listenRange))
 	})
+	It("Renders offline from a startup configuration", func() {
+		startupXML, err := xml.Marshal(VRouterConfig{VRouter: *manager.startup})
+		Expect(err).ToNot(HaveOccurred())
+
+		offline, err := NewOfflineManager(manager.baseConfig, startupXML)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(offline.WorkNSName).To(Equal("hbn"))
+		Expect(offline.KpiNSName).To(Equal("main"))
+
+		nodeSpec := &v1alpha1.NodeNetworkConfigSpec{
+			FabricVRFs: map[string]v1alpha1.FabricVRF{
+				"m2m": {VNI: 2000, EVPNImportRouteTargets: []string{"65000:2000"}, EVPNExportRouteTargets: []string{"65000:2000"}},
+			},
+		}
+		rendered, err := offline.RenderConfig(nodeSpec)
+		Expect(err).ToNot(HaveOccurred())
+
+		expected, err := manager.makeVRouter(nodeSpec)
+		Expect(err).ToNot(HaveOccurred())
+		expected.Sort()
+		expectedXML, err := xml.MarshalIndent(VRouterConfig{VRouter: *expected}, "", "  ")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rendered).To(MatchXML(expectedXML))
+	})
+	It("Rejects an offline base config without IPv4 VTEP loopback", func() {
+		_, err := NewOfflineManager(&config.BaseConfig{VTEPLoopbackIP: "fd00::1"}, nil)
+		Expect(err).To(HaveOccurred())
+	})
+	It("Reads the boot time from the ietf-system state", func() {
+		data := `<system-state xmlns="urn:ietf:params:xml:ns:yang:ietf-system">
+  <clock>
+    <current-datetime>2026-10-17T10:00:00+00:00</current-datetime>
+    <boot-datetime>2026-10-17T08:12:34+00:00</boot-datetime>
+  </clock>
+</system-state>`
+		var state SystemState
+		Expect(xml.Unmarshal([]byte(data), &state)).To(Succeed())
+		Expect(state.Clock.BootDatetime).To(Equal("2026-10-17T08:12:34+00:00"))
+	})
 })
 
 func findNamespace(v *VRouter, name string) *Namespace {
@@ -20,9 +20,11 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"net"
 	"strconv"
 
 	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/bgpcommunity"
 	"github.com/telekom/das-schiff-network-operator/pkg/config"
 	"github.com/telekom/das-schiff-network-operator/pkg/helpers/types"
 )
@@ -77,20 +79,27 @@ func (LayerBGP) convStaticRoute(from v1alpha1.StaticRoute) StaticRoute {
 	to := StaticRoute{
 		Destination: from.Prefix,
 	}
-	nh := NextHop{
-		NextHop: "blackhole",
-	}
 
-	if from.NextHop != nil {
-		if from.NextHop.Address != nil {
-			nh.NextHop = *from.NextHop.Address
+	for _, fromNH := range from.AllNextHops() {
+		nh := NextHop{
+			NextHop: "blackhole",
+			Weight:  fromNH.Weight,
+		}
+		if fromNH.Address != nil {
+			nh.NextHop = *fromNH.Address
+			if fromNH.BFD {
+				nh.BFD = types.ToPtr(true)
+			}
 		}
-		if from.NextHop.Vrf != nil {
-			nh.NextHop = *from.NextHop.Vrf
-			nh.VRF = from.NextHop.Vrf
+		if fromNH.Vrf != nil {
+			nh.NextHop = *fromNH.Vrf
+			nh.VRF = fromNH.Vrf
 		}
+		to.NextHops = append(to.NextHops, nh)
+	}
+	if len(to.NextHops) == 0 {
+		to.NextHops = append(to.NextHops, NextHop{NextHop: "blackhole"})
 	}
-	to.NextHops = append(to.NextHops, nh)
 
 	return to
 }
@@ -190,6 +199,51 @@ func (l *LayerBGP) mkCommunityList(name string, seqs ...BGPCommunityListSeq) {
 	})
 }
 
+func (l *LayerBGP) mkLargeCommunityList(name string, seqs ...BGPLargeCommunityListSeq) {
+	bgp := l.vrouter.Routing.BGP
+	for i := range bgp.LargeCommunityLists {
+		if bgp.LargeCommunityLists[i].Name == name {
+			bgp.LargeCommunityLists[i].Seqs = append(bgp.LargeCommunityLists[i].Seqs, seqs...)
+			return
+		}
+	}
+
+	bgp.LargeCommunityLists = append(bgp.LargeCommunityLists, BGPLargeCommunityList{
+		Name: name,
+		Seqs: seqs,
+	})
+}
+
+func (l *LayerBGP) mkExtCommunityList(name string, seqs ...BGPExtCommunityListSeq) {
+	bgp := l.vrouter.Routing.BGP
+	for i := range bgp.ExtCommunityLists {
+		if bgp.ExtCommunityLists[i].Name == name {
+			bgp.ExtCommunityLists[i].Seqs = append(bgp.ExtCommunityLists[i].Seqs, seqs...)
+			return
+		}
+	}
+
+	bgp.ExtCommunityLists = append(bgp.ExtCommunityLists, BGPExtCommunityList{
+		Name: name,
+		Seqs: seqs,
+	})
+}
+
+func (l *LayerBGP) mkASPathList(name string, seqs ...BGPASPathListSeq) {
+	bgp := l.vrouter.Routing.BGP
+	for i := range bgp.ASPathLists {
+		if bgp.ASPathLists[i].Name == name {
+			bgp.ASPathLists[i].Seqs = append(bgp.ASPathLists[i].Seqs, seqs...)
+			return
+		}
+	}
+
+	bgp.ASPathLists = append(bgp.ASPathLists, BGPASPathList{
+		Name: name,
+		Seqs: seqs,
+	})
+}
+
 func (l *LayerBGP) setupRouteMap(name string, i int, conf v1alpha1.FilterItem) {
 	rtmap := RtMapSeq{
 		Num:    i + 10, //nolint:mnd
@@ -226,16 +280,62 @@ func (l *LayerBGP) setupRouteMap(name string, i int, conf v1alpha1.FilterItem) {
 		name := "cm_" + name + "_" + strconv.Itoa(i)
 		comm := matcher.BGPCommunity
 
-		l.mkCommunityList(name, BGPCommunityListSeq{
-			Num:    DefaultCommunityListSeqNum,
+		if rtmap.Match == nil {
+			rtmap.Match = &RtMapMatch{}
+		}
+		var exactMatch *bool
+		if comm.ExactMatch {
+			exactMatch = &comm.ExactMatch
+		}
+
+		switch comm.Type {
+		case v1alpha1.CommunityLarge:
+			l.mkLargeCommunityList(name, BGPLargeCommunityListSeq{
+				Num:    DefaultCommunityListSeqNum,
+				Policy: Permit,
+				Attrs:  []string{comm.Community},
+			})
+			rtmap.Match.LargeCommunity = &RtMapMatchLargeCommunity{
+				ID:         name,
+				ExactMatch: exactMatch,
+			}
+		case v1alpha1.CommunityExtended:
+			groups := bgpcommunity.GroupExtended([]string{comm.Community})
+			l.mkExtCommunityList(name, BGPExtCommunityListSeq{
+				Num:    DefaultCommunityListSeqNum,
+				Policy: Permit,
+				RT:     groups[bgpcommunity.RouteTarget],
+				SOO:    groups[bgpcommunity.SiteOfOrigin],
+			})
+			rtmap.Match.ExtCommunity = &RtMapMatchExtCommunity{
+				ID: name,
+			}
+		default:
+			l.mkCommunityList(name, BGPCommunityListSeq{
+				Num:    DefaultCommunityListSeqNum,
+				Policy: Permit,
+				Attrs:  []string{comm.Community},
+			})
+			rtmap.Match.Community = &RtMapMatchCommunity{
+				ID:         name,
+				ExactMatch: exactMatch,
+			}
+		}
+	}
+
+	if matcher.ASPath != nil {
+		name := "asp_" + name + "_" + strconv.Itoa(i)
+
+		l.mkASPathList(name, BGPASPathListSeq{
+			Num:    DefaultASPathListSeqNum,
 			Policy: Permit,
-			Attrs:  []string{comm.Community},
+			Regex:  matcher.ASPath.Regex,
 		})
 
 		if rtmap.Match == nil {
 			rtmap.Match = &RtMapMatch{}
 		}
-		rtmap.Match.Community = &RtMapMatchCommunity{
+		rtmap.Match.ASPath = &RtMapMatchASPath{
 			ID: name,
 		}
 	}
@@ -278,6 +378,45 @@ func (l *LayerBGP) setupRouteMap(name string, i int, conf v1alpha1.FilterItem) {
 			}
 		}
 
+		set := rtmap.Set
+		if set == nil {
+			set = &RtMapSet{}
+		}
+		if len(modify.AddLargeCommunities) > 0 {
+			set.LargeCommunity = &RtMapSetLargeCommunity{}
+			if modify.AdditiveCommunities != nil {
+				set.LargeCommunity.Add = &RtMapSetCommAdd{
+					Attrs: modify.AddLargeCommunities,
+				}
+			} else {
+				set.LargeCommunity.Replace = &RtMapSetCommReplace{
+					Attrs: modify.AddLargeCommunities,
+				}
+			}
+		}
+		if groups := bgpcommunity.GroupExtended(modify.AddExtCommunities); groups != nil {
+			set.ExtCommunity = &RtMapSetExtCommunity{
+				RT:  groups[bgpcommunity.RouteTarget],
+				SOO: groups[bgpcommunity.SiteOfOrigin],
+			}
+		}
+		if modify.ASPathPrepend != nil {
+			set.ASPath = &RtMapSetASPath{
+				Prepend: modify.ASPathPrepend.Path(l.mgr.baseConfig.LocalASN),
+			}
+		}
+		if modify.SetLocalPreference != nil {
+			set.LocalPreference = types.ToPtr(int(*modify.SetLocalPreference))
+		}
+		set.Metric = modify.SetMED
+		set.Weight = modify.SetWeight
+		if modify.SetNextHop != nil {
+			setNextHop(set, *modify.SetNextHop)
+		}
+		if *set != (RtMapSet{}) {
+			rtmap.Set = set
+		}
+
 		if conf.Action.Type == v1alpha1.Next {
 			rtmap.OnMatch = types.ToPtr("next")
 		}
@@ -286,6 +425,22 @@ func (l *LayerBGP) setupRouteMap(name string, i int, conf v1alpha1.FilterItem) {
 	l.mkRouteMap("rm_"+name, rtmap)
 }
 
+// setNextHop sets the next hop of set to nextHop, an IP address or one of
+// v1alpha1.NextHopPeerAddress and v1alpha1.NextHopUnchanged.
+func setNextHop(set *RtMapSet, nextHop string) {
+	switch {
+	case nextHop == v1alpha1.NextHopPeerAddress:
+		set.IPv4 = &RtMapSetIP{NextHop: &nextHop}
+		set.IPv6 = &RtMapSetIPv6{NextHop: &RtMapSetIPv6NextHop{PeerAddress: types.ToPtr(true)}}
+	case nextHop == v1alpha1.NextHopUnchanged:
+		set.IPv4 = &RtMapSetIP{NextHop: &nextHop}
+	case net.ParseIP(nextHop).To4() != nil:
+		set.IPv4 = &RtMapSetIP{NextHop: &nextHop}
+	default:
+		set.IPv6 = &RtMapSetIPv6{NextHop: &RtMapSetIPv6NextHop{Global: &nextHop}}
+	}
+}
+
 func (l *LayerBGP) setupRouteMaps(name string, conf v1alpha1.Filter) {
 	for i, item := range conf.Items {
 		l.setupRouteMap(name, i, item)
@@ -723,6 +878,12 @@ func (l *LayerBGP) setupFabricVRF(name string, conf *v1alpha1.FabricVRF) error {
 		bgp.AF.EVPN.Advertise.UcastV6.RouteMap = types.ToPtr("rm_" + rtmap)
 	}
 
+	if conf.EVPNImportFilter != nil {
+		rtmap := vrf.Name + "_evpn_import"
+		l.setupRouteMaps(rtmap, *conf.EVPNImportFilter)
+		bgp.AF.EVPN.Imports.RouteMap = types.ToPtr("rm_" + rtmap)
+	}
+
 	if conf.Redistribute != nil {
 		l.setupRedistributeRtMap(name, bgp, *conf.Redistribute)
 	}
@@ -1130,7 +1291,7 @@ func (l *LayerBGP) setupGlobalConfiguration() {
 			},
 			Set: &RtMapSet{
 				IPv4: &RtMapSetIP{
-					NextHop: types.ToPtr("0.0.0.0"),
+					VPNNextHop: types.ToPtr("0.0.0.0"),
 				},
 			},
 			OnMatch: types.ToPtr("next"),
@@ -0,0 +1,249 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cra
+
+import (
+	"testing"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/config"
+)
+
+func newTestLayerBGP() *LayerBGP {
+	return NewLayerBGP(
+		&v1alpha1.NodeNetworkConfigSpec{},
+		&VRouter{Routing: &GlobalRouting{BGP: &GlobalBGP{}}},
+		&Namespace{},
+		&Manager{baseConfig: &config.BaseConfig{LocalASN: 64497}},
+	)
+}
+
+func TestSetupRouteMapASPath(t *testing.T) {
+	ownASCount := 2
+	l := newTestLayerBGP()
+	l.setupRouteMaps("peer-out", v1alpha1.Filter{
+		Items: []v1alpha1.FilterItem{
+			{
+				Matcher: v1alpha1.Matcher{ASPath: &v1alpha1.ASPathMatcher{Regex: "^65010_"}},
+				Action: v1alpha1.Action{
+					Type:        v1alpha1.Accept,
+					ModifyRoute: &v1alpha1.ModifyRouteAction{ASPathPrepend: &v1alpha1.ASPathPrepend{OwnASCount: &ownASCount}},
+				},
+			},
+			{
+				Matcher: v1alpha1.Matcher{Prefix: &v1alpha1.PrefixMatcher{Prefix: "10.0.0.0/24"}},
+				Action: v1alpha1.Action{
+					Type: v1alpha1.Accept,
+					ModifyRoute: &v1alpha1.ModifyRouteAction{
+						AddCommunities: []string{"65000:100"},
+						ASPathPrepend:  &v1alpha1.ASPathPrepend{ASNs: []uint32{65001, 65002}},
+					},
+				},
+			},
+		},
+		DefaultAction: v1alpha1.Action{Type: v1alpha1.Reject},
+	})
+
+	aspls := l.vrouter.Routing.BGP.ASPathLists
+	if len(aspls) != 1 || aspls[0].Name != "asp_peer-out_0" {
+		t.Fatalf("expected as-path list asp_peer-out_0, got %+v", aspls)
+	}
+	if seqs := aspls[0].Seqs; len(seqs) != 1 || seqs[0].Regex != "^65010_" || seqs[0].Policy != Permit {
+		t.Errorf("unexpected as-path list entries %+v", seqs)
+	}
+
+	rtmaps := l.vrouter.Routing.RouteMaps
+	if len(rtmaps) != 1 || len(rtmaps[0].Seqs) != 3 {
+		t.Fatalf("expected route map rm_peer-out with 3 entries, got %+v", rtmaps)
+	}
+	first, second := rtmaps[0].Seqs[0], rtmaps[0].Seqs[1]
+	if first.Match == nil || first.Match.ASPath == nil || first.Match.ASPath.ID != "asp_peer-out_0" {
+		t.Errorf("expected first entry to match asp_peer-out_0, got %+v", first.Match)
+	}
+	if first.Set == nil || first.Set.ASPath == nil || first.Set.ASPath.Prepend != "64497 64497" {
+		t.Errorf("expected first entry to prepend the own AS twice, got %+v", first.Set)
+	}
+	if second.Set == nil || second.Set.Community == nil || second.Set.ASPath == nil || second.Set.ASPath.Prepend != "65001 65002" {
+		t.Errorf("expected second entry to set communities and prepend 65001 65002, got %+v", second.Set)
+	}
+}
+
+func TestSetupRouteMapPreference(t *testing.T) {
+	localPref, med, weight := uint32(200), uint32(10), uint32(100)
+	nextHops := []string{"192.0.2.1", "2001:db8::1", v1alpha1.NextHopPeerAddress, v1alpha1.NextHopUnchanged}
+	items := []v1alpha1.FilterItem{{
+		Matcher: v1alpha1.Matcher{Prefix: &v1alpha1.PrefixMatcher{Prefix: "10.0.0.0/24"}},
+		Action: v1alpha1.Action{
+			Type: v1alpha1.Accept,
+			ModifyRoute: &v1alpha1.ModifyRouteAction{
+				SetLocalPreference: &localPref,
+				SetMED:             &med,
+				SetWeight:          &weight,
+			},
+		},
+	}}
+	for i := range nextHops {
+		items = append(items, v1alpha1.FilterItem{
+			Matcher: v1alpha1.Matcher{Prefix: &v1alpha1.PrefixMatcher{Prefix: "10.0.0.0/24"}},
+			Action: v1alpha1.Action{
+				Type:        v1alpha1.Accept,
+				ModifyRoute: &v1alpha1.ModifyRouteAction{SetNextHop: &nextHops[i]},
+			},
+		})
+	}
+
+	l := newTestLayerBGP()
+	l.setupRouteMaps("peer-in", v1alpha1.Filter{Items: items, DefaultAction: v1alpha1.Action{Type: v1alpha1.Reject}})
+
+	seqs := l.vrouter.Routing.RouteMaps[0].Seqs
+	if set := seqs[0].Set; set == nil || *set.LocalPreference != 200 || *set.Metric != 10 || *set.Weight != 100 {
+		t.Errorf("expected local preference 200, metric 10 and weight 100, got %+v", set)
+	}
+	if set := seqs[1].Set; set.IPv4 == nil || *set.IPv4.NextHop != "192.0.2.1" || set.IPv6 != nil {
+		t.Errorf("expected IPv4 next hop 192.0.2.1, got %+v", set)
+	}
+	if set := seqs[2].Set; set.IPv6 == nil || *set.IPv6.NextHop.Global != "2001:db8::1" || set.IPv4 != nil {
+		t.Errorf("expected IPv6 global next hop 2001:db8::1, got %+v", set)
+	}
+	if set := seqs[3].Set; set.IPv4 == nil || *set.IPv4.NextHop != "peer-address" ||
+		set.IPv6 == nil || set.IPv6.NextHop.PeerAddress == nil || !*set.IPv6.NextHop.PeerAddress {
+		t.Errorf("expected IPv4 and IPv6 peer-address next hops, got %+v", set)
+	}
+	if set := seqs[4].Set; set.IPv4 == nil || *set.IPv4.NextHop != "unchanged" {
+		t.Errorf("expected unchanged next hop, got %+v", set)
+	}
+}
+
+func TestSetupRouteMapCommunityTypes(t *testing.T) {
+	additive := true
+	l := newTestLayerBGP()
+	l.setupRouteMaps("peer-in", v1alpha1.Filter{
+		Items: []v1alpha1.FilterItem{
+			{
+				Matcher: v1alpha1.Matcher{BGPCommunity: &v1alpha1.BGPCommunityMatcher{
+					Community: "4200000000:1:2", Type: v1alpha1.CommunityLarge, ExactMatch: true,
+				}},
+				Action: v1alpha1.Action{
+					Type: v1alpha1.Accept,
+					ModifyRoute: &v1alpha1.ModifyRouteAction{
+						AddLargeCommunities: []string{"4200000000:0:1"},
+						AdditiveCommunities: &additive,
+						AddExtCommunities:   []string{"rt 65000:1", "soo 65000:2", "rt 65000:3"},
+					},
+				},
+			},
+			{
+				Matcher: v1alpha1.Matcher{BGPCommunity: &v1alpha1.BGPCommunityMatcher{
+					Community: "soo 10.0.0.1:5", Type: v1alpha1.CommunityExtended,
+				}},
+				Action: v1alpha1.Action{Type: v1alpha1.Reject},
+			},
+		},
+		DefaultAction: v1alpha1.Action{Type: v1alpha1.Accept},
+	})
+
+	bgp := l.vrouter.Routing.BGP
+	if len(bgp.CommunityLists) != 0 {
+		t.Errorf("expected no standard community lists, got %+v", bgp.CommunityLists)
+	}
+	if lcls := bgp.LargeCommunityLists; len(lcls) != 1 || lcls[0].Name != "cm_peer-in_0" || lcls[0].Seqs[0].Attrs[0] != "4200000000:1:2" {
+		t.Errorf("expected large community list cm_peer-in_0, got %+v", lcls)
+	}
+	if ecls := bgp.ExtCommunityLists; len(ecls) != 1 || ecls[0].Name != "cm_peer-in_1" || len(ecls[0].Seqs[0].SOO) != 1 {
+		t.Errorf("expected extended community list cm_peer-in_1 with a site of origin, got %+v", ecls)
+	}
+
+	seqs := l.vrouter.Routing.RouteMaps[0].Seqs
+	first := seqs[0]
+	if m := first.Match.LargeCommunity; m == nil || m.ID != "cm_peer-in_0" || m.ExactMatch == nil || !*m.ExactMatch {
+		t.Errorf("expected first entry to exactly match cm_peer-in_0, got %+v", first.Match)
+	}
+	if set := first.Set; set == nil || set.LargeCommunity == nil || set.LargeCommunity.Add == nil || set.Community != nil {
+		t.Errorf("expected first entry to add a large community, got %+v", first.Set)
+	}
+	if ext := first.Set.ExtCommunity; ext == nil || len(ext.RT) != 2 || len(ext.SOO) != 1 {
+		t.Errorf("expected first entry to set 2 route targets and 1 site of origin, got %+v", ext)
+	}
+	if m := seqs[1].Match.ExtCommunity; m == nil || m.ID != "cm_peer-in_1" {
+		t.Errorf("expected second entry to match cm_peer-in_1, got %+v", seqs[1].Match)
+	}
+}
+
+func TestSetupFabricVRFEVPNImportFilter(t *testing.T) {
+	l := newTestLayerBGP()
+	l.ns.VRFs = []VRF{{Name: "m2m", Routing: &Routing{BGP: &BGP{}}}}
+
+	err := l.setupFabricVRF("m2m", &v1alpha1.FabricVRF{
+		VNI: 100,
+		EVPNImportFilter: &v1alpha1.Filter{
+			Items: []v1alpha1.FilterItem{{
+				Matcher: v1alpha1.Matcher{Prefix: &v1alpha1.PrefixMatcher{Prefix: "10.0.0.0/8"}},
+				Action:  v1alpha1.Action{Type: v1alpha1.Accept},
+			}},
+			DefaultAction: v1alpha1.Action{Type: v1alpha1.Reject},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	imports := l.ns.VRFs[0].Routing.BGP.AF.EVPN.Imports
+	if imports.RouteMap == nil || *imports.RouteMap != "rm_m2m_evpn_import" {
+		t.Errorf("expected EVPN import route map rm_m2m_evpn_import, got %v", imports.RouteMap)
+	}
+	rtmaps := l.vrouter.Routing.RouteMaps
+	if len(rtmaps) != 1 || rtmaps[0].Name != "rm_m2m_evpn_import" || len(rtmaps[0].Seqs) != 2 {
+		t.Errorf("expected route map rm_m2m_evpn_import with 2 entries, got %+v", rtmaps)
+	}
+}
+
+func TestConvStaticRouteNextHops(t *testing.T) {
+	gw1, gw2, vrf := "192.0.2.1", "192.0.2.2", "m2m"
+	weight := uint32(3)
+	l := newTestLayerBGP()
+
+	route := l.convStaticRoute(v1alpha1.StaticRoute{
+		Prefix: "10.0.0.0/24",
+		NextHops: []v1alpha1.StaticRouteNextHop{
+			{NextHop: v1alpha1.NextHop{Address: &gw1}, Weight: &weight, BFD: true},
+			{NextHop: v1alpha1.NextHop{Address: &gw2}},
+			{NextHop: v1alpha1.NextHop{Vrf: &vrf}},
+		},
+	})
+	if len(route.NextHops) != 3 {
+		t.Fatalf("expected 3 next hops, got %+v", route.NextHops)
+	}
+	if nh := route.NextHops[0]; nh.NextHop != gw1 || nh.Weight == nil || *nh.Weight != 3 || nh.BFD == nil || !*nh.BFD {
+		t.Errorf("expected next hop %s with weight 3 and BFD, got %+v", gw1, nh)
+	}
+	if nh := route.NextHops[1]; nh.NextHop != gw2 || nh.Weight != nil || nh.BFD != nil {
+		t.Errorf("expected next hop %s without weight and BFD, got %+v", gw2, nh)
+	}
+	if nh := route.NextHops[2]; nh.NextHop != vrf || nh.VRF == nil || *nh.VRF != vrf {
+		t.Errorf("expected next hop VRF %s, got %+v", vrf, nh)
+	}
+
+	route = l.convStaticRoute(v1alpha1.StaticRoute{Prefix: "10.0.1.0/24", NextHop: &v1alpha1.NextHop{Address: &gw1}})
+	if len(route.NextHops) != 1 || route.NextHops[0].NextHop != gw1 {
+		t.Errorf("expected single next hop %s, got %+v", gw1, route.NextHops)
+	}
+
+	route = l.convStaticRoute(v1alpha1.StaticRoute{Prefix: "10.0.2.0/24"})
+	if len(route.NextHops) != 1 || route.NextHops[0].NextHop != "blackhole" {
+		t.Errorf("expected blackhole next hop, got %+v", route.NextHops)
+	}
+}
@@ -89,30 +89,21 @@ func NewManager(
 	if err != nil {
 		return nil, fmt.Errorf("error loading base config: %w", err)
 	}
-
-	if net.ParseIP(baseConfig.VTEPLoopbackIP).To4() == nil {
-		return nil, fmt.Errorf(
-			"VTEPLoopbackIP is not IPv4 in base config: %s",
-			baseConfig.VTEPLoopbackIP,
-		)
+	if err := m.setBaseConfig(baseConfig); err != nil {
+		return nil, err
 	}
-	m.baseConfig = baseConfig
 
 	err = m.nc.Open(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	m.startupXML, err = m.nc.Get(ctx, Startup, "/config")
+	startupXML, err := m.nc.Get(ctx, Startup, "/config")
 	if err != nil {
 		return nil, err
 	}
-
-	m.startup = &VRouter{}
-	if err := xml.Unmarshal(m.startupXML, m.startup); err != nil {
-		return nil, fmt.Errorf(
-			"failed to un-marshal startup config=%s: %w",
-			m.startupXML, err)
+	if err := m.setStartup(startupXML); err != nil {
+		return nil, err
 	}
 
 	m.running = &VRouter{}
@@ -121,14 +112,52 @@ func NewManager(
 		return nil, err
 	}
 
-	m.WorkNSName, err = m.findWorkNSName(m.startup)
-	if err != nil {
+	return m, nil
+}
+
+// NewOfflineManager creates a Manager that only renders configurations, from
+// the given base config and startup configuration XML of the router. It does
+// not connect to the router: ApplyConfiguration and the metrics getters must
+// not be used.
+func NewOfflineManager(baseConfig *config.BaseConfig, startupXML []byte) (*Manager, error) {
+	m := &Manager{}
+	if err := m.setBaseConfig(baseConfig); err != nil {
 		return nil, err
 	}
+	if err := m.setStartup(startupXML); err != nil {
+		return nil, err
+	}
+	m.running = m.startup
+	return m, nil
+}
 
-	m.KpiNSName = m.findKpiNSName(m.startup)
+func (m *Manager) setBaseConfig(baseConfig *config.BaseConfig) error {
+	if net.ParseIP(baseConfig.VTEPLoopbackIP).To4() == nil {
+		return fmt.Errorf(
+			"VTEPLoopbackIP is not IPv4 in base config: %s",
+			baseConfig.VTEPLoopbackIP,
+		)
+	}
+	m.baseConfig = baseConfig
+	return nil
+}
 
-	return m, nil
+func (m *Manager) setStartup(startupXML []byte) error {
+	m.startupXML = startupXML
+	m.startup = &VRouter{}
+	if err := xml.Unmarshal(m.startupXML, m.startup); err != nil {
+		return fmt.Errorf(
+			"failed to un-marshal startup config=%s: %w",
+			m.startupXML, err)
+	}
+
+	workNSName, err := m.findWorkNSName(m.startup)
+	if err != nil {
+		return err
+	}
+	m.WorkNSName = workNSName
+	m.KpiNSName = m.findKpiNSName(m.startup)
+	return nil
 }
 
 func (m *Manager) findWorkNSName(vrouter *VRouter) (string, error) {
@@ -201,6 +230,22 @@ func (m *Manager) ApplyConfiguration(
 	return nil
 }
 
+// RenderConfig returns the NETCONF configuration XML that ApplyConfiguration
+// merges into the startup configuration for the given node config.
+func (m *Manager) RenderConfig(nodeCfg *v1alpha1.NodeNetworkConfigSpec) ([]byte, error) {
+	vrouter, err := m.makeVRouter(nodeCfg)
+	if err != nil {
+		return nil, err
+	}
+	vrouter.Sort()
+
+	out, err := xml.MarshalIndent(&VRouterConfig{VRouter: *vrouter}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal vrouter config: %w", err)
+	}
+	return out, nil
+}
+
 func (m *Manager) makeVRouter(nodeCfg *v1alpha1.NodeNetworkConfigSpec) (*VRouter, error) {
 	vrouter := &VRouter{
 		Routing: &GlobalRouting{
@@ -322,6 +367,20 @@ func (*Manager) xpath(prefix string, paths []string) string {
 	return strings.Join(paths, " | ")
 }
 
+// BootTime returns the time the router last restarted at, as reported by
+// its ietf-system state. It only changes when the router restarted, not when
+// the NETCONF session is re-opened.
+func (m *Manager) BootTime(ctx context.Context) (string, error) {
+	var state SystemState
+	if err := m.nc.GetUnmarshal(ctx, Operational, "/system-state/clock/boot-datetime", &state); err != nil {
+		return "", fmt.Errorf("error getting the system state: %w", err)
+	}
+	if state.Clock.BootDatetime == "" {
+		return "", fmt.Errorf("the router does not report its boot time")
+	}
+	return state.Clock.BootDatetime, nil
+}
+
 func (m *Manager) GetMetrics(ctx context.Context) (*Metrics, error) {
 	metrics := Metrics{
 		State:            VRouter{},
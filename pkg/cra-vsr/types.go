@@ -40,6 +40,7 @@ const (
 	DefaultAllowASIn           int = 3
 	DefaultPrefixListSeqNum    int = 5
 	DefaultCommunityListSeqNum int = 5
+	DefaultASPathListSeqNum    int = 5
 )
 
 type VRouterConfig struct {
@@ -67,8 +68,11 @@ type GlobalRouting struct {
 }
 
 type GlobalBGP struct {
-	XMLName        xml.Name           `xml:"urn:6wind:vrouter/bgp bgp"`
-	CommunityLists []BGPCommunityList `xml:"community-list,omitempty"`
+	XMLName             xml.Name                `xml:"urn:6wind:vrouter/bgp bgp"`
+	CommunityLists      []BGPCommunityList      `xml:"community-list,omitempty"`
+	LargeCommunityLists []BGPLargeCommunityList `xml:"large-community-list,omitempty"`
+	ExtCommunityLists   []BGPExtCommunityList   `xml:"extcommunity-list,omitempty"`
+	ASPathLists         []BGPASPathList         `xml:"as-path-list,omitempty"`
 }
 
 type BGPCommunityList struct {
@@ -82,6 +86,40 @@ type BGPCommunityListSeq struct {
 	Attrs  []string `xml:"community,omitempty"`
 }
 
+type BGPLargeCommunityList struct {
+	Name string                     `xml:"name"`
+	Seqs []BGPLargeCommunityListSeq `xml:"policy,omitempty"`
+}
+
+type BGPLargeCommunityListSeq struct {
+	Num    int      `xml:"priority"`
+	Policy Policy   `xml:"policy"`
+	Attrs  []string `xml:"large-community,omitempty"`
+}
+
+type BGPExtCommunityList struct {
+	Name string                   `xml:"name"`
+	Seqs []BGPExtCommunityListSeq `xml:"policy,omitempty"`
+}
+
+type BGPExtCommunityListSeq struct {
+	Num    int      `xml:"priority"`
+	Policy Policy   `xml:"policy"`
+	RT     []string `xml:"rt,omitempty"`
+	SOO    []string `xml:"soo,omitempty"`
+}
+
+type BGPASPathList struct {
+	Name string             `xml:"name"`
+	Seqs []BGPASPathListSeq `xml:"policy,omitempty"`
+}
+
+type BGPASPathListSeq struct {
+	Num    int    `xml:"priority"`
+	Policy Policy `xml:"policy"`
+	Regex  string `xml:"regex"`
+}
+
 type PrefixList struct {
 	Name string          `xml:"name"`
 	Seqs []PrefixListSeq `xml:"seq,omitempty"`
@@ -110,10 +148,13 @@ type RtMapSeq struct {
 }
 
 type RtMapMatch struct {
-	Community *RtMapMatchCommunity `xml:"community,omitempty"`
-	IPv4      *RtMapMatchIP        `xml:"ip>address,omitempty"`
-	IPv6      *RtMapMatchIP        `xml:"ipv6>address,omitempty"`
-	SourceVRF *string              `xml:"source-l3vrf,omitempty"`
+	Community      *RtMapMatchCommunity      `xml:"community,omitempty"`
+	LargeCommunity *RtMapMatchLargeCommunity `xml:"large-community,omitempty"`
+	ExtCommunity   *RtMapMatchExtCommunity   `xml:"extcommunity,omitempty"`
+	ASPath         *RtMapMatchASPath         `xml:"as-path,omitempty"`
+	IPv4           *RtMapMatchIP             `xml:"ip>address,omitempty"`
+	IPv6           *RtMapMatchIP             `xml:"ipv6>address,omitempty"`
+	SourceVRF      *string                   `xml:"source-l3vrf,omitempty"`
 }
 
 type RtMapMatchCommunity struct {
@@ -122,6 +163,22 @@ type RtMapMatchCommunity struct {
 	ExactMatch *bool    `xml:"exact-match,omitempty"`
 }
 
+type RtMapMatchLargeCommunity struct {
+	XMLName    xml.Name `xml:"urn:6wind:vrouter/bgp large-community"`
+	ID         string   `xml:"id"`
+	ExactMatch *bool    `xml:"exact-match,omitempty"`
+}
+
+type RtMapMatchExtCommunity struct {
+	XMLName xml.Name `xml:"urn:6wind:vrouter/bgp extcommunity"`
+	ID      string   `xml:",chardata"`
+}
+
+type RtMapMatchASPath struct {
+	XMLName xml.Name `xml:"urn:6wind:vrouter/bgp as-path"`
+	ID      string   `xml:",chardata"`
+}
+
 type RtMapMatchIP struct {
 	AccessList *string `xml:"access-list,omitempty"`
 	PrefixList *string `xml:"prefix-list,omitempty"`
@@ -129,14 +186,47 @@ type RtMapMatchIP struct {
 }
 
 type RtMapSet struct {
-	IPv4            *RtMapSetIP        `xml:"ipv4,omitempty"`
-	LocalPreference *int               `xml:"local-preference,omitempty"`
-	Community       *RtMapSetCommunity `xml:"community,omitempty"`
-	CommListDelete  *string            `xml:"comm-list-delete,omitempty"`
+	IPv4            *RtMapSetIP             `xml:"ipv4,omitempty"`
+	IPv6            *RtMapSetIPv6           `xml:"ipv6,omitempty"`
+	LocalPreference *int                    `xml:"local-preference,omitempty"`
+	Metric          *uint32                 `xml:"metric,omitempty"`
+	Weight          *uint32                 `xml:"weight,omitempty"`
+	Community       *RtMapSetCommunity      `xml:"community,omitempty"`
+	LargeCommunity  *RtMapSetLargeCommunity `xml:"large-community,omitempty"`
+	ExtCommunity    *RtMapSetExtCommunity   `xml:"extcommunity,omitempty"`
+	CommListDelete  *string                 `xml:"comm-list-delete,omitempty"`
+	ASPath          *RtMapSetASPath         `xml:"as-path,omitempty"`
+}
+
+type RtMapSetLargeCommunity struct {
+	XMLName xml.Name             `xml:"urn:6wind:vrouter/bgp large-community"`
+	Replace *RtMapSetCommReplace `xml:"replace-by,omitempty"`
+	Add     *RtMapSetCommAdd     `xml:"add,omitempty"`
+}
+
+type RtMapSetExtCommunity struct {
+	XMLName xml.Name `xml:"urn:6wind:vrouter/bgp extcommunity"`
+	RT      []string `xml:"rt,omitempty"`
+	SOO     []string `xml:"soo,omitempty"`
+}
+
+type RtMapSetASPath struct {
+	XMLName xml.Name `xml:"urn:6wind:vrouter/bgp as-path"`
+	Prepend string   `xml:"prepend"`
 }
 
 type RtMapSetIP struct {
-	NextHop *string `xml:"vpn>next-hop,omitempty"`
+	NextHop    *string `xml:"next-hop,omitempty"`
+	VPNNextHop *string `xml:"vpn>next-hop,omitempty"`
+}
+
+type RtMapSetIPv6 struct {
+	NextHop *RtMapSetIPv6NextHop `xml:"next-hop,omitempty"`
+}
+
+type RtMapSetIPv6NextHop struct {
+	Global      *string `xml:"global,omitempty"`
+	PeerAddress *bool   `xml:"peer-address,omitempty"`
 }
 
 type RtMapSetCommunity struct {
@@ -279,6 +369,8 @@ type StaticRoute struct {
 type NextHop struct {
 	NextHop string  `xml:"next-hop"`
 	VRF     *string `xml:"nexthop-l3vrf,omitempty"`
+	Weight  *uint32 `xml:"weight,omitempty"`
+	BFD     *bool   `xml:"bfd,omitempty"`
 }
 
 type PolicyBasedRouting struct {
@@ -537,6 +629,7 @@ type BGPExportEVPN struct {
 
 type BGPImportEVPN struct {
 	RouteTargets []string `xml:"route-target,omitempty"`
+	RouteMap     *string  `xml:"route-map,omitempty"`
 }
 
 type BGPAdvert struct {
@@ -712,6 +805,17 @@ type ShowNeighborEntry struct {
 	Origin           string `xml:"origin"`
 }
 
+// SystemState is the ietf-system state of the router.
+type SystemState struct {
+	XMLName xml.Name         `xml:"urn:ietf:params:xml:ns:yang:ietf-system system-state"`
+	Clock   SystemStateClock `xml:"clock"`
+}
+
+type SystemStateClock struct {
+	// BootDatetime is the time the router last restarted at.
+	BootDatetime string `xml:"boot-datetime"`
+}
+
 type ShowBridgeFDBInput struct {
 	XMLName   xml.Name `xml:"urn:6wind:vrouter/bridge show-bridge-fdb"`
 	Namespace *string  `xml:"vrf,omitempty"`
@@ -1101,14 +1205,50 @@ func (comml *BGPCommunityList) Sort() {
 	}
 }
 
+func (comml *BGPLargeCommunityList) Sort() {
+	sort.Slice(comml.Seqs, func(i, j int) bool {
+		return comml.Seqs[i].Num < comml.Seqs[j].Num
+	})
+}
+
+func (comml *BGPExtCommunityList) Sort() {
+	sort.Slice(comml.Seqs, func(i, j int) bool {
+		return comml.Seqs[i].Num < comml.Seqs[j].Num
+	})
+}
+
+func (aspl *BGPASPathList) Sort() {
+	sort.Slice(aspl.Seqs, func(i, j int) bool {
+		return aspl.Seqs[i].Num < aspl.Seqs[j].Num
+	})
+}
+
 func (bgp *GlobalBGP) Sort() {
 	sort.Slice(bgp.CommunityLists, func(i, j int) bool {
 		return bgp.CommunityLists[i].Name < bgp.CommunityLists[j].Name
 	})
+	sort.Slice(bgp.LargeCommunityLists, func(i, j int) bool {
+		return bgp.LargeCommunityLists[i].Name < bgp.LargeCommunityLists[j].Name
+	})
+	sort.Slice(bgp.ExtCommunityLists, func(i, j int) bool {
+		return bgp.ExtCommunityLists[i].Name < bgp.ExtCommunityLists[j].Name
+	})
+	sort.Slice(bgp.ASPathLists, func(i, j int) bool {
+		return bgp.ASPathLists[i].Name < bgp.ASPathLists[j].Name
+	})
 
 	for _, comml := range bgp.CommunityLists {
 		comml.Sort()
 	}
+	for _, comml := range bgp.LargeCommunityLists {
+		comml.Sort()
+	}
+	for _, comml := range bgp.ExtCommunityLists {
+		comml.Sort()
+	}
+	for _, aspl := range bgp.ASPathLists {
+		aspl.Sort()
+	}
 }
 
 func (rting *GlobalRouting) Sort() {
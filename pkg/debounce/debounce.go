@@ -39,8 +39,13 @@ func NewDebouncer(function func(context.Context) error, debounceTime time.Durati
 
 func (d *Debouncer) debounceRoutine(ctx context.Context) {
 	for {
-		// First sleep for the debounceTime
-		time.Sleep(d.debounceTime)
+		// First sleep for the debounceTime. Stop retrying once the Debouncer
+		// was stopped, a failing function would otherwise be retried forever.
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(d.debounceTime):
+		}
 		d.calledDuringExecution.Store(false)
 		err := d.function(ctx)
 		if err == nil {
@@ -2,7 +2,9 @@ package debounce
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -92,3 +94,26 @@ func TestDebounce_StopCancelsInternalContext(t *testing.T) {
 		t.Fatal("internal context should be canceled after Stop()")
 	}
 }
+
+// TestDebounce_StopEndsRetries verifies that a failing function is no longer
+// retried once the Debouncer was stopped.
+func TestDebounce_StopEndsRetries(t *testing.T) {
+	var calls atomic.Int32
+	d := NewDebouncer(func(_ context.Context) error {
+		calls.Add(1)
+		return errors.New("failed")
+	}, 10*time.Millisecond, logr.Discard())
+
+	d.Debounce(context.Background())
+	time.Sleep(35 * time.Millisecond)
+	d.Stop()
+	stopped := calls.Load()
+	if stopped == 0 {
+		t.Fatal("debounced function was not called")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if got := calls.Load(); got > stopped+1 {
+		t.Errorf("debounced function was retried %d times after Stop()", got-stopped)
+	}
+}
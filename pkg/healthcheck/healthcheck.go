@@ -31,6 +31,10 @@ const (
 	// NetworkOperatorReadyConditionType is the custom Node condition type signalling
 	// that the network operator has successfully initialised networking on the node.
 	NetworkOperatorReadyConditionType corev1.NodeConditionType = "NetworkOperatorReady"
+	// BGPSessionsConditionType is the custom Node condition type signalling that
+	// no BGP session that was established before the last config was applied
+	// went down since.
+	BGPSessionsConditionType corev1.NodeConditionType = "BGPSessionsEstablished"
 
 	// Condition reasons.
 	ReasonHealthChecksPassed   = "HealthChecksPassed"
@@ -43,6 +47,10 @@ const (
 	ReasonVLANReconcileFailed   = "VLANReconcileFailed"
 	ReasonLoopbackReconcileFail = "LoopbackReconcileFailed"
 	ReasonConfigFetchFailed     = "ConfigFetchFailed"
+	// BGP sessions condition reasons.
+	ReasonBGPSessionsEstablished = "SessionsEstablished"
+	ReasonBGPSessionsDown        = "SessionsDown"
+	ReasonBGPCheckFailed         = "BGPCheckFailed"
 
 	configEnv         = "OPERATOR_NETHEALTHCHECK_CONFIG"
 	defaultTCPTimeout = 3
@@ -146,48 +154,53 @@ func (hc *HealthChecker) RemoveTaints(ctx context.Context) error {
 // status: corev1.ConditionTrue or corev1.ConditionFalse
 // reason & message provide contextual information visible to users.
 func (hc *HealthChecker) UpdateReadinessCondition(ctx context.Context, status corev1.ConditionStatus, reason, message string) error {
+	if err := SetNodeCondition(ctx, hc.client, NetworkOperatorReadyConditionType, status, reason, message); err != nil {
+		return fmt.Errorf("error updating node readiness condition: %w", err)
+	}
+	// Record the node readiness condition metric
+	RecordNodeReadinessCondition(status == corev1.ConditionTrue, reason)
+	return nil
+}
+
+// SetNodeCondition sets or updates a Node condition of the node the agent runs on.
+// The transition time only changes if the status changed.
+func SetNodeCondition(ctx context.Context, c client.Client, conditionType corev1.NodeConditionType,
+	status corev1.ConditionStatus, reason, message string) error {
 	node := &corev1.Node{}
-	if err := hc.client.Get(ctx, types.NamespacedName{Name: os.Getenv(NodenameEnv)}, node); err != nil {
-		return fmt.Errorf("error retrieving node to update readiness condition: %w", err)
+	if err := c.Get(ctx, types.NamespacedName{Name: os.Getenv(NodenameEnv)}, node); err != nil {
+		return fmt.Errorf("error retrieving node to update %s condition: %w", conditionType, err)
 	}
 
 	now := metav1.Now()
-	updated := false
 	found := false
 	for i := range node.Status.Conditions {
-		c := &node.Status.Conditions[i]
-		if c.Type != NetworkOperatorReadyConditionType { // reduce nesting per gocritic suggestion
+		cond := &node.Status.Conditions[i]
+		if cond.Type != conditionType { // reduce nesting per gocritic suggestion
 			continue
 		}
 		found = true
 		// Transition time only changes if status changed
-		if c.Status != status {
-			c.LastTransitionTime = now
+		if cond.Status != status {
+			cond.LastTransitionTime = now
 		}
-		c.LastHeartbeatTime = now
-		c.Status = status
-		c.Reason = reason
-		c.Message = message
-		updated = true
+		cond.LastHeartbeatTime = now
+		cond.Status = status
+		cond.Reason = reason
+		cond.Message = message
 		break
 	}
 	if !found { // append new condition
 		node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{
-			Type:               NetworkOperatorReadyConditionType,
+			Type:               conditionType,
 			Status:             status,
 			Reason:             reason,
 			Message:            message,
 			LastHeartbeatTime:  now,
 			LastTransitionTime: now,
 		})
-		updated = true
 	}
-	if updated {
-		if err := hc.client.Status().Update(ctx, node); err != nil {
-			return fmt.Errorf("error updating node readiness condition: %w", err)
-		}
-		// Record the node readiness condition metric
-		RecordNodeReadinessCondition(status == corev1.ConditionTrue, reason)
+	if err := c.Status().Update(ctx, node); err != nil {
+		return fmt.Errorf("error updating node %s condition: %w", conditionType, err)
 	}
 	return nil
 }
@@ -2,6 +2,8 @@ package monitoring
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,12 +14,16 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	"github.com/telekom/das-schiff-network-operator/pkg/healthcheck"
 )
@@ -31,8 +37,15 @@ const (
 
 	StatusSvcNameEnv      = "STATUS_SVC_NAME"
 	StatusSvcNamespaceEnv = "STATUS_SVC_NAMESPACE"
+	// ApplyAPICAFileEnv names the CA file to verify the certificates of the
+	// agents' apply API with. They are not verified if it is not set.
+	ApplyAPICAFileEnv = "APPLY_API_CA_FILE"
+	// ApplyPortName is the name of the status service port of the apply API.
+	ApplyPortName = "apply"
 
 	vniBitLength = 24
+
+	applyTimeout = 30 * time.Second
 )
 
 var (
@@ -51,6 +64,8 @@ type Endpoint struct {
 
 	statusSvcName      string
 	statusSvcNamespace string
+	applyClient        *http.Client
+	applyHandler       http.Handler
 	logr.Logger
 }
 
@@ -65,6 +80,52 @@ func NewEndpoint(k8sClient client.Client, frrcli FRRClient, svcName, svcNamespac
 	}
 }
 
+// SetApplyClient sets the client to query the agents' apply API with and the
+// filter that authenticates and authorizes the callers of /all/apply, e.g.
+// filters.WithAuthenticationAndAuthorization. The agents are queried with the
+// endpoint's own token, so callers must not get past the filter without the
+// permission to read the apply API themselves.
+func (e *Endpoint) SetApplyClient(c *http.Client, auth metricsserver.Filter) error {
+	handler, err := auth(e.Logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e.queryAll(w, r, true)
+	}))
+	if err != nil {
+		return fmt.Errorf("error creating the apply API auth filter: %w", err)
+	}
+	e.applyClient = c
+	e.applyHandler = handler
+	return nil
+}
+
+// NewApplyClient returns a client for the agents' apply API that
+// authenticates with the bearer token of config, i.e. the service account
+// token of the endpoint, which is re-read when it is rotated. The agents'
+// certificates are verified with the CA in caFile, or not at all if caFile is
+// empty, as the agents use self-signed certificates by default.
+func NewApplyClient(config *rest.Config, caFile string) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec // the agents use self-signed certificates by default
+	} else {
+		ca, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA file %s: %w", caFile, err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", caFile)
+		}
+	}
+
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.TLSClientConfig = tlsConfig
+	rt, err := transport.NewBearerAuthWithRefreshRoundTripper(config.BearerToken, config.BearerTokenFile, base)
+	if err != nil {
+		return nil, fmt.Errorf("error creating bearer token round tripper: %w", err)
+	}
+	return &http.Client{Transport: rt, Timeout: applyTimeout}, nil
+}
+
 // CreateMux configures HTTP handlers.
 func (e *Endpoint) CreateMux() *http.ServeMux {
 	sm := http.NewServeMux()
@@ -76,6 +137,7 @@ func (e *Endpoint) CreateMux() *http.ServeMux {
 	sm.HandleFunc("/all/show/bgp", e.QueryAll)
 	sm.HandleFunc("/all/show/bgp/summary", e.QueryAll)
 	sm.HandleFunc("/all/show/evpn", e.QueryAll)
+	sm.HandleFunc("/all/apply", e.QueryApply)
 	e.Logger.Info("created ServeMux")
 	return sm
 }
@@ -331,10 +393,30 @@ func validateVNI(vni string) error {
 }
 
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 
 // QueryAll - when called, will pass the request to all nodes and return their responses.
 func (e *Endpoint) QueryAll(w http.ResponseWriter, r *http.Request) {
 	e.Logger.Info("got QueryAll request")
+	e.queryAll(w, r, false)
+}
+
+// QueryApply checks that the caller may read the apply API, then passes the
+// request to the apply API of the agents on all nodes and returns their apply
+// reports. The agents serve the apply API over https on the apply port of the
+// status service. The request is authenticated with the endpoint's own
+// service account token, not the caller's.
+func (e *Endpoint) QueryApply(w http.ResponseWriter, r *http.Request) {
+	e.Logger.Info("got QueryApply request")
+	if e.applyHandler == nil {
+		http.Error(w, "the apply API client is not configured", http.StatusNotImplemented)
+		return
+	}
+	e.applyHandler.ServeHTTP(w, r)
+}
+
+func (e *Endpoint) queryAll(w http.ResponseWriter, r *http.Request, apply bool) {
 	service := &corev1.Service{}
 	err := e.c.Get(r.Context(), client.ObjectKey{Name: e.statusSvcName, Namespace: e.statusSvcNamespace}, service)
 	if err != nil {
@@ -356,8 +438,22 @@ func (e *Endpoint) QueryAll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	target := upstream{client: http.DefaultClient, protocol: "http"}
+	if r.TLS != nil {
+		target.protocol = "https"
+	}
+	if apply {
+		port, err := applyPort(service)
+		if err != nil {
+			e.Logger.Error(err, "error getting apply port")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		target = upstream{client: e.applyClient, protocol: "https", port: port}
+	}
+
 	e.Logger.Info("will querry endpoints", "endpoints", addr)
-	response, errs := queryEndpoints(r, addr)
+	response, errs := queryEndpoints(r, addr, target)
 	if len(errs) > 0 {
 		for _, err := range errs {
 			e.Logger.Error(err, "error querying endpoint")
@@ -373,6 +469,21 @@ func (e *Endpoint) QueryAll(w http.ResponseWriter, r *http.Request) {
 	e.writeResponse(&response, w, "QueryAll")
 }
 
+// applyPort returns the target port of the apply port of the status service.
+func applyPort(service *corev1.Service) (string, error) {
+	for i := range service.Spec.Ports {
+		port := &service.Spec.Ports[i]
+		if port.Name != ApplyPortName {
+			continue
+		}
+		if target := port.TargetPort.IntValue(); target > 0 {
+			return strconv.Itoa(target), nil
+		}
+		return strconv.Itoa(int(port.Port)), nil
+	}
+	return "", fmt.Errorf("service %s/%s has no port named %s", service.Namespace, service.Name, ApplyPortName)
+}
+
 func (e *Endpoint) writeResponse(data *[]byte, w http.ResponseWriter, requestType string) {
 	_, err := w.Write(*data)
 	if err != nil {
@@ -426,25 +537,31 @@ func withNodename(data *[]byte) (*[]byte, error) {
 	return result, nil
 }
 
-func passRequest(r *http.Request, addr, query string, results chan []byte, errors chan error) {
-	_, port, _ := net.SplitHostPort(r.Host)
+// upstream describes how the nodes are queried.
+type upstream struct {
+	client   *http.Client
+	protocol string
+	// port is the port to query, the port of the request if empty.
+	port string
+}
 
-	protocol := "http"
-	if r.TLS != nil {
-		protocol = "https"
+func passRequest(r *http.Request, target upstream, addr, query string, results chan []byte, errors chan error) {
+	port := target.port
+	if port == "" {
+		_, port, _ = net.SplitHostPort(r.Host)
 	}
 
 	host := addr
 	if port != "" {
 		host = net.JoinHostPort(addr, port)
 	}
-	url := fmt.Sprintf("%s://%s%s", protocol, host, query)
+	url := fmt.Sprintf("%s://%s%s", target.protocol, host, query)
 	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, url, http.NoBody) //nolint:gosec // URL is built from trusted monitoring endpoint addresses
 	if err != nil {
 		errors <- fmt.Errorf("error creating request for %s: %w", addr, err)
 		return
 	}
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := target.client.Do(req)
 	if err != nil {
 		errors <- fmt.Errorf("error getting data from %s: %w", addr, err)
 		return
@@ -480,7 +597,7 @@ func (e *Endpoint) getAddresses(ctx context.Context, svc *corev1.Service) ([]str
 	return addresses, nil
 }
 
-func queryEndpoints(r *http.Request, addr []string) ([]byte, []error) {
+func queryEndpoints(r *http.Request, addr []string, target upstream) ([]byte, []error) {
 	query := strings.ReplaceAll(r.URL.RequestURI(), "all/", "")
 	responses := []json.RawMessage{}
 
@@ -492,7 +609,7 @@ func queryEndpoints(r *http.Request, addr []string) ([]byte, []error) {
 		wg.Add(1)
 		go func(i int) {
 			defer wg.Done()
-			passRequest(r, addr[i], query, results, requestErrors)
+			passRequest(r, target, addr[i], query, results, requestErrors)
 		}(i)
 	}
 
@@ -4,15 +4,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"testing"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/telekom/das-schiff-network-operator/pkg/healthcheck"
@@ -340,6 +346,99 @@ var _ = Describe("Endpoint", func() {
 			Expect(res.Code).To(Equal(http.StatusOK))
 		})
 	})
+	Context("QueryApply()", func() {
+		allowAll := func(_ logr.Logger, handler http.Handler) (http.Handler, error) {
+			return handler, nil
+		}
+		It("returns error if no apply client is set", func() {
+			c := fake.NewClientBuilder().WithRuntimeObjects(fakePods, fakeServices).Build()
+			e := NewEndpoint(c, fcm, "test-service", "test-namespace")
+			req := httptest.NewRequest(http.MethodGet, "/all/apply", http.NoBody)
+			res := httptest.NewRecorder()
+			e.QueryApply(res, req)
+			Expect(res.Code).To(Equal(http.StatusNotImplemented))
+		})
+		It("rejects callers the auth filter denies before querying the agents", func() {
+			queried := false
+			svr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				queried = true
+				fmt.Fprintf(w, "{}")
+			}))
+			defer svr.Close()
+
+			c := fake.NewClientBuilder().WithRuntimeObjects(fakePods, fakeServices).Build()
+			e := NewEndpoint(c, fcm, "test-service", "test-namespace")
+			denyAll := func(_ logr.Logger, _ http.Handler) (http.Handler, error) {
+				return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				}), nil
+			}
+			Expect(e.SetApplyClient(svr.Client(), denyAll)).To(Succeed())
+			req := httptest.NewRequest(http.MethodGet, "/all/apply", http.NoBody)
+			res := httptest.NewRecorder()
+			e.QueryApply(res, req)
+			Expect(res.Code).To(Equal(http.StatusUnauthorized))
+			Expect(queried).To(BeFalse())
+		})
+		It("returns error if the auth filter cannot be created", func() {
+			c := fake.NewClientBuilder().WithRuntimeObjects(fakePods, fakeServices).Build()
+			e := NewEndpoint(c, fcm, "test-service", "test-namespace")
+			failing := func(_ logr.Logger, _ http.Handler) (http.Handler, error) {
+				return nil, fmt.Errorf("no authenticator")
+			}
+			Expect(e.SetApplyClient(http.DefaultClient, failing)).ToNot(Succeed())
+			req := httptest.NewRequest(http.MethodGet, "/all/apply", http.NoBody)
+			res := httptest.NewRecorder()
+			e.QueryApply(res, req)
+			Expect(res.Code).To(Equal(http.StatusNotImplemented))
+		})
+		It("returns error if the status service has no apply port", func() {
+			c := fake.NewClientBuilder().WithRuntimeObjects(fakePods, fakeServices).Build()
+			e := NewEndpoint(c, fcm, "test-service", "test-namespace")
+			Expect(e.SetApplyClient(http.DefaultClient, allowAll)).To(Succeed())
+			req := httptest.NewRequest(http.MethodGet, "/all/apply", http.NoBody)
+			res := httptest.NewRecorder()
+			e.QueryApply(res, req)
+			Expect(res.Code).To(Equal(http.StatusInternalServerError))
+		})
+		It("queries the apply port over https with the endpoint's own token", func() {
+			var gotPath, gotAuth string
+			svr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+				fmt.Fprintf(w, "{}")
+			}))
+			defer svr.Close()
+
+			_, port, err := net.SplitHostPort(svr.Listener.Addr().String())
+			Expect(err).ToNot(HaveOccurred())
+			targetPort, err := strconv.Atoi(port)
+			Expect(err).ToNot(HaveOccurred())
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "status", Namespace: "test-namespace"},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{Name: "metrics", Port: 7080, TargetPort: intstr.FromInt(7080)},
+						{Name: ApplyPortName, Port: 7086, TargetPort: intstr.FromInt(targetPort)},
+					},
+				},
+			}
+
+			applyClient, err := NewApplyClient(&rest.Config{BearerToken: "own-token"}, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			c := fake.NewClientBuilder().WithRuntimeObjects(fakePods, service).Build()
+			e := NewEndpoint(c, fcm, "status", "test-namespace")
+			Expect(e.SetApplyClient(applyClient, allowAll)).To(Succeed())
+			req := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:7082/all/apply", http.NoBody)
+			req.Header.Set("Authorization", "Bearer user-token")
+			res := httptest.NewRecorder()
+
+			e.QueryApply(res, req)
+			Expect(res.Code).To(Equal(http.StatusOK))
+			Expect(gotPath).To(Equal("/apply"))
+			Expect(gotAuth).To(Equal("Bearer own-token"))
+		})
+	})
 	Context("GetStatusServiceConfig()", func() {
 		It("returns no error if envs are set", func() {
 			name, namespace, err := GetStatusServiceConfig()
@@ -0,0 +1,202 @@
+package nl
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// linkTypeBond is the netlink link type string for a bond interface.
+const linkTypeBond = "bond"
+
+// InterfaceInformation describes a single link of the network namespace as
+// observed via netlink. It is a flattened, serialisable view used to report
+// the node's interface inventory.
+type InterfaceInformation struct {
+	Name        string
+	Index       int
+	Type        string
+	MAC         string
+	MTU         int
+	OperState   string
+	AdminUp     bool
+	Loopback    bool
+	MasterIndex int
+	Master      string
+	Parent      string
+	VlanID      int
+	Members     []string
+	Addresses   []string
+}
+
+// RouteEntry describes a single (next hop of a) route as observed via netlink.
+// Table is the name of the VRF owning the table, the well-known table name
+// (main, default, ...) or the numeric table ID if neither applies.
+type RouteEntry struct {
+	Destination string
+	Gateway     string
+	Interface   string
+	Table       string
+}
+
+// ListInterfaces returns all links of the network namespace including their
+// addresses, bond members and VLAN parents, sorted by name.
+func (n *Manager) ListInterfaces() ([]InterfaceInformation, error) {
+	links, err := n.toolkit.LinkList()
+	if err != nil {
+		return nil, fmt.Errorf("error listing links: %w", err)
+	}
+
+	names := make(map[int]string, len(links))
+	for _, link := range links {
+		names[link.Attrs().Index] = link.Attrs().Name
+	}
+
+	infos := make([]InterfaceInformation, 0, len(links))
+	byIndex := make(map[int]int, len(links))
+	for _, link := range links {
+		attrs := link.Attrs()
+		info := InterfaceInformation{
+			Name:        attrs.Name,
+			Index:       attrs.Index,
+			Type:        link.Type(),
+			MTU:         attrs.MTU,
+			OperState:   attrs.OperState.String(),
+			AdminUp:     attrs.Flags&net.FlagUp != 0,
+			Loopback:    attrs.Flags&net.FlagLoopback != 0,
+			MasterIndex: attrs.MasterIndex,
+			Master:      names[attrs.MasterIndex],
+		}
+		if len(attrs.HardwareAddr) > 0 {
+			info.MAC = attrs.HardwareAddr.String()
+		}
+		if vlan, ok := link.(*netlink.Vlan); ok {
+			info.VlanID = vlan.VlanId
+			info.Parent = names[attrs.ParentIndex]
+		} else if attrs.ParentIndex > 0 && attrs.ParentIndex != attrs.Index {
+			info.Parent = names[attrs.ParentIndex]
+		}
+
+		addresses, err := n.toolkit.AddrList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return nil, fmt.Errorf("error listing addresses of link %s: %w", attrs.Name, err)
+		}
+		for i := range addresses {
+			if addresses[i].IPNet == nil {
+				continue
+			}
+			info.Addresses = append(info.Addresses, addresses[i].IPNet.String())
+		}
+		sort.Strings(info.Addresses)
+
+		byIndex[attrs.Index] = len(infos)
+		infos = append(infos, info)
+	}
+
+	// Bond members are only known from the member side (their master index).
+	for i := range infos {
+		if infos[i].MasterIndex == 0 {
+			continue
+		}
+		master, ok := byIndex[infos[i].MasterIndex]
+		if !ok || infos[master].Type != linkTypeBond {
+			continue
+		}
+		infos[master].Members = append(infos[master].Members, infos[i].Name)
+	}
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	for i := range infos {
+		sort.Strings(infos[i].Members)
+	}
+
+	return infos, nil
+}
+
+// ListRoutes returns the routes of all tables except the kernel local table.
+// Multipath routes are reported once per next hop.
+func (n *Manager) ListRoutes() ([]RouteEntry, error) {
+	routes, err := n.listRoutes()
+	if err != nil {
+		return nil, err
+	}
+	links, err := n.toolkit.LinkList()
+	if err != nil {
+		return nil, fmt.Errorf("error listing links: %w", err)
+	}
+
+	names := make(map[int]string, len(links))
+	tables := map[int]VRFInformation{}
+	for _, link := range links {
+		names[link.Attrs().Index] = link.Attrs().Name
+		if vrf, ok := link.(*netlink.Vrf); ok {
+			tables[int(vrf.Table)] = VRFInformation{Name: link.Attrs().Name, table: int(vrf.Table)}
+		}
+	}
+
+	entries := make([]RouteEntry, 0, len(routes))
+	for i := range routes {
+		rt := &routes[i]
+		if rt.Table == unix.RT_TABLE_LOCAL {
+			continue
+		}
+
+		table, err := n.getVRFName(rt.Table, tables)
+		if err != nil {
+			return nil, fmt.Errorf("error getting vrfName for table id %d: %w", rt.Table, err)
+		}
+		if table == "" {
+			table = strconv.Itoa(rt.Table)
+		}
+
+		destination := defaultDestination(rt.Family)
+		if rt.Dst != nil {
+			destination = rt.Dst.String()
+		}
+
+		if len(rt.MultiPath) == 0 {
+			entries = append(entries, RouteEntry{
+				Destination: destination,
+				Gateway:     ipString(rt.Gw),
+				Interface:   names[rt.LinkIndex],
+				Table:       table,
+			})
+			continue
+		}
+		for _, nh := range rt.MultiPath {
+			entries = append(entries, RouteEntry{
+				Destination: destination,
+				Gateway:     ipString(nh.Gw),
+				Interface:   names[nh.LinkIndex],
+				Table:       table,
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Table != entries[j].Table {
+			return entries[i].Table < entries[j].Table
+		}
+		return entries[i].Destination < entries[j].Destination
+	})
+
+	return entries, nil
+}
+
+func defaultDestination(family int) string {
+	if family == netlink.FAMILY_V6 {
+		return "::/0"
+	}
+	return "0.0.0.0/0"
+}
+
+func ipString(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
@@ -128,6 +128,82 @@ var _ = Describe("ListL3()", func() {
 	})
 })
 
+var _ = Describe("ListInterfaces()", func() {
+	It("returns error if cannot list links", func() {
+		mockctrl := gomock.NewController(GinkgoT())
+		defer mockctrl.Finish()
+		netlinkMock := mock_nl.NewMockToolkitInterface(mockctrl)
+		nm := NewManager(netlinkMock, &config.BaseConfig{})
+		netlinkMock.EXPECT().LinkList().Return(nil, errors.New("error listing links"))
+		_, err := nm.ListInterfaces()
+		Expect(err).To(HaveOccurred())
+	})
+	It("reports bond members, VLAN parents and addresses", func() {
+		mockctrl := gomock.NewController(GinkgoT())
+		defer mockctrl.Finish()
+		netlinkMock := mock_nl.NewMockToolkitInterface(mockctrl)
+		nm := NewManager(netlinkMock, &config.BaseConfig{})
+		_, addr, err := net.ParseCIDR("10.0.0.1/24")
+		Expect(err).ToNot(HaveOccurred())
+		addr.IP = net.ParseIP("10.0.0.1")
+		netlinkMock.EXPECT().LinkList().Return([]netlink.Link{
+			&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth1", Index: 2, MasterIndex: 4}},
+			&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth0", Index: 3, MasterIndex: 4}},
+			&netlink.Bond{LinkAttrs: netlink.LinkAttrs{Name: "bond0", Index: 4, OperState: netlink.OperUp}},
+			&netlink.Vlan{LinkAttrs: netlink.LinkAttrs{Name: "vlan.100", Index: 5, ParentIndex: 4}, VlanId: 100},
+		}, nil)
+		netlinkMock.EXPECT().AddrList(gomock.Any(), netlink.FAMILY_ALL).DoAndReturn(func(link netlink.Link, _ int) ([]netlink.Addr, error) {
+			if link.Attrs().Name == "vlan.100" {
+				return []netlink.Addr{{IPNet: addr}}, nil
+			}
+			return nil, nil
+		}).Times(4)
+
+		result, err := nm.ListInterfaces()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveLen(4))
+		Expect(result[0].Name).To(Equal("bond0"))
+		Expect(result[0].OperState).To(Equal("up"))
+		Expect(result[0].Members).To(Equal([]string{"eth0", "eth1"}))
+		Expect(result[1].Master).To(Equal("bond0"))
+		Expect(result[3].Parent).To(Equal("bond0"))
+		Expect(result[3].VlanID).To(Equal(100))
+		Expect(result[3].Addresses).To(Equal([]string{"10.0.0.1/24"}))
+	})
+})
+
+var _ = Describe("ListRoutes()", func() {
+	It("resolves VRF tables and skips the local table", func() {
+		mockctrl := gomock.NewController(GinkgoT())
+		defer mockctrl.Finish()
+		netlinkMock := mock_nl.NewMockToolkitInterface(mockctrl)
+		nm := NewManager(netlinkMock, &config.BaseConfig{})
+		_, dst, err := net.ParseCIDR("192.168.0.0/24")
+		Expect(err).ToNot(HaveOccurred())
+		netlinkMock.EXPECT().RouteListFiltered(netlink.FAMILY_ALL, gomock.Any(), gomock.Any()).Return([]netlink.Route{
+			{Table: unix.RT_TABLE_LOCAL, Dst: dst, LinkIndex: 2},
+			{Table: 50, Dst: dst, LinkIndex: 2},
+			{Table: unix.RT_TABLE_MAIN, Family: netlink.FAMILY_V4, Gw: net.ParseIP("10.0.0.254"), LinkIndex: 2},
+			{Table: 99, Dst: dst, MultiPath: []*netlink.NexthopInfo{
+				{LinkIndex: 2, Gw: net.ParseIP("10.0.0.1")},
+				{LinkIndex: 2, Gw: net.ParseIP("10.0.0.2")},
+			}},
+		}, nil)
+		netlinkMock.EXPECT().LinkList().Return([]netlink.Link{
+			&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth0", Index: 2}},
+			&netlink.Vrf{LinkAttrs: netlink.LinkAttrs{Name: "tenant", Index: 3}, Table: 50},
+		}, nil)
+
+		result, err := nm.ListRoutes()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveLen(4))
+		Expect(result[0]).To(Equal(RouteEntry{Destination: dst.String(), Gateway: "10.0.0.1", Interface: "eth0", Table: "99"}))
+		Expect(result[1].Gateway).To(Equal("10.0.0.2"))
+		Expect(result[2]).To(Equal(RouteEntry{Destination: "0.0.0.0/0", Gateway: "10.0.0.254", Interface: "eth0", Table: "main"}))
+		Expect(result[3]).To(Equal(RouteEntry{Destination: dst.String(), Interface: "eth0", Table: "tenant"}))
+	})
+})
+
 var _ = Describe("ListL2()", func() {
 	It("returns error if cannot list links", func() {
 		mockctrl := gomock.NewController(GinkgoT())
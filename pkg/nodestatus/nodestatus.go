@@ -0,0 +1,464 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodestatus publishes the observed interface and route inventory of
+// a node as a NodeNetworkStatus resource. It is shared by all node agents.
+package nodestatus
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/vishvananda/netlink"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	networkconnector "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
+	"github.com/telekom/das-schiff-network-operator/pkg/config"
+	"github.com/telekom/das-schiff-network-operator/pkg/debounce"
+	"github.com/telekom/das-schiff-network-operator/pkg/healthcheck"
+	"github.com/telekom/das-schiff-network-operator/pkg/nl"
+)
+
+const (
+	// DefaultMinInterval is the minimum time between two status updates. Netlink
+	// events arriving faster than this are coalesced into a single update.
+	DefaultMinInterval = 5 * time.Second
+	// DefaultResyncInterval is the interval of unconditional status refreshes.
+	DefaultResyncInterval = 5 * time.Minute
+	// RemoteResyncInterval is the interval of status refreshes for listers of
+	// another network namespace, whose netlink events the agent does not see.
+	RemoteResyncInterval = time.Minute
+	// DefaultMaxRoutes caps the number of reported routes to keep the object
+	// well below the etcd object size limit on nodes carrying full tables.
+	DefaultMaxRoutes = 2000
+
+	// ConditionReady reports whether the inventory could be collected.
+	ConditionReady = "Ready"
+	// ConditionRoutesTruncated reports whether the route list was capped.
+	ConditionRoutesTruncated = "RoutesTruncated"
+
+	ReasonInventoryCollected = "InventoryCollected"
+	ReasonCollectionFailed   = "CollectionFailed"
+	ReasonRouteLimitExceeded = "RouteLimitExceeded"
+	ReasonRoutesComplete     = "RoutesComplete"
+)
+
+// Lister lists the interfaces and routes of the node's network namespace.
+// It is implemented by *nl.Manager.
+type Lister interface {
+	ListInterfaces() ([]nl.InterfaceInformation, error)
+	ListRoutes() ([]nl.RouteEntry, error)
+}
+
+// Options configures a Publisher.
+type Options struct {
+	// MinInterval is the minimum time between two status updates.
+	MinInterval time.Duration
+	// ResyncInterval is the interval of unconditional status refreshes.
+	ResyncInterval time.Duration
+	// MaxRoutes caps the number of reported routes (0 means DefaultMaxRoutes).
+	MaxRoutes int
+	// SkipNetlinkEvents disables the refresh on netlink events of the agent's
+	// network namespace, for listers that read another network namespace.
+	SkipNetlinkEvents bool
+}
+
+// Publisher keeps the NodeNetworkStatus of a single node up to date. It
+// refreshes the status on netlink link, address and route events (rate limited
+// by MinInterval) and periodically every ResyncInterval.
+type Publisher struct {
+	client    client.Client
+	logger    logr.Logger
+	lister    Lister
+	nodeName  string
+	debouncer *debounce.Debouncer
+
+	resyncInterval    time.Duration
+	maxRoutes         int
+	skipNetlinkEvents bool
+
+	linkSubscribeFn  func(ch chan<- netlink.LinkUpdate, done <-chan struct{}, options netlink.LinkSubscribeOptions) error
+	addrSubscribeFn  func(ch chan<- netlink.AddrUpdate, done <-chan struct{}, options netlink.AddrSubscribeOptions) error
+	routeSubscribeFn func(ch chan<- netlink.RouteUpdate, done <-chan struct{}, options netlink.RouteSubscribeOptions) error
+}
+
+// NewPublisher creates a new Publisher for the given node.
+func NewPublisher(c client.Client, logger logr.Logger, lister Lister, nodeName string, opts Options) *Publisher {
+	if opts.MinInterval <= 0 {
+		opts.MinInterval = DefaultMinInterval
+	}
+	if opts.ResyncInterval <= 0 {
+		opts.ResyncInterval = DefaultResyncInterval
+	}
+	if opts.MaxRoutes <= 0 {
+		opts.MaxRoutes = DefaultMaxRoutes
+	}
+
+	p := &Publisher{
+		client:            c,
+		logger:            logger,
+		lister:            lister,
+		nodeName:          nodeName,
+		resyncInterval:    opts.ResyncInterval,
+		maxRoutes:         opts.MaxRoutes,
+		skipNetlinkEvents: opts.SkipNetlinkEvents,
+		linkSubscribeFn:   netlink.LinkSubscribeWithOptions,
+		addrSubscribeFn:   netlink.AddrSubscribeWithOptions,
+		routeSubscribeFn:  netlink.RouteSubscribeWithOptions,
+	}
+	p.debouncer = debounce.NewDebouncer(p.Publish, opts.MinInterval, logger)
+
+	return p
+}
+
+//+kubebuilder:rbac:groups=network-connector.sylvaproject.org,resources=nodenetworkstatuses,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups=network-connector.sylvaproject.org,resources=nodenetworkstatuses/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
+// AddToManager registers a Publisher for the node named by the NODE_NAME
+// environment variable with the manager. The inventory is read with lister,
+// e.g. from the network namespace of the CRA, and refreshed every
+// RemoteResyncInterval. If lister is nil, it is read from the network
+// namespace of the agent (the host namespace) and refreshed on netlink events.
+// Only one agent per node must publish the status, as the agents would
+// overwrite each other's inventory.
+func AddToManager(mgr manager.Manager, lister Lister) error {
+	nodeName := os.Getenv(healthcheck.NodenameEnv)
+	if nodeName == "" {
+		return fmt.Errorf("environment variable %s is not set", healthcheck.NodenameEnv)
+	}
+
+	opts := Options{}
+	if lister == nil {
+		lister = nl.NewManager(&nl.Toolkit{}, &config.BaseConfig{})
+	} else {
+		opts.ResyncInterval = RemoteResyncInterval
+		opts.SkipNetlinkEvents = true
+	}
+	p := NewPublisher(mgr.GetClient(), mgr.GetLogger().WithName("nodestatus"), lister, nodeName, opts)
+	if err := mgr.Add(p); err != nil {
+		return fmt.Errorf("error adding NodeNetworkStatus publisher to manager: %w", err)
+	}
+
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every agent
+// publishes the status of its own node, so no leader election is required.
+func (*Publisher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable. It publishes the initial status and then
+// keeps it up to date until ctx is cancelled.
+func (p *Publisher) Start(ctx context.Context) error {
+	defer p.debouncer.Stop()
+
+	if err := p.Publish(ctx); err != nil {
+		p.logger.Error(err, "error publishing initial NodeNetworkStatus")
+	}
+
+	ticker := time.NewTicker(p.resyncInterval)
+	defer ticker.Stop()
+
+	if p.skipNetlinkEvents {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				p.debouncer.Debounce(ctx)
+			}
+		}
+	}
+
+	links := make(chan netlink.LinkUpdate)
+	addrs := make(chan netlink.AddrUpdate)
+	routes := make(chan netlink.RouteUpdate)
+	done := make(chan struct{})
+	defer close(done)
+
+	errorCallback := func(err error) {
+		p.logger.Error(err, "netlink subscription error")
+	}
+
+	if err := p.linkSubscribeFn(links, done, netlink.LinkSubscribeOptions{ErrorCallback: errorCallback}); err != nil {
+		return fmt.Errorf("error subscribing to link updates: %w", err)
+	}
+	if err := p.addrSubscribeFn(addrs, done, netlink.AddrSubscribeOptions{ErrorCallback: errorCallback}); err != nil {
+		return fmt.Errorf("error subscribing to address updates: %w", err)
+	}
+	if err := p.routeSubscribeFn(routes, done, netlink.RouteSubscribeOptions{ErrorCallback: errorCallback}); err != nil {
+		return fmt.Errorf("error subscribing to route updates: %w", err)
+	}
+
+	// A closed subscription channel is set to nil so it blocks forever; the
+	// periodic resync keeps the status fresh in that case.
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-links:
+			if !ok {
+				p.logger.Info("link subscription closed, falling back to periodic resync")
+				links = nil
+				continue
+			}
+			p.debouncer.Debounce(ctx)
+		case _, ok := <-addrs:
+			if !ok {
+				p.logger.Info("address subscription closed, falling back to periodic resync")
+				addrs = nil
+				continue
+			}
+			p.debouncer.Debounce(ctx)
+		case _, ok := <-routes:
+			if !ok {
+				p.logger.Info("route subscription closed, falling back to periodic resync")
+				routes = nil
+				continue
+			}
+			p.debouncer.Debounce(ctx)
+		case <-ticker.C:
+			p.debouncer.Debounce(ctx)
+		}
+	}
+}
+
+// Publish collects the current inventory and writes it to the node's
+// NodeNetworkStatus, creating the object if required.
+func (p *Publisher) Publish(ctx context.Context) error {
+	nns, err := p.getOrCreate(ctx)
+	if err != nil {
+		return err
+	}
+
+	status, collectErr := p.collect()
+	if collectErr != nil {
+		// Keep the last known inventory and only flag the failure.
+		status = nns.Status.DeepCopy()
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               ConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             ReasonCollectionFailed,
+			Message:            collectErr.Error(),
+			ObservedGeneration: nns.Generation,
+		})
+	} else {
+		status.Conditions = nns.Status.Conditions
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               ConditionReady,
+			Status:             metav1.ConditionTrue,
+			Reason:             ReasonInventoryCollected,
+			Message:            fmt.Sprintf("%d interfaces, %d routes", len(status.Interfaces), len(status.Routes)),
+			ObservedGeneration: nns.Generation,
+		})
+		p.setTruncatedCondition(status, nns.Generation)
+	}
+
+	now := metav1.Now()
+	status.LastUpdated = &now
+	nns.Status = *status
+
+	if err := p.client.Status().Update(ctx, nns); err != nil {
+		return fmt.Errorf("error updating NodeNetworkStatus %s: %w", p.nodeName, err)
+	}
+
+	if collectErr != nil {
+		return collectErr
+	}
+
+	return nil
+}
+
+func (p *Publisher) setTruncatedCondition(status *networkconnector.NodeNetworkStatusStatus, generation int64) {
+	if len(status.Routes) > p.maxRoutes {
+		total := len(status.Routes)
+		status.Routes = status.Routes[:p.maxRoutes]
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               ConditionRoutesTruncated,
+			Status:             metav1.ConditionTrue,
+			Reason:             ReasonRouteLimitExceeded,
+			Message:            fmt.Sprintf("reporting %d of %d routes", p.maxRoutes, total),
+			ObservedGeneration: generation,
+		})
+		return
+	}
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               ConditionRoutesTruncated,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonRoutesComplete,
+		Message:            "all routes are reported",
+		ObservedGeneration: generation,
+	})
+}
+
+func (p *Publisher) collect() (*networkconnector.NodeNetworkStatusStatus, error) {
+	interfaces, err := p.lister.ListInterfaces()
+	if err != nil {
+		return nil, fmt.Errorf("error listing interfaces: %w", err)
+	}
+	routes, err := p.lister.ListRoutes()
+	if err != nil {
+		return nil, fmt.Errorf("error listing routes: %w", err)
+	}
+
+	return BuildStatus(interfaces, routes), nil
+}
+
+func (p *Publisher) getOrCreate(ctx context.Context) (*networkconnector.NodeNetworkStatus, error) {
+	nns := &networkconnector.NodeNetworkStatus{}
+	err := p.client.Get(ctx, types.NamespacedName{Name: p.nodeName}, nns)
+	if err == nil {
+		return nns, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("error getting NodeNetworkStatus %s: %w", p.nodeName, err)
+	}
+
+	nns = &networkconnector.NodeNetworkStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: p.nodeName},
+	}
+
+	// Tie the lifecycle of the status to the node, so it is garbage collected
+	// once the node is removed from the cluster.
+	node := &corev1.Node{}
+	if err := p.client.Get(ctx, types.NamespacedName{Name: p.nodeName}, node); err != nil {
+		return nil, fmt.Errorf("error getting node %s: %w", p.nodeName, err)
+	}
+	if err := controllerutil.SetOwnerReference(node, nns, p.client.Scheme()); err != nil {
+		return nil, fmt.Errorf("error setting owner reference on NodeNetworkStatus %s: %w", p.nodeName, err)
+	}
+
+	if err := p.client.Create(ctx, nns); err != nil {
+		return nil, fmt.Errorf("error creating NodeNetworkStatus %s: %w", p.nodeName, err)
+	}
+
+	return nns, nil
+}
+
+// BuildStatus converts the netlink inventory into a NodeNetworkStatusStatus.
+// Conditions and LastUpdated are left for the caller to fill in.
+func BuildStatus(interfaces []nl.InterfaceInformation, routes []nl.RouteEntry) *networkconnector.NodeNetworkStatusStatus {
+	status := &networkconnector.NodeNetworkStatusStatus{
+		Interfaces: make([]networkconnector.NodeInterface, 0, len(interfaces)),
+		Routes:     make([]networkconnector.NodeRoute, 0, len(routes)),
+	}
+
+	for i := range interfaces {
+		status.Interfaces = append(status.Interfaces, convertInterface(&interfaces[i]))
+	}
+	for i := range routes {
+		status.Routes = append(status.Routes, convertRoute(&routes[i]))
+	}
+
+	return status
+}
+
+func convertInterface(info *nl.InterfaceInformation) networkconnector.NodeInterface {
+	ifType := interfaceType(info)
+	iface := networkconnector.NodeInterface{
+		Name:      info.Name,
+		State:     interfaceState(info),
+		Type:      &ifType,
+		Addresses: info.Addresses,
+	}
+
+	if info.MAC != "" {
+		iface.Mac = &info.MAC
+	}
+	if info.MTU > 0 {
+		mtu := int32(info.MTU) //nolint:gosec // MTU always fits into int32
+		iface.Mtu = &mtu
+	}
+	if ifType == networkconnector.InterfaceTypeBond && len(info.Members) > 0 {
+		iface.Members = info.Members
+	}
+	if ifType == networkconnector.InterfaceTypeVlan {
+		if info.Parent != "" {
+			iface.Parent = &info.Parent
+		}
+		vlanID := int32(info.VlanID) //nolint:gosec // VLAN IDs are 12 bit
+		iface.VlanID = &vlanID
+	}
+
+	return iface
+}
+
+func convertRoute(entry *nl.RouteEntry) networkconnector.NodeRoute {
+	r := networkconnector.NodeRoute{
+		Destination: entry.Destination,
+		Interface:   entry.Interface,
+	}
+	if entry.Gateway != "" {
+		r.Gateway = &entry.Gateway
+	}
+	if entry.Table != "" {
+		r.Table = &entry.Table
+	}
+	return r
+}
+
+func interfaceType(info *nl.InterfaceInformation) networkconnector.NodeInterfaceType {
+	if info.Loopback {
+		return networkconnector.InterfaceTypeLoopback
+	}
+
+	switch info.Type {
+	case "device":
+		return networkconnector.InterfaceTypePhysical
+	case "bond":
+		return networkconnector.InterfaceTypeBond
+	case "vlan":
+		return networkconnector.InterfaceTypeVlan
+	case "bridge":
+		return networkconnector.InterfaceTypeBridge
+	case "vxlan":
+		return networkconnector.InterfaceTypeVxlan
+	default:
+		return networkconnector.InterfaceTypeVirtual
+	}
+}
+
+// interfaceState maps the kernel operational state to the reported link state.
+// Virtual interfaces such as dummies and loopbacks never report an operational
+// state, so for those the administrative state is used.
+func interfaceState(info *nl.InterfaceInformation) networkconnector.NodeInterfaceState {
+	switch info.OperState {
+	case "up":
+		return networkconnector.InterfaceStateUp
+	case "unknown":
+		if info.AdminUp {
+			return networkconnector.InterfaceStateUp
+		}
+		return networkconnector.InterfaceStateDown
+	case "down", "lower-layer-down", "not-present", "dormant":
+		return networkconnector.InterfaceStateDown
+	default:
+		return networkconnector.InterfaceStateUnknown
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodestatus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/vishvananda/netlink"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	networkconnector "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
+	"github.com/telekom/das-schiff-network-operator/pkg/nl"
+)
+
+const testNode = "node-1"
+
+type fakeLister struct {
+	interfaces []nl.InterfaceInformation
+	routes     []nl.RouteEntry
+	err        error
+}
+
+func (f *fakeLister) ListInterfaces() ([]nl.InterfaceInformation, error) {
+	return f.interfaces, f.err
+}
+
+func (f *fakeLister) ListRoutes() ([]nl.RouteEntry, error) {
+	return f.routes, f.err
+}
+
+func newTestClient(t *testing.T) client.Client {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := networkconnector.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: testNode, UID: "node-uid"}}
+	return fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(node).
+		WithStatusSubresource(&networkconnector.NodeNetworkStatus{}).
+		Build()
+}
+
+func testInventory() *fakeLister {
+	return &fakeLister{
+		interfaces: []nl.InterfaceInformation{
+			{Name: "bond0", Type: "bond", OperState: "up", MTU: 9000, MAC: "02:00:00:00:00:01", Members: []string{"eth0", "eth1"}},
+			{Name: "dum.underlay", Type: "dummy", OperState: "unknown", AdminUp: true, Addresses: []string{"10.0.0.1/32"}},
+			{Name: "eth0", Type: "device", OperState: "up", MasterIndex: 3, Master: "bond0"},
+			{Name: "eth1", Type: "device", OperState: "down", MasterIndex: 3, Master: "bond0"},
+			{Name: "lo", Type: "device", OperState: "unknown", AdminUp: true, Loopback: true},
+			{Name: "vlan.100", Type: "vlan", OperState: "lower-layer-down", Parent: "bond0", VlanID: 100},
+			{Name: "vr.tenant", Type: "vrf", OperState: "testing"},
+		},
+		routes: []nl.RouteEntry{
+			{Destination: "0.0.0.0/0", Gateway: "10.0.0.254", Interface: "bond0", Table: "main"},
+			{Destination: "192.168.0.0/24", Interface: "vlan.100", Table: "tenant"},
+		},
+	}
+}
+
+func TestBuildStatus(t *testing.T) {
+	inv := testInventory()
+	status := BuildStatus(inv.interfaces, inv.routes)
+
+	if len(status.Interfaces) != len(inv.interfaces) {
+		t.Fatalf("expected %d interfaces, got %d", len(inv.interfaces), len(status.Interfaces))
+	}
+
+	byName := map[string]networkconnector.NodeInterface{}
+	for _, iface := range status.Interfaces {
+		byName[iface.Name] = iface
+	}
+
+	tests := []struct {
+		name  string
+		typ   networkconnector.NodeInterfaceType
+		state networkconnector.NodeInterfaceState
+	}{
+		{"bond0", networkconnector.InterfaceTypeBond, networkconnector.InterfaceStateUp},
+		{"dum.underlay", networkconnector.InterfaceTypeVirtual, networkconnector.InterfaceStateUp},
+		{"eth1", networkconnector.InterfaceTypePhysical, networkconnector.InterfaceStateDown},
+		{"lo", networkconnector.InterfaceTypeLoopback, networkconnector.InterfaceStateUp},
+		{"vlan.100", networkconnector.InterfaceTypeVlan, networkconnector.InterfaceStateDown},
+		{"vr.tenant", networkconnector.InterfaceTypeVirtual, networkconnector.InterfaceStateUnknown},
+	}
+	for _, tc := range tests {
+		iface := byName[tc.name]
+		if iface.Type == nil || *iface.Type != tc.typ {
+			t.Errorf("%s: expected type %s, got %v", tc.name, tc.typ, iface.Type)
+		}
+		if iface.State != tc.state {
+			t.Errorf("%s: expected state %s, got %s", tc.name, tc.state, iface.State)
+		}
+	}
+
+	if got := byName["bond0"].Members; len(got) != 2 || got[0] != "eth0" || got[1] != "eth1" {
+		t.Errorf("unexpected bond members: %v", got)
+	}
+	vlan := byName["vlan.100"]
+	if vlan.Parent == nil || *vlan.Parent != "bond0" || vlan.VlanID == nil || *vlan.VlanID != 100 {
+		t.Errorf("unexpected vlan parent/id: %v/%v", vlan.Parent, vlan.VlanID)
+	}
+
+	if len(status.Routes) != 2 {
+		t.Fatalf("expected 2 routes, got %d", len(status.Routes))
+	}
+	if status.Routes[0].Gateway == nil || *status.Routes[0].Gateway != "10.0.0.254" {
+		t.Errorf("expected gateway on default route, got %v", status.Routes[0].Gateway)
+	}
+	if status.Routes[1].Gateway != nil {
+		t.Errorf("expected no gateway on connected route, got %v", *status.Routes[1].Gateway)
+	}
+	if status.Routes[1].Table == nil || *status.Routes[1].Table != "tenant" {
+		t.Errorf("expected VRF table name, got %v", status.Routes[1].Table)
+	}
+}
+
+func TestPublishCreatesStatus(t *testing.T) {
+	c := newTestClient(t)
+	p := NewPublisher(c, logr.Discard(), testInventory(), testNode, Options{})
+
+	if err := p.Publish(context.Background()); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	nns := &networkconnector.NodeNetworkStatus{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: testNode}, nns); err != nil {
+		t.Fatalf("expected NodeNetworkStatus to exist: %v", err)
+	}
+	if len(nns.OwnerReferences) != 1 || nns.OwnerReferences[0].Kind != "Node" {
+		t.Errorf("expected node owner reference, got %v", nns.OwnerReferences)
+	}
+	if len(nns.Status.Interfaces) != 7 || len(nns.Status.Routes) != 2 {
+		t.Errorf("unexpected inventory: %d interfaces, %d routes", len(nns.Status.Interfaces), len(nns.Status.Routes))
+	}
+	if nns.Status.LastUpdated == nil {
+		t.Error("expected lastUpdated to be set")
+	}
+	if !meta.IsStatusConditionTrue(nns.Status.Conditions, ConditionReady) {
+		t.Errorf("expected Ready condition, got %v", nns.Status.Conditions)
+	}
+	if meta.IsStatusConditionTrue(nns.Status.Conditions, ConditionRoutesTruncated) {
+		t.Error("expected routes not to be truncated")
+	}
+}
+
+func TestPublishTruncatesRoutes(t *testing.T) {
+	c := newTestClient(t)
+	p := NewPublisher(c, logr.Discard(), testInventory(), testNode, Options{MaxRoutes: 1})
+
+	if err := p.Publish(context.Background()); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	nns := &networkconnector.NodeNetworkStatus{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: testNode}, nns); err != nil {
+		t.Fatal(err)
+	}
+	if len(nns.Status.Routes) != 1 {
+		t.Errorf("expected 1 route, got %d", len(nns.Status.Routes))
+	}
+	if !meta.IsStatusConditionTrue(nns.Status.Conditions, ConditionRoutesTruncated) {
+		t.Errorf("expected RoutesTruncated condition, got %v", nns.Status.Conditions)
+	}
+}
+
+func TestPublishKeepsInventoryOnFailure(t *testing.T) {
+	c := newTestClient(t)
+	lister := testInventory()
+	p := NewPublisher(c, logr.Discard(), lister, testNode, Options{})
+
+	if err := p.Publish(context.Background()); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	lister.err = errors.New("netlink unavailable")
+	if err := p.Publish(context.Background()); err == nil {
+		t.Fatal("expected collection error")
+	}
+
+	nns := &networkconnector.NodeNetworkStatus{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: testNode}, nns); err != nil {
+		t.Fatal(err)
+	}
+	if len(nns.Status.Interfaces) != 7 {
+		t.Errorf("expected last known inventory to be kept, got %d interfaces", len(nns.Status.Interfaces))
+	}
+	cond := meta.FindStatusCondition(nns.Status.Conditions, ConditionReady)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != ReasonCollectionFailed {
+		t.Errorf("expected Ready=False/%s, got %v", ReasonCollectionFailed, cond)
+	}
+}
+
+func TestStartSkipsNetlinkEvents(t *testing.T) {
+	c := newTestClient(t)
+	p := NewPublisher(c, logr.Discard(), testInventory(), testNode, Options{SkipNetlinkEvents: true})
+	p.linkSubscribeFn = func(chan<- netlink.LinkUpdate, <-chan struct{}, netlink.LinkSubscribeOptions) error {
+		t.Error("unexpected link subscription")
+		return nil
+	}
+	p.addrSubscribeFn = func(chan<- netlink.AddrUpdate, <-chan struct{}, netlink.AddrSubscribeOptions) error {
+		t.Error("unexpected address subscription")
+		return nil
+	}
+	p.routeSubscribeFn = func(chan<- netlink.RouteUpdate, <-chan struct{}, netlink.RouteSubscribeOptions) error {
+		t.Error("unexpected route subscription")
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.Start(ctx); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	nns := &networkconnector.NodeNetworkStatus{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: testNode}, nns); err != nil {
+		t.Fatalf("expected the initial NodeNetworkStatus to be published: %v", err)
+	}
+}
@@ -2,14 +2,19 @@ package agent_cra_frr //nolint:revive
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
 	"github.com/telekom/das-schiff-network-operator/pkg/config"
 	cra "github.com/telekom/das-schiff-network-operator/pkg/cra-frr"
+	"github.com/telekom/das-schiff-network-operator/pkg/frr"
 	"github.com/telekom/das-schiff-network-operator/pkg/nl"
 	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/common"
 )
@@ -17,6 +22,8 @@ import (
 const (
 	baseConfigPath  = "/etc/cra/config/base-config.yaml"
 	frrTemplatePath = "/opt/network-operator/frr.conf.tpl"
+
+	bgpStateEstablished = "Established"
 )
 
 // CRAFRRConfigApplier implements the common.ConfigApplier interface for CRA-FRR.
@@ -24,6 +31,11 @@ type CRAFRRConfigApplier struct {
 	craManager  *cra.Manager
 	baseConfig  *config.BaseConfig
 	frrTemplate cra.FRRTemplate
+	// isolateFailures makes the CRA skip failing Layer2s and VRFs instead of
+	// failing the whole config.
+	isolateFailures bool
+	// results are the results of the last ApplyConfig call.
+	results *v1alpha1.ApplyResults
 }
 
 // ApplyConfig applies the network configuration using CRA-FRR manager.
@@ -31,18 +43,132 @@ func (a *CRAFRRConfigApplier) ApplyConfig(ctx context.Context, cfg *v1alpha1.Nod
 	netlinkConfig := a.convertNodeConfigToNetlink(cfg)
 	policyRoutes := convertPolicyRoutes(cfg)
 
+	a.results = nil
+
 	frrConfig, err := a.frrTemplate.TemplateFRR(a.baseConfig, &cfg.Spec)
 	if err != nil {
 		return fmt.Errorf("error templating FRR configuration: %w", err)
 	}
 
-	if err := a.craManager.ApplyConfiguration(ctx, &netlinkConfig, frrConfig, policyRoutes); err != nil {
+	result, err := a.craManager.ApplyConfiguration(ctx, &cra.Configuration{
+		NetlinkConfiguration: netlinkConfig,
+		FRRConfiguration:     frrConfig,
+		PolicyRoutes:         policyRoutes,
+		IsolateFailures:      a.isolateFailures,
+	})
+	if result != nil {
+		a.results = &v1alpha1.ApplyResults{
+			Stages:         result.Stages,
+			FailedSections: sectionResults(cfg, result.Failed),
+		}
+	}
+	if err != nil {
 		return fmt.Errorf("error applying cra configuration: %w", err)
 	}
 
 	return nil
 }
 
+// ApplyResults implements the common.ApplyReporter interface with the results reported by the CRA.
+func (a *CRAFRRConfigApplier) ApplyResults() *v1alpha1.ApplyResults {
+	return a.results
+}
+
+// sectionResults maps the Layer2s and VRFs the CRA failed to apply to the
+// sections of the config.
+func sectionResults(cfg *v1alpha1.NodeNetworkConfig, failed []cra.FailedItem) []v1alpha1.SectionResult {
+	var sections []v1alpha1.SectionResult
+	for _, item := range failed {
+		section := v1alpha1.SectionResult{Stage: item.Stage, Error: item.Error}
+		switch {
+		case item.VRF != "":
+			section.Section = "vrf:" + item.VRF
+			if _, ok := cfg.Spec.FabricVRFs[item.VRF]; ok {
+				section.Section = "fabricVRFs/" + item.VRF
+			} else if _, ok := cfg.Spec.LocalVRFs[item.VRF]; ok {
+				section.Section = "localVRFs/" + item.VRF
+			}
+		default:
+			section.Section = fmt.Sprintf("vlan:%d", item.VlanID)
+			for key := range cfg.Spec.Layer2s {
+				if int(cfg.Spec.Layer2s[key].VLAN) == item.VlanID {
+					section.Section = "layer2s/" + key
+					break
+				}
+			}
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// RenderConfig implements the common.ConfigRenderer interface by templating the FRR configuration.
+func (a *CRAFRRConfigApplier) RenderConfig(_ context.Context, cfg *v1alpha1.NodeNetworkConfig) ([]byte, string, error) {
+	frrConfig, err := a.frrTemplate.TemplateFRR(a.baseConfig, &cfg.Spec)
+	if err != nil {
+		return nil, "", fmt.Errorf("error templating FRR configuration: %w", err)
+	}
+	return []byte(frrConfig), "text/plain; charset=utf-8", nil
+}
+
+// BGPSessions implements the common.BGPChecker interface using the BGP summary of all VRFs.
+func (a *CRAFRRConfigApplier) BGPSessions(_ context.Context) (map[string]bool, error) {
+	data := a.craManager.ExecuteWithJSON([]string{"show", "bgp", "vrf", "all", "summary"})
+	if data == nil {
+		return nil, errors.New("error getting BGP summary from CRA")
+	}
+
+	summary := frr.BGPVrfSummary{}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("error parsing BGP summary: %w", err)
+	}
+
+	return getBGPSessions(summary), nil
+}
+
+// DetectDrift implements the common.DriftDetector interface using the drift
+// report of the CRA.
+func (a *CRAFRRConfigApplier) DetectDrift(ctx context.Context) ([]string, error) {
+	report, err := a.craManager.GetDrift(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting drift report from CRA: %w", err)
+	}
+	if !report.Applied {
+		return nil, errors.New("the CRA has not applied a configuration since it started")
+	}
+	if report.Error != "" {
+		return nil, fmt.Errorf("error checking for drift on the CRA: %s", report.Error)
+	}
+
+	drift := make([]string, 0, len(report.Items))
+	for _, item := range report.Items {
+		drift = append(drift, item.Section+": "+item.Reason)
+	}
+	return drift, nil
+}
+
+// CRAGeneration implements the common.RestartDetector interface using the
+// boot ID of the CRA.
+func (a *CRAFRRConfigApplier) CRAGeneration(ctx context.Context) (string, bool, error) {
+	info, err := a.craManager.GetBootInfo(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("error getting boot ID from CRA: %w", err)
+	}
+	return info.BootID, info.Applied, nil
+}
+
+func getBGPSessions(summary frr.BGPVrfSummary) map[string]bool {
+	sessions := map[string]bool{}
+	for vrf, families := range summary {
+		for _, family := range families {
+			for peer := range family.Peers {
+				sessions[vrf+"/"+peer] = family.Peers[peer].State == bgpStateEstablished
+			}
+		}
+	}
+	return sessions
+}
+
 func (a *CRAFRRConfigApplier) convertNodeConfigToNetlink(nodeCfg *v1alpha1.NodeNetworkConfig) (netlinkConfig nl.NetlinkConfiguration) {
 	for _, layer2 := range nodeCfg.Spec.Layer2s {
 		nlLayer2 := nl.Layer2Information{
@@ -184,6 +310,32 @@ func convertPolicyRoutes(nodeCfg *v1alpha1.NodeNetworkConfig) []cra.PolicyRoute
 	return routes
 }
 
+// Options configures the CRA-FRR NodeNetworkConfigReconciler.
+type Options struct {
+	// IsolateFailures applies the Layer2s and VRFs independently: the ones
+	// that fail are skipped and reported in the NodeNetworkConfig status while
+	// the others converge. By default a single failure fails the whole config
+	// and the previous config is restored.
+	IsolateFailures bool
+	// ConfirmTimeout enables confirmed apply, see
+	// common.ReconcilerOptions.ConfirmTimeout.
+	ConfirmTimeout time.Duration
+	// HealthMonitorWindow is the time after a config was provisioned during
+	// which the node's health is re-evaluated, see
+	// common.ReconcilerOptions.HealthMonitorWindow. Zero disables it.
+	HealthMonitorWindow time.Duration
+	// DriftCheckInterval is the interval the CRA is asked for drift at, zero
+	// disables drift detection.
+	DriftCheckInterval time.Duration
+	// ReapplyOnDrift re-applies the config when drift is detected.
+	ReapplyOnDrift bool
+	// RestartCheckInterval is the interval the CRA is checked for a restart
+	// at, zero disables replaying the config after a restart.
+	RestartCheckInterval time.Duration
+	// EventRecorder is used to emit events on the NodeNetworkConfig.
+	EventRecorder events.EventRecorder
+}
+
 // NodeNetworkConfigReconciler wraps the common reconciler with CRA-FRR specific logic.
 type NodeNetworkConfigReconciler struct {
 	*common.NodeNetworkConfigReconciler
@@ -195,6 +347,7 @@ func NewNodeNetworkConfigReconciler(
 	clusterClient client.Client,
 	logger logr.Logger,
 	nodeNetworkConfigPath string,
+	opts Options,
 ) (*NodeNetworkConfigReconciler, error) {
 	baseConfig, err := config.LoadBaseConfig(baseConfigPath)
 	if err != nil {
@@ -202,9 +355,10 @@ func NewNodeNetworkConfigReconciler(
 	}
 
 	configApplier := &CRAFRRConfigApplier{
-		craManager:  craManager,
-		baseConfig:  baseConfig,
-		frrTemplate: cra.FRRTemplate{FRRTemplatePath: frrTemplatePath},
+		craManager:      craManager,
+		baseConfig:      baseConfig,
+		frrTemplate:     cra.FRRTemplate{FRRTemplatePath: frrTemplatePath},
+		isolateFailures: opts.IsolateFailures,
 	}
 
 	commonReconciler, err := common.NewNodeNetworkConfigReconciler(
@@ -215,6 +369,17 @@ func NewNodeNetworkConfigReconciler(
 		common.ReconcilerOptions{
 			RestoreOnReconcileFailure: true, // FRR can partially apply invalid configs
 			LocalASN:                  baseConfig.LocalASN,
+			HealthMonitorWindow:       opts.HealthMonitorWindow,
+			BGPChecker:                configApplier,
+			ConfigRenderer:            configApplier,
+			ApplyReporter:             configApplier,
+			ConfirmTimeout:            opts.ConfirmTimeout,
+			DriftDetector:             configApplier,
+			DriftCheckInterval:        opts.DriftCheckInterval,
+			ReapplyOnDrift:            opts.ReapplyOnDrift,
+			RestartDetector:           configApplier,
+			RestartCheckInterval:      opts.RestartCheckInterval,
+			EventRecorder:             opts.EventRecorder,
 		},
 	)
 	if err != nil {
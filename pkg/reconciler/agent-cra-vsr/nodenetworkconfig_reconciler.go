@@ -19,8 +19,11 @@ package agent_cra_vsr //nolint:revive
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
@@ -31,16 +34,72 @@ import (
 // CRAVSRConfigApplier implements the common.ConfigApplier interface for CRA-VSR.
 type CRAVSRConfigApplier struct {
 	craManager *cra.Manager
+	// results are the results of the last ApplyConfig call.
+	results *v1alpha1.ApplyResults
 }
 
 // ApplyConfig applies the network configuration using CRA-VSR manager.
 func (a *CRAVSRConfigApplier) ApplyConfig(ctx context.Context, cfg *v1alpha1.NodeNetworkConfig) error {
-	if err := a.craManager.ApplyConfiguration(ctx, &cfg.Spec); err != nil {
+	started := time.Now()
+	err := a.craManager.ApplyConfiguration(ctx, &cfg.Spec)
+
+	stage := v1alpha1.StageResult{
+		Stage:    v1alpha1.ApplyStageVSRCommit,
+		Duration: metav1.Duration{Duration: time.Since(started)},
+	}
+	if err != nil {
+		stage.Error = err.Error()
+	}
+	a.results = &v1alpha1.ApplyResults{Stages: []v1alpha1.StageResult{stage}}
+
+	if err != nil {
 		return fmt.Errorf("error applying cra configuration: %w", err)
 	}
 	return nil
 }
 
+// ApplyResults implements the common.ApplyReporter interface with the timing of the vSR commit.
+func (a *CRAVSRConfigApplier) ApplyResults() *v1alpha1.ApplyResults {
+	return a.results
+}
+
+// RenderConfig implements the common.ConfigRenderer interface by rendering the vSR configuration.
+func (a *CRAVSRConfigApplier) RenderConfig(_ context.Context, cfg *v1alpha1.NodeNetworkConfig) ([]byte, string, error) {
+	vsrConfig, err := a.craManager.RenderConfig(&cfg.Spec)
+	if err != nil {
+		return nil, "", fmt.Errorf("error rendering vSR configuration: %w", err)
+	}
+	return vsrConfig, "application/xml", nil
+}
+
+// CRAGeneration implements the common.RestartDetector interface using the
+// boot time of the router, which changes when the router restarted. The
+// router is always considered configured, as its running config survives
+// everything but a restart.
+func (a *CRAVSRConfigApplier) CRAGeneration(ctx context.Context) (string, bool, error) {
+	bootTime, err := a.craManager.BootTime(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("error probing the vSR: %w", err)
+	}
+	return "boot-" + bootTime, true, nil
+}
+
+// Options configures the CRA-VSR NodeNetworkConfigReconciler.
+type Options struct {
+	// ConfirmTimeout enables confirmed apply, see
+	// common.ReconcilerOptions.ConfirmTimeout.
+	ConfirmTimeout time.Duration
+	// HealthMonitorWindow is the time after a config was provisioned during
+	// which the node's health is re-evaluated, see
+	// common.ReconcilerOptions.HealthMonitorWindow. Zero disables it.
+	HealthMonitorWindow time.Duration
+	// RestartCheckInterval is the interval the vSR is checked for a restart
+	// at, zero disables replaying the config after a restart.
+	RestartCheckInterval time.Duration
+	// EventRecorder is used to emit events on the NodeNetworkConfig.
+	EventRecorder events.EventRecorder
+}
+
 // NodeNetworkConfigReconciler wraps the common reconciler with CRA-VSR specific logic.
 type NodeNetworkConfigReconciler struct {
 	*common.NodeNetworkConfigReconciler
@@ -52,6 +111,7 @@ func NewNodeNetworkConfigReconciler(
 	clusterClient client.Client,
 	logger logr.Logger,
 	nodeNetworkConfigPath string,
+	opts Options,
 ) (*NodeNetworkConfigReconciler, error) {
 	configApplier := &CRAVSRConfigApplier{
 		craManager: craManager,
@@ -65,6 +125,13 @@ func NewNodeNetworkConfigReconciler(
 		common.ReconcilerOptions{
 			RestoreOnReconcileFailure: false, // VSR cannot commit invalid configs
 			LocalASN:                  craManager.LocalASN(),
+			HealthMonitorWindow:       opts.HealthMonitorWindow,
+			ConfigRenderer:            configApplier,
+			ApplyReporter:             configApplier,
+			ConfirmTimeout:            opts.ConfirmTimeout,
+			RestartDetector:           configApplier,
+			RestartCheckInterval:      opts.RestartCheckInterval,
+			EventRecorder:             opts.EventRecorder,
 		},
 	)
 	if err != nil {
@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/healthcheck"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/nncdiff"
+)
+
+const (
+	// ApplyPath is the path of the apply API serving the ApplyReport.
+	ApplyPath = "/apply"
+	// ApplyRenderedPath is the path of the apply API serving the rendered
+	// backend config of the applied NodeNetworkConfig.
+	ApplyRenderedPath = "/apply/rendered"
+	// DefaultApplyAPIAddr is the default bind address of the apply API.
+	DefaultApplyAPIAddr = ":7086"
+
+	// redacted replaces BGP peer passwords in the apply API's responses.
+	redacted = nncdiff.Redacted
+)
+
+// ApplyReport is what the apply API reports about the node's config.
+type ApplyReport struct {
+	Node string `json:"node"`
+	// AppliedRevision is the revision of the last successfully applied config.
+	AppliedRevision string `json:"appliedRevision,omitempty"`
+	// DesiredRevision is the revision of the node's NodeNetworkConfig.
+	DesiredRevision string `json:"desiredRevision,omitempty"`
+	// Applied is the last successfully applied config.
+	Applied *v1alpha1.NodeNetworkConfig `json:"applied,omitempty"`
+	// Diff lists the differences from the applied to the desired config.
+	Diff []nncdiff.Change `json:"diff,omitempty"`
+	// DesiredError is set if the desired config could not be fetched.
+	DesiredError string `json:"desiredError,omitempty"`
+	// LastApply is the most recent attempt to apply a config.
+	LastApply *ApplyResult `json:"lastApply,omitempty"`
+	// LastError is the most recent failed attempt to apply a config.
+	LastError *ApplyResult `json:"lastError,omitempty"`
+}
+
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// ApplyAPIOptions configures the server of the apply API.
+type ApplyAPIOptions struct {
+	// BindAddress is the address to serve the apply API on, "0" disables it.
+	BindAddress string
+	// CertDir contains the tls.crt and tls.key to serve the apply API with.
+	// A self-signed certificate is used if they do not exist.
+	CertDir string
+}
+
+// AddApplyAPIToManager serves the apply API of r over https, next to an
+// authenticated copy of the /metrics endpoint. Requests must carry a bearer
+// token whose user may get the request path (a nonResourceURLs rule), which
+// is checked with cached TokenReviews and SubjectAccessReviews.
+func AddApplyAPIToManager(mgr manager.Manager, r *NodeNetworkConfigReconciler, opts ApplyAPIOptions) error {
+	server, err := metricsserver.NewServer(metricsserver.Options{
+		BindAddress:    opts.BindAddress,
+		SecureServing:  true,
+		FilterProvider: filters.WithAuthenticationAndAuthorization,
+		CertDir:        opts.CertDir,
+		ExtraHandlers: map[string]http.Handler{
+			ApplyPath:         http.HandlerFunc(r.ServeApplyReport),
+			ApplyRenderedPath: http.HandlerFunc(r.ServeRenderedConfig),
+		},
+	}, mgr.GetConfig(), mgr.GetHTTPClient())
+	if err != nil {
+		return fmt.Errorf("error creating apply API server: %w", err)
+	}
+	if server == nil {
+		return nil
+	}
+	if err := mgr.Add(server); err != nil {
+		return fmt.Errorf("error adding apply API server: %w", err)
+	}
+	return nil
+}
+
+// ApplyReport returns what is known about the node's applied and desired
+// config. BGP peer passwords are redacted.
+func (r *NodeNetworkConfigReconciler) ApplyReport(req *http.Request) *ApplyReport {
+	r.mu.RLock()
+	applied := r.NodeNetworkConfig
+	report := &ApplyReport{
+		Node:      os.Getenv(healthcheck.NodenameEnv),
+		LastApply: r.lastApply,
+		LastError: r.lastError,
+	}
+	r.mu.RUnlock()
+
+	var from nncdiff.Config
+	if applied != nil {
+		report.Applied = redactPasswords(applied)
+		report.AppliedRevision = applied.Spec.Revision
+		from.Spec = &applied.Spec
+	}
+
+	desired, err := r.fetchNodeConfig(req.Context())
+	if err != nil {
+		report.DesiredError = err.Error()
+		return report
+	}
+	report.DesiredRevision = desired.Spec.Revision
+	report.Diff = nncdiff.Diff(from, nncdiff.Config{Spec: &desired.Spec})
+
+	return report
+}
+
+// redactPasswords returns a copy of cfg with the passwords of its BGP peers
+// redacted.
+func redactPasswords(cfg *v1alpha1.NodeNetworkConfig) *v1alpha1.NodeNetworkConfig {
+	cfg = cfg.DeepCopy()
+	if cfg.Spec.ClusterVRF != nil {
+		redactPeerPasswords(cfg.Spec.ClusterVRF.BGPPeers)
+	}
+	for _, vrf := range cfg.Spec.FabricVRFs {
+		redactPeerPasswords(vrf.BGPPeers)
+	}
+	for _, vrf := range cfg.Spec.LocalVRFs {
+		redactPeerPasswords(vrf.BGPPeers)
+	}
+	return cfg
+}
+
+func redactPeerPasswords(peers []v1alpha1.BGPPeer) {
+	for i := range peers {
+		if peers[i].Password != nil {
+			password := redacted
+			peers[i].Password = &password
+		}
+	}
+}
+
+// ServeApplyReport serves the ApplyReport as JSON.
+func (r *NodeNetworkConfigReconciler) ServeApplyReport(w http.ResponseWriter, req *http.Request) {
+	data, err := json.MarshalIndent(r.ApplyReport(req), "", "\t")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error marshaling apply report: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		r.logger.Error(err, "failed to write apply report")
+	}
+}
+
+// ServeRenderedConfig serves the backend config of the applied
+// NodeNetworkConfig as rendered by the agent's ConfigRenderer. BGP peer
+// passwords are redacted before rendering.
+func (r *NodeNetworkConfigReconciler) ServeRenderedConfig(w http.ResponseWriter, req *http.Request) {
+	if r.configRenderer == nil {
+		http.Error(w, "the agent does not render a backend config", http.StatusNotFound)
+		return
+	}
+
+	r.mu.RLock()
+	applied := r.NodeNetworkConfig
+	r.mu.RUnlock()
+	if applied == nil {
+		http.Error(w, "no config has been applied yet", http.StatusNotFound)
+		return
+	}
+
+	data, contentType, err := r.configRenderer.RenderConfig(req.Context(), redactPasswords(applied))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error rendering config: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if _, err := w.Write(data); err != nil {
+		r.logger.Error(err, "failed to write rendered config")
+	}
+}
@@ -0,0 +1,139 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/nncdiff"
+)
+
+// fakeRenderer renders every config to the same string and keeps the last
+// rendered config.
+type fakeRenderer struct {
+	config   string
+	rendered *v1alpha1.NodeNetworkConfig
+}
+
+func (f *fakeRenderer) RenderConfig(_ context.Context, cfg *v1alpha1.NodeNetworkConfig) ([]byte, string, error) {
+	f.rendered = cfg
+	return []byte(f.config), "text/plain", nil
+}
+
+// withPeerPassword returns cfg with a cluster VRF BGP peer using password.
+func withPeerPassword(cfg *v1alpha1.NodeNetworkConfig, password string) *v1alpha1.NodeNetworkConfig {
+	address := "192.0.2.1"
+	cfg.Spec.ClusterVRF = &v1alpha1.VRF{BGPPeers: []v1alpha1.BGPPeer{{
+		Address:   &address,
+		RemoteASN: 65001,
+		Password:  &password,
+	}}}
+	return cfg
+}
+
+func newApplyAPIClient(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(objs...).
+		Build()
+}
+
+func serveApplyAPI(r *NodeNetworkConfigReconciler, path string) *httptest.ResponseRecorder {
+	handler := http.HandlerFunc(r.ServeApplyReport)
+	if path == ApplyRenderedPath {
+		handler = http.HandlerFunc(r.ServeRenderedConfig)
+	}
+	req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+	return res
+}
+
+var _ = Describe("apply API", func() {
+	var (
+		mockCtrl   *gomock.Controller
+		configPath string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		configPath = filepath.Join(tmpPath, "apply-config.yaml")
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("reports the applied config, its diff to the desired one and the last error", func() {
+		desired := createTestNodeNetworkConfig("2")
+		desired.Spec.Layer2s = map[string]v1alpha1.Layer2{"100": {VNI: 100, VLAN: 100, MTU: 1500}}
+		r := newMockReconciler(mockCtrl, newApplyAPIClient(desired), configPath, ReconcilerOptions{})
+		r.NodeNetworkConfig = createTestNodeNetworkConfig("1")
+
+		r.mockApplier.EXPECT().ApplyConfig(gomock.Any(), desired).Return(errors.New("commit failed"))
+		_, err := r.processConfig(context.Background(), desired)
+		Expect(err).To(HaveOccurred())
+
+		res := serveApplyAPI(r.NodeNetworkConfigReconciler, ApplyPath)
+		Expect(res.Code).To(Equal(http.StatusOK))
+
+		report := &ApplyReport{}
+		Expect(json.Unmarshal(res.Body.Bytes(), report)).To(Succeed())
+		Expect(report.Node).To(Equal(testNodeName))
+		Expect(report.AppliedRevision).To(Equal("1"))
+		Expect(report.DesiredRevision).To(Equal("2"))
+		Expect(report.Diff).To(HaveLen(1))
+		Expect(report.Diff[0].Op).To(Equal(nncdiff.Added))
+		Expect(report.Diff[0].Section).To(Equal("layer2s/100"))
+		Expect(report.LastError).ToNot(BeNil())
+		Expect(report.LastError.Revision).To(Equal("2"))
+		Expect(report.LastError.Error).To(ContainSubstring("commit failed"))
+		Expect(report.LastApply).To(Equal(report.LastError))
+	})
+
+	It("serves the rendered backend config of the applied config", func() {
+		r := newMockReconciler(mockCtrl, newApplyAPIClient(), configPath, ReconcilerOptions{})
+		Expect(serveApplyAPI(r.NodeNetworkConfigReconciler, ApplyRenderedPath).Code).To(Equal(http.StatusNotFound))
+
+		r = newMockReconciler(mockCtrl, newApplyAPIClient(), configPath, ReconcilerOptions{ConfigRenderer: &fakeRenderer{config: "router bgp 65000"}})
+		Expect(serveApplyAPI(r.NodeNetworkConfigReconciler, ApplyRenderedPath).Code).To(Equal(http.StatusNotFound))
+
+		r.NodeNetworkConfig = createTestNodeNetworkConfig("1")
+		res := serveApplyAPI(r.NodeNetworkConfigReconciler, ApplyRenderedPath)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(res.Header().Get("Content-Type")).To(Equal("text/plain"))
+		Expect(res.Body.String()).To(Equal("router bgp 65000"))
+	})
+
+	It("redacts BGP peer passwords", func() {
+		desired := withPeerPassword(createTestNodeNetworkConfig("2"), "new-secret")
+		renderer := &fakeRenderer{config: "router bgp 65000"}
+		r := newMockReconciler(mockCtrl, newApplyAPIClient(desired), configPath, ReconcilerOptions{ConfigRenderer: renderer})
+		r.NodeNetworkConfig = withPeerPassword(createTestNodeNetworkConfig("1"), "old-secret")
+
+		res := serveApplyAPI(r.NodeNetworkConfigReconciler, ApplyPath)
+		Expect(res.Code).To(Equal(http.StatusOK))
+		Expect(res.Body.String()).ToNot(ContainSubstring("secret"))
+
+		report := &ApplyReport{}
+		Expect(json.Unmarshal(res.Body.Bytes(), report)).To(Succeed())
+		Expect(*report.Applied.Spec.ClusterVRF.BGPPeers[0].Password).To(Equal(redacted))
+		Expect(report.Diff).To(HaveLen(1))
+		Expect(report.Diff[0].Fields).To(ContainElement(nncdiff.Field{Op: nncdiff.Changed, Path: "password", From: redacted, To: redacted}))
+
+		Expect(serveApplyAPI(r.NodeNetworkConfigReconciler, ApplyRenderedPath).Code).To(Equal(http.StatusOK))
+		Expect(*renderer.rendered.Spec.ClusterVRF.BGPPeers[0].Password).To(Equal(redacted))
+		Expect(*r.NodeNetworkConfig.Spec.ClusterVRF.BGPPeers[0].Password).To(Equal("old-secret"))
+	})
+})
@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+const (
+	// maxDriftMessageItems is the number of drift items listed in the
+	// ConfigDrift condition message.
+	maxDriftMessageItems = 10
+)
+
+var (
+	// DriftItems is a gauge of the number of differences between the applied
+	// config and the node's actual network config.
+	DriftItems = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "nwop",
+			Subsystem: "drift",
+			Name:      "items",
+			Help:      "Number of differences between the applied config and the node's actual network config",
+		},
+	)
+
+	// DriftLastCheck is a gauge that records the Unix timestamp of the last
+	// successful drift check.
+	DriftLastCheck = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "nwop",
+			Subsystem: "drift",
+			Name:      "last_check_timestamp_seconds",
+			Help:      "Unix timestamp of the last successful drift check",
+		},
+	)
+
+	// DriftReapplies is a counter of the times the config was re-applied
+	// because of drift.
+	DriftReapplies = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "nwop",
+			Subsystem: "drift",
+			Name:      "reapplies_total",
+			Help:      "Number of times the config was re-applied because of drift",
+		},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(DriftItems, DriftLastCheck, DriftReapplies)
+}
+
+// DriftDetector is an optional interface for agents that can detect changes
+// made to the node's network config behind their back.
+type DriftDetector interface {
+	// DetectDrift returns the differences between the last applied config
+	// and the node's actual network config, none if they match.
+	DetectDrift(ctx context.Context) ([]string, error)
+}
+
+// checkDrift checks the node for drift at most every driftCheckInterval,
+// publishes the result as the ConfigDrift condition of cfg and re-applies cfg
+// if enabled. The returned result requeues for the next check.
+func (r *NodeNetworkConfigReconciler) checkDrift(ctx context.Context, cfg *v1alpha1.NodeNetworkConfig, result ctrl.Result) ctrl.Result {
+	if r.driftDetector == nil || r.driftCheckInterval <= 0 {
+		return result
+	}
+	if since := time.Since(r.lastDriftCheck); since < r.driftCheckInterval {
+		return requeueWithin(result, r.driftCheckInterval-since)
+	}
+	r.lastDriftCheck = time.Now()
+
+	condition := metav1.Condition{
+		Type:               v1alpha1.ConditionConfigDrift,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1alpha1.ReasonNoDrift,
+		Message:            "The node's network config matches the applied config",
+		ObservedGeneration: cfg.Generation,
+	}
+	reapplied := false
+
+	drift, err := r.driftDetector.DetectDrift(ctx)
+	switch {
+	case err != nil:
+		r.logger.Error(err, "failed to check for config drift")
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = v1alpha1.ReasonDriftCheckFailed
+		condition.Message = err.Error()
+	case len(drift) > 0:
+		r.logger.Info("config drift detected", "drift", drift)
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1alpha1.ReasonDriftDetected
+		condition.Message = driftMessage(drift)
+		if r.reapplyOnDrift {
+			reapplied = r.reapply(ctx, cfg)
+			if reapplied {
+				condition.Message += " (config re-applied)"
+			}
+		}
+	}
+	if err == nil {
+		DriftItems.Set(float64(len(drift)))
+		DriftLastCheck.SetToCurrentTime()
+	}
+
+	if changed := meta.SetStatusCondition(&cfg.Status.Conditions, condition); changed || reapplied {
+		if err := r.client.Status().Update(ctx, cfg); err != nil {
+			r.logger.Error(err, "failed to update NodeNetworkConfig drift condition")
+		}
+	}
+
+	return requeueWithin(result, r.driftCheckInterval)
+}
+
+// reapply applies cfg again to correct drift. It returns whether cfg was
+// applied.
+func (r *NodeNetworkConfigReconciler) reapply(ctx context.Context, cfg *v1alpha1.NodeNetworkConfig) bool {
+	r.logger.Info("re-applying NodeNetworkConfig to correct drift", "name", cfg.Name, "revision", cfg.Spec.Revision)
+	DriftReapplies.Inc()
+
+	started := time.Now()
+	err := r.doReconciliation(ctx, cfg)
+	r.recordApply(cfg.Spec.Revision, started, time.Since(started), err)
+	r.setApplyResults(cfg)
+	if err != nil {
+		r.logger.Error(err, "failed to re-apply NodeNetworkConfig")
+		return false
+	}
+	return true
+}
+
+// driftRequeue returns result requeued for the next drift check.
+func (r *NodeNetworkConfigReconciler) driftRequeue(result ctrl.Result) ctrl.Result {
+	if r.driftDetector == nil || r.driftCheckInterval <= 0 {
+		return result
+	}
+	return requeueWithin(result, r.driftCheckInterval)
+}
+
+// requeueWithin returns result requeued after at most after.
+func requeueWithin(result ctrl.Result, after time.Duration) ctrl.Result {
+	if result.RequeueAfter == 0 || after < result.RequeueAfter {
+		result.RequeueAfter = after
+	}
+	return result
+}
+
+// driftMessage lists the first maxDriftMessageItems drift items.
+func driftMessage(drift []string) string {
+	if len(drift) <= maxDriftMessageItems {
+		return strings.Join(drift, "; ")
+	}
+	return fmt.Sprintf("%s; and %d more", strings.Join(drift[:maxDriftMessageItems], "; "), len(drift)-maxDriftMessageItems)
+}
@@ -23,13 +23,19 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -45,6 +51,19 @@ const (
 	NodeNetworkConfigFilePerm = 0o600
 	// TaintRemovalRequeueTime is the delay before retrying taint removal after a conflict.
 	TaintRemovalRequeueTime = 30 * time.Second
+	// HealthMonitorInterval is the interval the node's health is re-evaluated at
+	// during the health monitor window.
+	HealthMonitorInterval = 30 * time.Second
+	// ConfirmInterval is the interval the API server and reachability checks
+	// are retried at while a config awaits confirmation.
+	ConfirmInterval = 2 * time.Second
+	// UnconfirmedFileSuffix is appended to the path of the stored config to
+	// get the path of the unconfirmed config marker.
+	UnconfirmedFileSuffix = ".unconfirmed"
+
+	// errUnconfirmedRestart is the reason a config is invalidated for if the
+	// agent restarted while it awaited confirmation.
+	errUnconfirmedRestart = "agent restarted before the apply was confirmed"
 )
 
 // ConfigApplier is an interface for applying network configuration.
@@ -54,6 +73,28 @@ type ConfigApplier interface {
 	ApplyConfig(ctx context.Context, cfg *v1alpha1.NodeNetworkConfig) error
 }
 
+// BGPChecker is an optional interface for agents that run BGP.
+type BGPChecker interface {
+	// BGPSessions returns all configured BGP sessions (keyed by VRF and peer)
+	// and whether they are established.
+	BGPSessions(ctx context.Context) (map[string]bool, error)
+}
+
+// ConfigRenderer is an optional interface for agents that can render the
+// backend config they apply for a NodeNetworkConfig, e.g. the FRR config.
+type ConfigRenderer interface {
+	// RenderConfig returns the backend config for cfg and its content type.
+	RenderConfig(ctx context.Context, cfg *v1alpha1.NodeNetworkConfig) ([]byte, string, error)
+}
+
+// ApplyReporter is an optional interface for ConfigAppliers that report the
+// stages and the failed sections of their last ApplyConfig call.
+type ApplyReporter interface {
+	// ApplyResults returns the results of the last ApplyConfig call, nil if
+	// there are none.
+	ApplyResults() *v1alpha1.ApplyResults
+}
+
 // ReconcilerOptions contains configuration options for the reconciler.
 type ReconcilerOptions struct {
 	// RestoreOnReconcileFailure controls whether to restore the previous config
@@ -67,6 +108,52 @@ type ReconcilerOptions struct {
 	// node's NodeNetworkConfig.status.asNumber so the operator can report the
 	// server ASN on BGPPeering status. Zero means unset (nothing is surfaced).
 	LocalASN int
+
+	// HealthMonitorWindow is the time after a config was provisioned during
+	// which the node's health is re-evaluated every HealthMonitorInterval and
+	// published as Node conditions, so the operator can roll back revisions
+	// that degrade the node. Zero disables the monitoring.
+	HealthMonitorWindow time.Duration
+
+	// BGPChecker is used to publish the BGPSessionsEstablished Node condition.
+	// If nil, the condition is not published.
+	BGPChecker BGPChecker
+
+	// ConfigRenderer is used by the apply API to serve the rendered backend
+	// config. If nil, the rendered config is not served.
+	ConfigRenderer ConfigRenderer
+
+	// ApplyReporter is used to publish the stage and section results of
+	// applying a config on the NodeNetworkConfig status. If nil, they are
+	// not published.
+	ApplyReporter ApplyReporter
+
+	// ConfirmTimeout enables confirmed apply: after a config was applied, the
+	// reachability and API server checks must pass within this time. If they
+	// do not, the previous config is restored right away, before the config
+	// is invalidated, so a config that cuts the node off from the control
+	// plane is reverted without the operator. Zero disables confirmed apply.
+	ConfirmTimeout time.Duration
+
+	// DriftDetector is used to check the node for drift from the applied
+	// config every DriftCheckInterval, published as the ConfigDrift condition
+	// of the NodeNetworkConfig. If nil or the interval is zero, drift is not
+	// checked.
+	DriftDetector      DriftDetector
+	DriftCheckInterval time.Duration
+	// ReapplyOnDrift re-applies the config when drift is detected.
+	ReapplyOnDrift bool
+
+	// RestartDetector is used to check the CRA for a restart every
+	// RestartCheckInterval. The provisioned config is replayed right away
+	// when the CRA restarted, as it may have lost the applied config. If nil
+	// or the interval is zero, restarts are not detected.
+	RestartDetector      RestartDetector
+	RestartCheckInterval time.Duration
+
+	// EventRecorder is used to emit events on the NodeNetworkConfig. If nil,
+	// no events are emitted.
+	EventRecorder events.EventRecorder
 }
 
 // NodeNetworkConfigReconciler handles the common reconciliation logic for NodeNetworkConfig.
@@ -79,6 +166,53 @@ type NodeNetworkConfigReconciler struct {
 	NodeNetworkConfigPath     string
 	restoreOnReconcileFailure bool
 	localASN                  int64
+	healthMonitorWindow       time.Duration
+	bgpChecker                BGPChecker
+	configRenderer            ConfigRenderer
+	applyReporter             ApplyReporter
+	confirmTimeout            time.Duration
+	confirmInterval           time.Duration
+	driftDetector             DriftDetector
+	driftCheckInterval        time.Duration
+	reapplyOnDrift            bool
+	restartDetector           RestartDetector
+	restartCheckInterval      time.Duration
+	eventRecorder             events.EventRecorder
+	// lastDriftCheck is the time the node was last checked for drift.
+	lastDriftCheck time.Time
+	// lastRestartCheck is the time the CRA was last checked for a restart and
+	// craGeneration the CRA generation seen then, empty if there is no
+	// baseline yet.
+	lastRestartCheck time.Time
+	craGeneration    string
+	// unconfirmed is the config that awaits confirmation or that was
+	// reverted because it could not be confirmed but not yet invalidated, as
+	// the API server was unreachable. It is persisted next to the stored
+	// config; restoreUnconfirmed is set if it was read from there at startup
+	// and the stored config must be restored before invalidating it.
+	unconfirmed        *ApplyResult
+	restoreUnconfirmed bool
+	// bgpBaseline holds the BGP sessions established before the current
+	// config was applied.
+	bgpBaseline map[string]bool
+
+	// mu guards NodeNetworkConfig, lastApply and lastError, which are read
+	// by the apply API.
+	mu        sync.RWMutex
+	lastApply *ApplyResult
+	lastError *ApplyResult
+}
+
+// ApplyResult records an attempt to apply a NodeNetworkConfig.
+type ApplyResult struct {
+	// Revision is the revision of the applied NodeNetworkConfig.
+	Revision string `json:"revision"`
+	// Started is the time the config was started to be applied at.
+	Started metav1.Time `json:"started"`
+	// Duration is the time it took to apply the config.
+	Duration metav1.Duration `json:"duration"`
+	// Error is the reason the config was invalidated, empty on success.
+	Error string `json:"error,omitempty"`
 }
 
 // NewNodeNetworkConfigReconciler creates a new NodeNetworkConfigReconciler.
@@ -96,6 +230,18 @@ func NewNodeNetworkConfigReconciler(
 		NodeNetworkConfigPath:     nodeNetworkConfigPath,
 		restoreOnReconcileFailure: opts.RestoreOnReconcileFailure,
 		localASN:                  int64(opts.LocalASN),
+		healthMonitorWindow:       opts.HealthMonitorWindow,
+		bgpChecker:                opts.BGPChecker,
+		configRenderer:            opts.ConfigRenderer,
+		applyReporter:             opts.ApplyReporter,
+		confirmTimeout:            opts.ConfirmTimeout,
+		confirmInterval:           ConfirmInterval,
+		driftDetector:             opts.DriftDetector,
+		driftCheckInterval:        opts.DriftCheckInterval,
+		reapplyOnDrift:            opts.ReapplyOnDrift,
+		restartDetector:           opts.RestartDetector,
+		restartCheckInterval:      opts.RestartCheckInterval,
+		eventRecorder:             opts.EventRecorder,
 	}
 
 	nc, err := healthcheck.LoadConfig(healthcheck.NetHealthcheckFile)
@@ -117,6 +263,10 @@ func NewNodeNetworkConfigReconciler(
 		return nil, fmt.Errorf("error reading NodeNetworkConfig from disk: %w", err)
 	}
 
+	if err := reconciler.loadUnconfirmed(); err != nil {
+		return nil, err
+	}
+
 	return reconciler, nil
 }
 
@@ -143,10 +293,11 @@ func (r *NodeNetworkConfigReconciler) Reconcile(ctx context.Context) (ctrl.Resul
 	cfg.Status.ASNumber = r.localASN
 
 	if r.NodeNetworkConfig != nil && r.NodeNetworkConfig.Spec.Revision == cfg.Spec.Revision {
-		// replace in-memory working NodeNetworkConfig and store it on the disk
-		if err := r.storeConfig(cfg, r.NodeNetworkConfigPath); err != nil {
-			return ctrl.Result{}, fmt.Errorf("error saving NodeNetworkConfig status: %w", err)
-		}
+		// replace in-memory working NodeNetworkConfig, the one on the disk
+		// already has this revision
+		r.mu.Lock()
+		r.NodeNetworkConfig = cfg
+		r.mu.Unlock()
 
 		// current in-memory config has the same revision as the fetched one
 		// this means that NodeNetworkConfig was already provisioned - skip
@@ -165,9 +316,30 @@ func (r *NodeNetworkConfigReconciler) Reconcile(ctx context.Context) (ctrl.Resul
 			}
 		}
 
-		return ctrl.Result{}, nil
+		// replay the config if the CRA restarted and may have lost it
+		if err := r.checkRestart(ctx, cfg); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return r.restartRequeue(r.checkDrift(ctx, cfg, r.monitorHealth(ctx, cfg))), nil
 	}
 
+	// NodeNetworkConfig was reverted as it could not be confirmed - invalidate it
+	if r.unconfirmed != nil && r.unconfirmed.Revision == cfg.Spec.Revision {
+		// the agent restarted, the config may not have been reverted yet
+		if r.restoreUnconfirmed {
+			if err := r.restoreNodeNetworkConfig(ctx); err != nil {
+				return ctrl.Result{}, fmt.Errorf("error reverting unconfirmed NodeNetworkConfig: %w", err)
+			}
+			r.restoreUnconfirmed = false
+		}
+		return ctrl.Result{}, r.invalidateUnconfirmed(ctx, cfg)
+	}
+	if err := r.setUnconfirmed(nil); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.restoreUnconfirmed = false
+
 	// NodeNetworkConfig is invalid - discard
 	if cfg.Spec.Revision == cfg.Status.LastAppliedRevision && cfg.Status.ConfigStatus == operator.StatusInvalid {
 		r.logger.Info("skipping invalid NodeNetworkConfig", "name", cfg.Name)
@@ -184,14 +356,21 @@ func (r *NodeNetworkConfigReconciler) Reconcile(ctx context.Context) (ctrl.Resul
 		return ctrl.Result{}, fmt.Errorf("error saving NodeNetworkConfig status: %w", err)
 	}
 
-	return result, nil
+	// check the new config for drift and the CRA for restarts on the next
+	// reconciliation
+	r.lastDriftCheck = time.Time{}
+	r.resetCRAGeneration()
+
+	return r.restartRequeue(r.driftRequeue(result)), nil
 }
 
 func (r *NodeNetworkConfigReconciler) storeConfig(
 	cfg *v1alpha1.NodeNetworkConfig,
 	path string,
 ) error {
+	r.mu.Lock()
 	r.NodeNetworkConfig = cfg
+	r.mu.Unlock()
 
 	// save working NodeNetworkConfig
 	c, err := json.MarshalIndent(*r.NodeNetworkConfig, "", " ")
@@ -309,8 +488,16 @@ func (r *NodeNetworkConfigReconciler) processConfig(
 		return ctrl.Result{}, fmt.Errorf("error setting NodeNetworkConfig status %s: %w", operator.StatusProvisioning, err)
 	}
 
+	// remember the established BGP sessions to detect the ones going down after the config was applied
+	r.recordBGPBaseline(ctx)
+
 	// reconcile NodeNetworkConfig
-	if err := r.doReconciliation(ctx, cfg); err != nil {
+	started := time.Now()
+	err := r.doReconciliation(ctx, cfg)
+	duration := time.Since(started)
+	r.recordApply(cfg.Spec.Revision, started, duration, err)
+	r.setApplyResults(cfg)
+	if err != nil {
 		reconcileErrMsg := err.Error()
 		// if reconciliation failed set NodeNetworkConfig's status as invalid
 		if r.restoreOnReconcileFailure {
@@ -330,9 +517,25 @@ func (r *NodeNetworkConfigReconciler) processConfig(
 		return ctrl.Result{}, fmt.Errorf("reconciler error: %w", err)
 	}
 
+	// confirm the node can still reach the API server, otherwise revert
+	if r.confirmTimeout > 0 {
+		// the config is reverted after a restart until it was confirmed
+		if err := r.setUnconfirmed(&ApplyResult{Revision: cfg.Spec.Revision, Error: errUnconfirmedRestart}); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.confirmApply(ctx); err != nil {
+			r.recordApply(cfg.Spec.Revision, started, duration, err)
+			return ctrl.Result{}, r.revertUnconfirmed(ctx, cfg, err)
+		}
+		if err := r.setUnconfirmed(nil); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// check if node is healthy after reconciliation
 	result, err := r.checkHealth(ctx)
 	if err != nil {
+		r.recordApply(cfg.Spec.Revision, started, duration, fmt.Errorf("healthcheck failed: %w", err))
 		healthErrMsg := err.Error()
 		// if node is not healthy set NodeNetworkConfig's status as invalid
 		// and restore last known working NodeNetworkConfig
@@ -348,9 +551,228 @@ func (r *NodeNetworkConfigReconciler) processConfig(
 		return ctrl.Result{}, fmt.Errorf("error setting NodeNetworkConfig status %s: %w", operator.StatusProvisioned, err)
 	}
 
+	// start monitoring the node's health
+	if result.RequeueAfter == 0 && r.healthMonitorWindow > 0 {
+		result.RequeueAfter = HealthMonitorInterval
+	}
+
 	return result, nil
 }
 
+// confirmApply waits for the reachability and API server checks to pass,
+// retrying them every confirmInterval for up to confirmTimeout.
+func (r *NodeNetworkConfigReconciler) confirmApply(ctx context.Context) error {
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, r.confirmInterval, r.confirmTimeout, true, func(ctx context.Context) (bool, error) {
+		if lastErr = r.healthChecker.CheckReachability(); lastErr != nil {
+			return false, nil
+		}
+		if lastErr = r.healthChecker.CheckAPIServer(ctx); lastErr != nil {
+			return false, nil
+		}
+		return true, nil
+	})
+	if err == nil {
+		return nil
+	}
+	if lastErr == nil {
+		lastErr = err
+	}
+	return fmt.Errorf("apply not confirmed within %s: %w", r.confirmTimeout, lastErr)
+}
+
+// revertUnconfirmed restores the previous config after cfg could not be
+// confirmed and then invalidates cfg. If the API server is still unreachable,
+// cfg is invalidated by the next reconciliation instead.
+func (r *NodeNetworkConfigReconciler) revertUnconfirmed(ctx context.Context, cfg *v1alpha1.NodeNetworkConfig, confirmErr error) error {
+	r.logger.Info("reverting unconfirmed NodeNetworkConfig", "name", cfg.Name, "revision", cfg.Spec.Revision, "error", confirmErr.Error())
+	if err := r.restoreNodeNetworkConfig(ctx); err != nil {
+		return fmt.Errorf("error reverting unconfirmed NodeNetworkConfig: %w", err)
+	}
+
+	if err := r.setUnconfirmed(&ApplyResult{Revision: cfg.Spec.Revision, Error: confirmErr.Error()}); err != nil {
+		return err
+	}
+	if err := r.invalidateUnconfirmed(ctx, cfg); err != nil {
+		r.logger.Error(err, "failed to invalidate unconfirmed NodeNetworkConfig, will retry on next reconciliation")
+	}
+
+	return fmt.Errorf("previous NodeNetworkConfig restored: %w", confirmErr)
+}
+
+// invalidateUnconfirmed invalidates cfg, the reverted unconfirmed config.
+func (r *NodeNetworkConfigReconciler) invalidateUnconfirmed(ctx context.Context, cfg *v1alpha1.NodeNetworkConfig) error {
+	if err := r.invalidateNodeNetworkConfig(ctx, cfg, "apply not confirmed", r.unconfirmed.Error); err != nil {
+		return err
+	}
+	return r.setUnconfirmed(nil)
+}
+
+func (r *NodeNetworkConfigReconciler) unconfirmedPath() string {
+	return r.NodeNetworkConfigPath + UnconfirmedFileSuffix
+}
+
+// setUnconfirmed sets the unconfirmed config and persists it next to the
+// stored config, or removes it from there if unconfirmed is nil.
+func (r *NodeNetworkConfigReconciler) setUnconfirmed(unconfirmed *ApplyResult) error {
+	r.unconfirmed = unconfirmed
+	if unconfirmed == nil {
+		if err := os.Remove(r.unconfirmedPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error removing unconfirmed NodeNetworkConfig marker: %w", err)
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(unconfirmed)
+	if err != nil {
+		return fmt.Errorf("error marshaling unconfirmed NodeNetworkConfig marker: %w", err)
+	}
+	if err := os.WriteFile(r.unconfirmedPath(), data, NodeNetworkConfigFilePerm); err != nil {
+		return fmt.Errorf("error saving unconfirmed NodeNetworkConfig marker: %w", err)
+	}
+	return nil
+}
+
+// loadUnconfirmed reads the unconfirmed config persisted by a previous run of
+// the agent. That config is reverted and invalidated if it is still desired.
+func (r *NodeNetworkConfigReconciler) loadUnconfirmed() error {
+	data, err := os.ReadFile(r.unconfirmedPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading unconfirmed NodeNetworkConfig marker: %w", err)
+	}
+
+	unconfirmed := &ApplyResult{}
+	if err := json.Unmarshal(data, unconfirmed); err != nil {
+		return fmt.Errorf("error unmarshalling unconfirmed NodeNetworkConfig marker: %w", err)
+	}
+	r.unconfirmed = unconfirmed
+	r.restoreUnconfirmed = true
+	return nil
+}
+
+// recordApply records the result of applying a config for the apply API.
+func (r *NodeNetworkConfigReconciler) recordApply(revision string, started time.Time, duration time.Duration, err error) {
+	result := &ApplyResult{
+		Revision: revision,
+		Started:  metav1.NewTime(started),
+		Duration: metav1.Duration{Duration: duration},
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastApply = result
+	if err != nil {
+		r.lastError = result
+	}
+}
+
+// setApplyResults copies the results of the last ApplyConfig call to the
+// status of cfg and sets a condition per reported stage. Conditions of stages
+// that were not reported are removed, other conditions are kept.
+func (r *NodeNetworkConfigReconciler) setApplyResults(cfg *v1alpha1.NodeNetworkConfig) {
+	if r.applyReporter == nil {
+		return
+	}
+
+	results := &v1alpha1.ApplyResults{}
+	if reported := r.applyReporter.ApplyResults(); reported != nil {
+		results = reported.DeepCopy()
+	}
+	cfg.Status.ApplyResults = *results
+
+	reported := make(map[string]bool, len(results.Stages))
+	for _, stage := range results.Stages {
+		condition := metav1.Condition{
+			Type:               string(stage.Stage),
+			Status:             metav1.ConditionTrue,
+			Reason:             v1alpha1.ReasonStageSucceeded,
+			Message:            fmt.Sprintf("Applied in %s", stage.Duration.Round(time.Millisecond)),
+			ObservedGeneration: cfg.Generation,
+		}
+		if stage.Error != "" {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = v1alpha1.ReasonStageFailed
+			condition.Message = stage.Error
+		}
+		meta.SetStatusCondition(&cfg.Status.Conditions, condition)
+		reported[condition.Type] = true
+	}
+	for _, condition := range slices.Clone(cfg.Status.Conditions) {
+		isStage := condition.Reason == v1alpha1.ReasonStageSucceeded || condition.Reason == v1alpha1.ReasonStageFailed
+		if isStage && !reported[condition.Type] {
+			meta.RemoveStatusCondition(&cfg.Status.Conditions, condition.Type)
+		}
+	}
+}
+
+// monitorHealth re-evaluates the node's health during the health monitor
+// window after the config was provisioned. The results are only published as
+// Node conditions, the operator decides whether the revision is rolled back.
+func (r *NodeNetworkConfigReconciler) monitorHealth(ctx context.Context, cfg *v1alpha1.NodeNetworkConfig) ctrl.Result {
+	if r.healthMonitorWindow <= 0 || cfg.Status.LastUpdate.IsZero() || time.Since(cfg.Status.LastUpdate.Time) > r.healthMonitorWindow {
+		return ctrl.Result{}
+	}
+
+	if _, err := r.checkHealth(ctx); err != nil {
+		r.logger.Error(err, "post-apply healthcheck failed")
+	}
+	r.checkBGPSessions(ctx)
+
+	return ctrl.Result{RequeueAfter: HealthMonitorInterval}
+}
+
+func (r *NodeNetworkConfigReconciler) recordBGPBaseline(ctx context.Context) {
+	if r.bgpChecker == nil {
+		return
+	}
+
+	sessions, err := r.bgpChecker.BGPSessions(ctx)
+	if err != nil {
+		r.logger.Error(err, "failed to get BGP sessions, BGP sessions going down will not be detected")
+		r.bgpBaseline = nil
+		return
+	}
+	r.bgpBaseline = sessions
+}
+
+// checkBGPSessions publishes whether any BGP session that was established
+// before the config was applied is down now. Sessions that were removed by the
+// config or were not established before are not taken into account.
+func (r *NodeNetworkConfigReconciler) checkBGPSessions(ctx context.Context) {
+	if r.bgpChecker == nil {
+		return
+	}
+
+	status, reason, message := corev1.ConditionTrue, healthcheck.ReasonBGPSessionsEstablished, "All previously established BGP sessions are up"
+	sessions, err := r.bgpChecker.BGPSessions(ctx)
+	if err != nil {
+		status, reason, message = corev1.ConditionUnknown, healthcheck.ReasonBGPCheckFailed, err.Error()
+	} else if down := getDownBGPSessions(r.bgpBaseline, sessions); len(down) > 0 {
+		status, reason, message = corev1.ConditionFalse, healthcheck.ReasonBGPSessionsDown, "BGP sessions down: "+strings.Join(down, ", ")
+	}
+
+	if err := healthcheck.SetNodeCondition(ctx, r.client, healthcheck.BGPSessionsConditionType, status, reason, message); err != nil {
+		r.logger.Error(err, "failed to update BGP sessions condition")
+	}
+}
+
+func getDownBGPSessions(baseline, sessions map[string]bool) []string {
+	down := []string{}
+	for session, established := range sessions {
+		if !established && baseline[session] {
+			down = append(down, session)
+		}
+	}
+	slices.Sort(down)
+	return down
+}
+
 func (r *NodeNetworkConfigReconciler) doReconciliation(
 	ctx context.Context,
 	nodeCfg *v1alpha1.NodeNetworkConfig,
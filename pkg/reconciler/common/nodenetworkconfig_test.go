@@ -8,17 +8,21 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
 	"github.com/telekom/das-schiff-network-operator/pkg/healthcheck"
@@ -27,7 +31,10 @@ import (
 	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/operator"
 )
 
-const testNodeName = "test-node"
+const (
+	testNodeName        = "test-node"
+	healthMonitorWindow = 10 * time.Minute
+)
 
 var (
 	logger  logr.Logger
@@ -102,6 +109,18 @@ func newMockReconciler(
 		NodeNetworkConfigPath:     configPath,
 		restoreOnReconcileFailure: opts.RestoreOnReconcileFailure,
 		localASN:                  int64(opts.LocalASN),
+		healthMonitorWindow:       opts.HealthMonitorWindow,
+		bgpChecker:                opts.BGPChecker,
+		configRenderer:            opts.ConfigRenderer,
+		applyReporter:             opts.ApplyReporter,
+		confirmTimeout:            opts.ConfirmTimeout,
+		confirmInterval:           time.Millisecond,
+		driftDetector:             opts.DriftDetector,
+		driftCheckInterval:        opts.DriftCheckInterval,
+		reapplyOnDrift:            opts.ReapplyOnDrift,
+		restartDetector:           opts.RestartDetector,
+		restartCheckInterval:      opts.RestartCheckInterval,
+		eventRecorder:             opts.EventRecorder,
 	}
 
 	return &mockReconciler{
@@ -130,6 +149,47 @@ func (m *mockReconciler) setupHealthyHealthCheckWithTaintRemoval() {
 	m.mockHealthChecker.EXPECT().RemoveTaints(gomock.Any()).Return(nil)
 }
 
+// fakeBGPChecker returns the configured BGP sessions.
+type fakeBGPChecker struct {
+	sessions map[string]bool
+	err      error
+}
+
+func (f *fakeBGPChecker) BGPSessions(_ context.Context) (map[string]bool, error) {
+	return f.sessions, f.err
+}
+
+// fakeApplyReporter returns the configured apply results.
+type fakeApplyReporter struct {
+	results *v1alpha1.ApplyResults
+}
+
+func (f *fakeApplyReporter) ApplyResults() *v1alpha1.ApplyResults {
+	return f.results
+}
+
+// fakeDriftDetector returns the configured drift and counts its calls.
+type fakeDriftDetector struct {
+	drift []string
+	err   error
+	calls int
+}
+
+func (f *fakeDriftDetector) DetectDrift(_ context.Context) ([]string, error) {
+	f.calls++
+	return f.drift, f.err
+}
+
+type fakeRestartDetector struct {
+	generation string
+	configured bool
+	err        error
+}
+
+func (f *fakeRestartDetector) CRAGeneration(_ context.Context) (string, bool, error) {
+	return f.generation, f.configured, f.err
+}
+
 var _ = Describe("NodeNetworkConfigReconciler", func() {
 	var (
 		mockCtrl   *gomock.Controller
@@ -298,6 +358,26 @@ var _ = Describe("NodeNetworkConfigReconciler", func() {
 			Expect(fetched.Status.ASNumber).To(Equal(int64(64497)))
 		})
 
+		It("does not rewrite the stored config for the same revision (fast path)", func() {
+			cfg := createTestNodeNetworkConfig("1")
+			cfg.Status.ConfigStatus = operator.StatusProvisioned
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(cfg).
+				WithStatusSubresource(cfg).
+				Build()
+
+			r := newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{})
+			r.NodeNetworkConfig = createTestNodeNetworkConfig("1")
+			r.mockHealthChecker.EXPECT().TaintsRemoved().Return(true)
+
+			_, err := r.Reconcile(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.NodeNetworkConfig.Status.ConfigStatus).To(Equal(operator.StatusProvisioned))
+			_, err = os.Stat(configPath)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
 		It("clears a stale ASN when the agent has none configured (fail closed)", func() {
 			cfg := createTestNodeNetworkConfig("1")
 			cfg.Status.ConfigStatus = operator.StatusProvisioned
@@ -737,6 +817,408 @@ var _ = Describe("NodeNetworkConfigReconciler", func() {
 		})
 	})
 
+	Context("confirmed apply", func() {
+		It("should provision the config once the API server is reachable again", func() {
+			cfg := createTestNodeNetworkConfig("2")
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(cfg).
+				WithStatusSubresource(cfg).
+				Build()
+
+			r := newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{ConfirmTimeout: time.Second})
+			r.NodeNetworkConfig = createTestNodeNetworkConfig("1")
+
+			r.mockApplier.EXPECT().ApplyConfig(gomock.Any(), cfg).Return(nil)
+			gomock.InOrder(
+				r.mockHealthChecker.EXPECT().CheckReachability().Return(nil),
+				r.mockHealthChecker.EXPECT().CheckAPIServer(gomock.Any()).Return(errors.New("api server unreachable")),
+				r.mockHealthChecker.EXPECT().CheckReachability().Return(nil),
+				r.mockHealthChecker.EXPECT().CheckAPIServer(gomock.Any()).Return(nil),
+			)
+			r.mockHealthChecker.EXPECT().CheckInterfaces().Return(nil)
+			r.mockHealthChecker.EXPECT().CheckReachability().Return(nil)
+			r.mockHealthChecker.EXPECT().CheckAPIServer(gomock.Any()).Return(nil)
+			r.mockHealthChecker.EXPECT().UpdateReadinessCondition(gomock.Any(), corev1.ConditionTrue, healthcheck.ReasonHealthChecksPassed, gomock.Any()).Return(nil)
+			r.mockHealthChecker.EXPECT().TaintsRemoved().Return(true)
+
+			_, err := r.processConfig(context.Background(), cfg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.Status.ConfigStatus).To(Equal(operator.StatusProvisioned))
+			Expect(configPath + UnconfirmedFileSuffix).ToNot(BeAnExistingFile())
+		})
+
+		It("should revert an unconfirmed config and invalidate it once the API server is reachable", func() {
+			currentCfg := createTestNodeNetworkConfig("2")
+			storedCfg := createTestNodeNetworkConfig("1")
+			apiDown := true
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(currentCfg).
+				WithStatusSubresource(currentCfg).
+				WithInterceptorFuncs(interceptor.Funcs{
+					SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						if apiDown {
+							return errors.New("connection refused")
+						}
+						return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+					},
+				}).
+				Build()
+
+			r := newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{ConfirmTimeout: 20 * time.Millisecond})
+			r.NodeNetworkConfig = storedCfg
+
+			// the provisioning status is written before the config cuts the node off
+			apiDown = false
+			r.mockApplier.EXPECT().ApplyConfig(gomock.Any(), currentCfg).DoAndReturn(func(context.Context, *v1alpha1.NodeNetworkConfig) error {
+				apiDown = true
+				return nil
+			})
+			r.mockHealthChecker.EXPECT().CheckReachability().Return(nil).AnyTimes()
+			r.mockHealthChecker.EXPECT().CheckAPIServer(gomock.Any()).Return(errors.New("api server unreachable")).AnyTimes()
+			r.mockApplier.EXPECT().ApplyConfig(gomock.Any(), storedCfg).Return(nil)
+
+			_, err := r.processConfig(context.Background(), currentCfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("apply not confirmed"))
+			Expect(r.unconfirmed).ToNot(BeNil())
+			Expect(configPath + UnconfirmedFileSuffix).To(BeAnExistingFile())
+
+			// the reverted config is invalidated, not applied again
+			apiDown = false
+			_, err = r.Reconcile(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.unconfirmed).To(BeNil())
+
+			fetched := &v1alpha1.NodeNetworkConfig{}
+			Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(currentCfg), fetched)).To(Succeed())
+			Expect(fetched.Status.ConfigStatus).To(Equal(operator.StatusInvalid))
+			Expect(fetched.Status.ErrorMessage).To(ContainSubstring("apply not confirmed"))
+			Expect(configPath + UnconfirmedFileSuffix).ToNot(BeAnExistingFile())
+		})
+
+		It("should revert and invalidate a config left unconfirmed by a restart of the agent", func() {
+			currentCfg := createTestNodeNetworkConfig("2")
+			storedCfg := createTestNodeNetworkConfig("1")
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(currentCfg).
+				WithStatusSubresource(currentCfg).
+				Build()
+
+			// the agent stopped while the config awaited confirmation
+			r := newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{ConfirmTimeout: time.Second})
+			Expect(r.setUnconfirmed(&ApplyResult{Revision: "2", Error: errUnconfirmedRestart})).To(Succeed())
+
+			r = newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{ConfirmTimeout: time.Second})
+			r.NodeNetworkConfig = storedCfg
+			Expect(r.loadUnconfirmed()).To(Succeed())
+			Expect(r.unconfirmed).ToNot(BeNil())
+
+			// the stored config is restored and the unconfirmed one invalidated
+			r.mockApplier.EXPECT().ApplyConfig(gomock.Any(), storedCfg).Return(nil)
+			_, err := r.Reconcile(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.unconfirmed).To(BeNil())
+			Expect(configPath + UnconfirmedFileSuffix).ToNot(BeAnExistingFile())
+
+			fetched := &v1alpha1.NodeNetworkConfig{}
+			Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(currentCfg), fetched)).To(Succeed())
+			Expect(fetched.Status.ConfigStatus).To(Equal(operator.StatusInvalid))
+			Expect(fetched.Status.ErrorMessage).To(ContainSubstring(errUnconfirmedRestart))
+		})
+	})
+
+	Context("apply results", func() {
+		It("should publish the stages, failed sections and stage conditions on the status", func() {
+			currentCfg := createTestNodeNetworkConfig("2")
+			currentCfg.Status.Conditions = []metav1.Condition{{
+				Type: string(v1alpha1.ApplyStageVSRCommit), Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonStageSucceeded,
+			}}
+
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(currentCfg).
+				WithStatusSubresource(currentCfg).
+				Build()
+
+			reporter := &fakeApplyReporter{results: &v1alpha1.ApplyResults{
+				Stages: []v1alpha1.StageResult{
+					{Stage: v1alpha1.ApplyStageNetlinkL2, Duration: metav1.Duration{Duration: 10 * time.Millisecond}},
+					{Stage: v1alpha1.ApplyStageNetlinkL3, Duration: metav1.Duration{Duration: 20 * time.Millisecond}, Error: "error creating L3 (VRF: m2m)"},
+				},
+				FailedSections: []v1alpha1.SectionResult{
+					{Section: "fabricVRFs/m2m", Stage: v1alpha1.ApplyStageNetlinkL3, Error: "error creating L3 (VRF: m2m)"},
+				},
+			}}
+			r := newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{ApplyReporter: reporter})
+
+			r.mockApplier.EXPECT().
+				ApplyConfig(gomock.Any(), currentCfg).
+				Return(errors.New("error creating L3 (VRF: m2m)"))
+
+			_, err := r.processConfig(context.Background(), currentCfg)
+			Expect(err).To(HaveOccurred())
+
+			stored := &v1alpha1.NodeNetworkConfig{}
+			Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(currentCfg), stored)).To(Succeed())
+			Expect(stored.Status.ConfigStatus).To(Equal(operator.StatusInvalid))
+			Expect(stored.Status.Stages).To(Equal(reporter.results.Stages))
+			Expect(stored.Status.FailedSections).To(Equal(reporter.results.FailedSections))
+
+			Expect(stored.Status.Conditions).To(HaveLen(2))
+			l2 := meta.FindStatusCondition(stored.Status.Conditions, string(v1alpha1.ApplyStageNetlinkL2))
+			Expect(l2).ToNot(BeNil())
+			Expect(l2.Status).To(Equal(metav1.ConditionTrue))
+			Expect(l2.Message).To(Equal("Applied in 10ms"))
+			l3 := meta.FindStatusCondition(stored.Status.Conditions, string(v1alpha1.ApplyStageNetlinkL3))
+			Expect(l3).ToNot(BeNil())
+			Expect(l3.Status).To(Equal(metav1.ConditionFalse))
+			Expect(l3.Reason).To(Equal(v1alpha1.ReasonStageFailed))
+			Expect(l3.Message).To(Equal("error creating L3 (VRF: m2m)"))
+		})
+	})
+
+	Context("drift detection", func() {
+		newProvisionedClient := func(cfg *v1alpha1.NodeNetworkConfig) client.Client {
+			cfg.Status.ConfigStatus = operator.StatusProvisioned
+			return fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(cfg).
+				WithStatusSubresource(cfg).
+				Build()
+		}
+
+		It("should publish drift, re-apply the config and wait for the next check", func() {
+			cfg := createTestNodeNetworkConfig("1")
+			fakeClient = newProvisionedClient(cfg)
+			detector := &fakeDriftDetector{drift: []string{"layer2/100: missing"}}
+			r := newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{
+				DriftDetector:      detector,
+				DriftCheckInterval: time.Minute,
+				ReapplyOnDrift:     true,
+			})
+			r.NodeNetworkConfig = createTestNodeNetworkConfig("1")
+			r.mockHealthChecker.EXPECT().TaintsRemoved().Return(true).Times(2)
+			r.mockApplier.EXPECT().ApplyConfig(gomock.Any(), gomock.Any()).Return(nil)
+
+			result, err := r.Reconcile(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+			stored := &v1alpha1.NodeNetworkConfig{}
+			Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(cfg), stored)).To(Succeed())
+			condition := meta.FindStatusCondition(stored.Status.Conditions, v1alpha1.ConditionConfigDrift)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(v1alpha1.ReasonDriftDetected))
+			Expect(condition.Message).To(Equal("layer2/100: missing (config re-applied)"))
+
+			result, err = r.Reconcile(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(detector.calls).To(Equal(1))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", time.Minute))
+		})
+
+		It("should set the drift condition to Unknown if the node cannot be checked", func() {
+			cfg := createTestNodeNetworkConfig("1")
+			fakeClient = newProvisionedClient(cfg)
+			r := newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{
+				DriftDetector:      &fakeDriftDetector{err: errors.New("CRA unreachable")},
+				DriftCheckInterval: time.Minute,
+			})
+			r.NodeNetworkConfig = createTestNodeNetworkConfig("1")
+			r.mockHealthChecker.EXPECT().TaintsRemoved().Return(true)
+
+			_, err := r.Reconcile(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+
+			stored := &v1alpha1.NodeNetworkConfig{}
+			Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(cfg), stored)).To(Succeed())
+			condition := meta.FindStatusCondition(stored.Status.Conditions, v1alpha1.ConditionConfigDrift)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+			Expect(condition.Message).To(Equal("CRA unreachable"))
+		})
+
+		It("should keep the drift condition when publishing apply results", func() {
+			cfg := createTestNodeNetworkConfig("1")
+			cfg.Status.Conditions = []metav1.Condition{
+				{Type: v1alpha1.ConditionConfigDrift, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonNoDrift},
+				{Type: string(v1alpha1.ApplyStageVSRCommit), Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonStageSucceeded},
+			}
+			r := newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{ApplyReporter: &fakeApplyReporter{}})
+
+			r.setApplyResults(cfg)
+			Expect(cfg.Status.Conditions).To(HaveLen(1))
+			Expect(cfg.Status.Conditions[0].Type).To(Equal(v1alpha1.ConditionConfigDrift))
+		})
+	})
+
+	Context("CRA restart detection", func() {
+		var (
+			cfg      *v1alpha1.NodeNetworkConfig
+			detector *fakeRestartDetector
+			recorder *events.FakeRecorder
+			r        *mockReconciler
+		)
+
+		BeforeEach(func() {
+			cfg = createTestNodeNetworkConfig("1")
+			cfg.Status.ConfigStatus = operator.StatusProvisioned
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(cfg).
+				WithStatusSubresource(cfg).
+				Build()
+			detector = &fakeRestartDetector{generation: "boot-1", configured: true}
+			recorder = events.NewFakeRecorder(1)
+			r = newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{
+				RestartDetector:      detector,
+				RestartCheckInterval: time.Nanosecond,
+				EventRecorder:        recorder,
+			})
+			r.NodeNetworkConfig = createTestNodeNetworkConfig("1")
+			r.mockHealthChecker.EXPECT().TaintsRemoved().Return(true).AnyTimes()
+		})
+
+		It("should replay the config when the CRA generation changes", func() {
+			result, err := r.Reconcile(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Nanosecond))
+			Expect(recorder.Events).To(BeEmpty())
+
+			detector.generation = "boot-2"
+			r.mockApplier.EXPECT().ApplyConfig(gomock.Any(), gomock.Any()).Return(nil)
+			_, err = r.Reconcile(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorder.Events).To(Receive(HavePrefix("Normal " + EventReasonCRAReplayed)))
+
+			// the replayed instance is the new baseline
+			_, err = r.Reconcile(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should replay the config when the CRA was not configured since it started", func() {
+			detector.configured = false
+			r.mockApplier.EXPECT().ApplyConfig(gomock.Any(), gomock.Any()).Return(nil)
+
+			_, err := r.Reconcile(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorder.Events).To(Receive(HavePrefix("Normal " + EventReasonCRAReplayed)))
+		})
+
+		It("should retry a failed replay", func() {
+			r.craGeneration = "boot-0"
+			r.mockApplier.EXPECT().ApplyConfig(gomock.Any(), gomock.Any()).Return(errors.New("CRA not ready"))
+
+			_, err := r.Reconcile(context.Background())
+			Expect(err).To(HaveOccurred())
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning " + EventReasonCRAReplayFailed)))
+			Expect(r.craGeneration).To(Equal("boot-0"))
+			Expect(r.lastRestartCheck).To(BeZero())
+		})
+
+		It("should not replay if the CRA cannot be checked", func() {
+			detector.err = errors.New("CRA unreachable")
+
+			_, err := r.Reconcile(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+
+	Context("post-apply health monitoring", func() {
+		var nodeScheme *runtime.Scheme
+
+		BeforeEach(func() {
+			nodeScheme = runtime.NewScheme()
+			Expect(corev1.AddToScheme(nodeScheme)).To(Succeed())
+			Expect(v1alpha1.AddToScheme(nodeScheme)).To(Succeed())
+		})
+
+		It("should request monitoring after the config was provisioned", func() {
+			cfg := createTestNodeNetworkConfig("1")
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(cfg).
+				WithStatusSubresource(cfg).
+				Build()
+
+			r := newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{HealthMonitorWindow: healthMonitorWindow})
+			r.mockApplier.EXPECT().ApplyConfig(gomock.Any(), cfg).Return(nil)
+			r.setupHealthyHealthCheck()
+
+			result, err := r.processConfig(context.Background(), cfg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(HealthMonitorInterval))
+		})
+
+		It("should not monitor after the health monitor window", func() {
+			cfg := createTestNodeNetworkConfig("1")
+			cfg.Status.ConfigStatus = operator.StatusProvisioned
+			cfg.Status.LastUpdate = metav1.NewTime(time.Now().Add(-2 * healthMonitorWindow))
+
+			r := newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{HealthMonitorWindow: healthMonitorWindow})
+
+			Expect(r.monitorHealth(context.Background(), cfg).RequeueAfter).To(BeZero())
+		})
+
+		It("should publish BGP sessions that went down since the config was applied", func() {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: testNodeName}}
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(nodeScheme).
+				WithRuntimeObjects(node).
+				WithStatusSubresource(node).
+				Build()
+			checker := &fakeBGPChecker{sessions: map[string]bool{"default/10.0.0.1": true, "vrf1/10.0.1.1": true}}
+
+			r := newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{
+				HealthMonitorWindow: healthMonitorWindow,
+				BGPChecker:          checker,
+			})
+			r.recordBGPBaseline(context.Background())
+
+			// vrf1 peer went down, vrf2 peer was added by the config and is not yet established
+			checker.sessions = map[string]bool{"default/10.0.0.1": true, "vrf1/10.0.1.1": false, "vrf2/10.0.2.1": false}
+			r.setupHealthyHealthCheck()
+
+			cfg := createTestNodeNetworkConfig("1")
+			cfg.Status.ConfigStatus = operator.StatusProvisioned
+			cfg.Status.LastUpdate = metav1.Now()
+			Expect(r.monitorHealth(context.Background(), cfg).RequeueAfter).To(Equal(HealthMonitorInterval))
+
+			updated := &corev1.Node{}
+			Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(node), updated)).To(Succeed())
+			Expect(updated.Status.Conditions).To(HaveLen(1))
+			Expect(updated.Status.Conditions[0].Type).To(Equal(healthcheck.BGPSessionsConditionType))
+			Expect(updated.Status.Conditions[0].Status).To(Equal(corev1.ConditionFalse))
+			Expect(updated.Status.Conditions[0].Reason).To(Equal(healthcheck.ReasonBGPSessionsDown))
+			Expect(updated.Status.Conditions[0].Message).To(Equal("BGP sessions down: vrf1/10.0.1.1"))
+		})
+
+		It("should report the BGP sessions condition as unknown if the sessions cannot be retrieved", func() {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: testNodeName}}
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(nodeScheme).
+				WithRuntimeObjects(node).
+				WithStatusSubresource(node).
+				Build()
+
+			r := newMockReconciler(mockCtrl, fakeClient, configPath, ReconcilerOptions{
+				BGPChecker: &fakeBGPChecker{err: errors.New("cra unavailable")},
+			})
+			r.checkBGPSessions(context.Background())
+
+			updated := &corev1.Node{}
+			Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(node), updated)).To(Succeed())
+			Expect(updated.Status.Conditions).To(HaveLen(1))
+			Expect(updated.Status.Conditions[0].Status).To(Equal(corev1.ConditionUnknown))
+			Expect(updated.Status.Conditions[0].Reason).To(Equal(healthcheck.ReasonBGPCheckFailed))
+		})
+	})
+
 	Context("fetchNodeConfig", func() {
 		It("should fetch config from API server", func() {
 			cfg := createTestNodeNetworkConfig("1")
@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+const (
+	// DefaultRestartCheckInterval is the default interval the CRA is checked
+	// for restarts at. Every check requeues the NodeNetworkConfig.
+	DefaultRestartCheckInterval = time.Minute
+
+	// EventReasonCRAReplayed is the reason of the event emitted when the
+	// config was replayed after the CRA restarted.
+	EventReasonCRAReplayed = "CRAReplayed"
+	// EventReasonCRAReplayFailed is the reason of the event emitted when the
+	// config could not be replayed after the CRA restarted.
+	EventReasonCRAReplayFailed = "CRAReplayFailed"
+
+	replayResultSuccess = "success"
+	replayResultFailure = "failure"
+)
+
+// CRAReplays is a counter of the times the config was replayed because the
+// CRA restarted, by result.
+var CRAReplays = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "nwop",
+		Subsystem: "cra",
+		Name:      "replays_total",
+		Help:      "Number of times the config was replayed because the CRA restarted",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(CRAReplays)
+}
+
+//+kubebuilder:rbac:groups="";events.k8s.io,resources=events,verbs=create;patch
+
+// RestartDetector is an optional interface for agents whose CRA loses the
+// applied config when it restarts.
+type RestartDetector interface {
+	// CRAGeneration returns an identifier of the running CRA instance, which
+	// changes when the CRA restarts, and whether the instance has been
+	// configured since it started.
+	CRAGeneration(ctx context.Context) (generation string, configured bool, err error)
+}
+
+// checkRestart checks the CRA for a restart at most every
+// restartCheckInterval and replays cfg, the provisioned config, if it
+// restarted or was not configured since it started. The first generation seen
+// after cfg was applied is the baseline.
+func (r *NodeNetworkConfigReconciler) checkRestart(ctx context.Context, cfg *v1alpha1.NodeNetworkConfig) error {
+	if r.restartDetector == nil || r.restartCheckInterval <= 0 {
+		return nil
+	}
+	if time.Since(r.lastRestartCheck) < r.restartCheckInterval {
+		return nil
+	}
+	r.lastRestartCheck = time.Now()
+
+	generation, configured, err := r.restartDetector.CRAGeneration(ctx)
+	if err != nil {
+		r.logger.Error(err, "failed to check the CRA for a restart")
+		return nil
+	}
+	if configured && (r.craGeneration == "" || r.craGeneration == generation) {
+		r.craGeneration = generation
+		return nil
+	}
+
+	r.logger.Info("CRA restarted, replaying NodeNetworkConfig", "name", cfg.Name, "revision", cfg.Spec.Revision,
+		"generation", generation, "previousGeneration", r.craGeneration)
+
+	started := time.Now()
+	err = r.doReconciliation(ctx, cfg)
+	r.recordApply(cfg.Spec.Revision, started, time.Since(started), err)
+	r.setApplyResults(cfg)
+	if r.applyReporter != nil {
+		if updateErr := r.client.Status().Update(ctx, cfg); updateErr != nil {
+			r.logger.Error(updateErr, "failed to update NodeNetworkConfig apply results")
+		}
+	}
+
+	if err != nil {
+		CRAReplays.WithLabelValues(replayResultFailure).Inc()
+		r.recordEvent(cfg, corev1.EventTypeWarning, EventReasonCRAReplayFailed,
+			fmt.Sprintf("Replaying revision %s after the CRA restarted failed: %s", cfg.Spec.Revision, err))
+		// retry with the controller's backoff
+		r.lastRestartCheck = time.Time{}
+		return fmt.Errorf("error replaying NodeNetworkConfig after CRA restart: %w", err)
+	}
+
+	CRAReplays.WithLabelValues(replayResultSuccess).Inc()
+	r.recordEvent(cfg, corev1.EventTypeNormal, EventReasonCRAReplayed,
+		fmt.Sprintf("Replayed revision %s after the CRA restarted", cfg.Spec.Revision))
+	r.craGeneration = generation
+	// check the replayed config for drift on the next reconciliation
+	r.lastDriftCheck = time.Time{}
+
+	return nil
+}
+
+// resetCRAGeneration makes the next restart check take the CRA generation as
+// the baseline, after a config was applied.
+func (r *NodeNetworkConfigReconciler) resetCRAGeneration() {
+	r.craGeneration = ""
+	r.lastRestartCheck = time.Time{}
+}
+
+// restartRequeue returns result requeued for the next restart check.
+func (r *NodeNetworkConfigReconciler) restartRequeue(result ctrl.Result) ctrl.Result {
+	if r.restartDetector == nil || r.restartCheckInterval <= 0 {
+		return result
+	}
+	return requeueWithin(result, r.restartCheckInterval)
+}
+
+// recordEvent emits an event for cfg if an event recorder is configured.
+func (r *NodeNetworkConfigReconciler) recordEvent(cfg *v1alpha1.NodeNetworkConfig, eventType, reason, note string) {
+	if r.eventRecorder == nil {
+		return
+	}
+	r.eventRecorder.Eventf(cfg, nil, eventType, reason, "Replay", "%s", note)
+}
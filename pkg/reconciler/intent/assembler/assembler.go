@@ -100,6 +100,10 @@ func Assemble(contributions []*builder.NodeContribution) (*AssembleResult, error
 					existing.EVPNExportFilter.Items = append(existing.EVPNExportFilter.Items, v.EVPNExportFilter.Items...)
 				}
 			}
+			// The EVPN import filter comes from the VRF, so all contributions carry the same one.
+			if existing.EVPNImportFilter == nil {
+				existing.EVPNImportFilter = v.EVPNImportFilter
+			}
 			// Merge EVPN route targets (deduplicated).
 			existing.EVPNExportRouteTargets = mergeStringSlice(existing.EVPNExportRouteTargets, v.EVPNExportRouteTargets)
 			existing.EVPNImportRouteTargets = mergeStringSlice(existing.EVPNImportRouteTargets, v.EVPNImportRouteTargets)
@@ -141,9 +145,9 @@ func Assemble(contributions []*builder.NodeContribution) (*AssembleResult, error
 			}
 		}
 
-		// Merge origins.
+		// Merge origins: a section built from several CRDs lists all of them.
 		for k, v := range c.Origins {
-			origins[k] = v
+			origins[k] = builder.MergeOrigins(origins[k], v)
 		}
 
 		// Merge netplan node IPs.
@@ -145,6 +145,9 @@ func TestAssemble_MergeFabricVRFs(t *testing.T) {
 				{Prefix: "172.16.0.0/12"},
 			},
 		},
+		EVPNImportFilter: &networkv1alpha1.Filter{
+			DefaultAction: networkv1alpha1.Action{Type: networkv1alpha1.Reject},
+		},
 	}
 
 	result, err := Assemble([]*builder.NodeContribution{c1, c2})
@@ -162,6 +165,29 @@ func TestAssemble_MergeFabricVRFs(t *testing.T) {
 	if len(fvrf.StaticRoutes) != 2 {
 		t.Errorf("expected 2 merged StaticRoutes, got %d", len(fvrf.StaticRoutes))
 	}
+	if fvrf.EVPNImportFilter == nil {
+		t.Error("expected EVPNImportFilter from second contribution")
+	}
+}
+
+func TestAssemble_MergeOrigins(t *testing.T) {
+	c1 := builder.NewNodeContribution()
+	c1.AddOrigin("fabricVRFs/vrf-a", "Inbound/ib")
+	c1.AddOrigin("layer2s/100", "Layer2Attachment/l2a")
+	c2 := builder.NewNodeContribution()
+	c2.AddOrigin("fabricVRFs/vrf-a", "Outbound/ob")
+
+	result, err := Assemble([]*builder.NodeContribution{c1, c2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := result.Origins["fabricVRFs/vrf-a"]; got != "Inbound/ib, Outbound/ob" {
+		t.Errorf("expected merged origin 'Inbound/ib, Outbound/ob', got %q", got)
+	}
+	if got := result.Origins["layer2s/100"]; got != "Layer2Attachment/l2a" {
+		t.Errorf("expected origin 'Layer2Attachment/l2a', got %q", got)
+	}
 }
 
 func TestAssemble_MergeClusterVRF(t *testing.T) {
@@ -194,7 +194,8 @@ func TestBGPPeeringBuilder_ListenRange(t *testing.T) { //nolint:funlen // table-
 // TestBGPPeeringBuilder_ListenRangeExportCommunities verifies that when
 // spec.export.communities is set on a listenRange BGPPeering, the resulting
 // EVPN export FilterItems carry an additive ModifyRoute with those communities;
-// and that when export is unset, no ModifyRoute is present.
+// that an export AS path match and prepend end up on every item; and that when
+// export is unset, no ModifyRoute is present.
 func TestBGPPeeringBuilder_ListenRangeExportCommunities(t *testing.T) { //nolint:funlen // table-driven test
 	makeData := func(export *nc.BGPPeeringExport) *resolver.ResolvedData {
 		return &resolver.ResolvedData{
@@ -282,6 +283,32 @@ func TestBGPPeeringBuilder_ListenRangeExportCommunities(t *testing.T) { //nolint
 		}
 	}
 
+	// With AS path match and prepend: every item matches the AS path and
+	// prepends, without communities.
+	result, err = b.Build(context.Background(), makeData(&nc.BGPPeeringExport{
+		ASPathMatch:   "^65100_",
+		ASPathPrepend: &nc.ASPathPrepend{OwnASCount: ptr(2)},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fvrf = result["node-1"].FabricVRFs["prod"]
+	if fvrf.EVPNExportFilter == nil || len(fvrf.EVPNExportFilter.Items) != 2 {
+		t.Fatalf("expected 2 EVPN export items, got %v", fvrf.EVPNExportFilter)
+	}
+	for i, item := range fvrf.EVPNExportFilter.Items {
+		if item.Matcher.ASPath == nil || item.Matcher.ASPath.Regex != "^65100_" {
+			t.Errorf("item %d: expected AS path matcher ^65100_, got %v", i, item.Matcher.ASPath)
+		}
+		if item.Action.ModifyRoute == nil || item.Action.ModifyRoute.ASPathPrepend == nil ||
+			item.Action.ModifyRoute.ASPathPrepend.OwnASCount == nil || *item.Action.ModifyRoute.ASPathPrepend.OwnASCount != 2 {
+			t.Fatalf("item %d: expected own AS prepended twice, got %v", i, item.Action.ModifyRoute)
+		}
+		if len(item.Action.ModifyRoute.AddCommunities) != 0 || item.Action.ModifyRoute.AdditiveCommunities != nil {
+			t.Errorf("item %d: expected no communities, got %v", i, item.Action.ModifyRoute.AddCommunities)
+		}
+	}
+
 	// Without export: EVPN export items are plain Accept, no ModifyRoute.
 	result, err = b.Build(context.Background(), makeData(nil))
 	if err != nil {
@@ -804,6 +831,33 @@ func TestCidrFilterItems_WithHostCommunities(t *testing.T) {
 	}
 }
 
+func TestCidrFilterItems_CommunityTypes(t *testing.T) {
+	ap := &nc.AnnouncementPolicy{
+		Spec: nc.AnnouncementPolicySpec{
+			HostRoutes: &nc.RouteAnnouncementConfig{
+				Communities: []string{"65000:100", "4200000000:1:2", "rt 65000:200"},
+			},
+		},
+	}
+	items := cidrFilterItems("10.1.0.0/24", 32, 31, ap)
+	host := items[0].Action.ModifyRoute
+	if host == nil {
+		t.Fatal("host: expected ModifyRoute, got nil")
+	}
+	if len(host.AddCommunities) != 1 || host.AddCommunities[0] != "65000:100" {
+		t.Errorf("host: expected standard community 65000:100, got %v", host.AddCommunities)
+	}
+	if len(host.AddLargeCommunities) != 1 || host.AddLargeCommunities[0] != "4200000000:1:2" {
+		t.Errorf("host: expected large community 4200000000:1:2, got %v", host.AddLargeCommunities)
+	}
+	if len(host.AddExtCommunities) != 1 || host.AddExtCommunities[0] != "rt 65000:200" {
+		t.Errorf("host: expected extended community rt 65000:200, got %v", host.AddExtCommunities)
+	}
+	if host.AdditiveCommunities == nil || !*host.AdditiveCommunities {
+		t.Error("host: expected additive communities")
+	}
+}
+
 func TestCidrFilterItems_AggregateDisabled(t *testing.T) {
 	ap := &nc.AnnouncementPolicy{
 		Spec: nc.AnnouncementPolicySpec{
@@ -857,6 +911,64 @@ func TestCidrFilterItems_AggregateCommunities(t *testing.T) {
 	}
 }
 
+func TestCidrFilterItems_ASPathPrepend(t *testing.T) {
+	ap := &nc.AnnouncementPolicy{
+		Spec: nc.AnnouncementPolicySpec{
+			HostRoutes: &nc.RouteAnnouncementConfig{
+				ASPathPrepend: &nc.ASPathPrepend{ASNs: []uint32{65001, 65001}},
+			},
+		},
+	}
+	items := cidrFilterItems("10.1.0.0/24", 32, 31, ap)
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+
+	// Host route prepends without communities.
+	host := items[0].Action.ModifyRoute
+	if host == nil || host.ASPathPrepend == nil || len(host.ASPathPrepend.ASNs) != 2 {
+		t.Fatalf("host: expected AS path prepend, got %v", host)
+	}
+	if host.AddCommunities != nil || host.AdditiveCommunities != nil {
+		t.Errorf("host: expected no communities, got %v", host.AddCommunities)
+	}
+
+	// Aggregate is unchanged.
+	if items[1].Action.ModifyRoute != nil {
+		t.Errorf("agg: expected nil ModifyRoute, got %v", items[1].Action.ModifyRoute)
+	}
+}
+
+func TestCidrFilterItems_Preference(t *testing.T) {
+	localPref, med := uint32(200), uint32(10)
+	ap := &nc.AnnouncementPolicy{
+		Spec: nc.AnnouncementPolicySpec{
+			HostRoutes: &nc.RouteAnnouncementConfig{LocalPreference: &localPref},
+			Aggregate:  &nc.AggregateConfig{MED: &med},
+		},
+	}
+	items := cidrFilterItems("10.1.0.0/24", 32, 31, ap)
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+
+	host := items[0].Action.ModifyRoute
+	if host == nil || host.SetLocalPreference == nil || *host.SetLocalPreference != 200 {
+		t.Fatalf("host: expected local preference 200, got %v", host)
+	}
+	if host.SetMED != nil || host.AddCommunities != nil {
+		t.Errorf("host: expected only the local preference, got %v", host)
+	}
+
+	agg := items[1].Action.ModifyRoute
+	if agg == nil || agg.SetMED == nil || *agg.SetMED != 10 {
+		t.Fatalf("agg: expected MED 10, got %v", agg)
+	}
+	if agg.SetLocalPreference != nil {
+		t.Errorf("agg: expected no local preference, got %d", *agg.SetLocalPreference)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // addressFilterItems tests.
 // ---------------------------------------------------------------------------
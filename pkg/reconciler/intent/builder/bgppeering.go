@@ -27,6 +27,7 @@ import (
 	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
 	nc "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
 	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent/resolver"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/nncdiff"
 )
 
 // BGPPeeringBuilder transforms BGPPeering intent CRDs into VRF BGPPeer configurations.
@@ -145,6 +146,12 @@ func (b *BGPPeeringBuilder) buildListenRange(bp *nc.BGPPeering, data *resolver.R
 			}
 			fvrf.EVPNExportFilter.Items = append(fvrf.EVPNExportFilter.Items, evpnExportItems...)
 			contrib.FabricVRFs[vrfName] = fvrf
+
+			section := "fabricVRFs/" + vrfName
+			contrib.AddOrigin(section, originOf("BGPPeering", bp.Name))
+			for pi := range peers {
+				contrib.AddOrigin(nncdiff.PeerSection(section, &peers[pi]), originOf("BGPPeering", bp.Name))
+			}
 		}
 	}
 
@@ -172,6 +179,8 @@ func (b *BGPPeeringBuilder) buildLoopbackPeer(bp *nc.BGPPeering, data *resolver.
 		}
 
 		contrib.ClusterVRF.BGPPeers = append(contrib.ClusterVRF.BGPPeers, peer)
+		contrib.AddOrigin("clusterVRF", originOf("BGPPeering", bp.Name))
+		contrib.AddOrigin(nncdiff.PeerSection("clusterVRF", &peer), originOf("BGPPeering", bp.Name))
 	}
 }
 
@@ -269,24 +278,32 @@ func (*BGPPeeringBuilder) buildPeerAF(bp *nc.BGPPeering, prefixes []string, isIP
 
 // evpnExportItems builds EVPN export filter items from the allow-list prefixes
 // (networkRefs CIDRs) so those prefixes are distributed across the fabric. When
-// export carries communities, they are attached additively to each generated
-// item; otherwise items are plain Accept (community-free).
+// export carries communities, an AS path prepend or a preference, they are
+// applied to each generated item; otherwise items are plain Accept
+// (community-free). An export AS path match restricts each item to the
+// matching routes.
 func (*BGPPeeringBuilder) evpnExportItems(ipv4, ipv6 []string, export *nc.BGPPeeringExport) []networkv1alpha1.FilterItem {
-	var communities []string
+	var (
+		attrs  routeAttributes
+		asPath *networkv1alpha1.ASPathMatcher
+	)
 	if export != nil {
-		communities = export.Communities
+		attrs = routeAttributes{
+			communities:     export.Communities,
+			prepend:         export.ASPathPrepend,
+			localPreference: export.LocalPreference,
+			med:             export.MED,
+		}
+		if export.ASPathMatch != "" {
+			asPath = &networkv1alpha1.ASPathMatcher{Regex: export.ASPathMatch}
+		}
 	}
 
 	newAction := func() networkv1alpha1.Action {
-		action := networkv1alpha1.Action{Type: networkv1alpha1.Accept}
-		if len(communities) > 0 {
-			additive := true
-			action.ModifyRoute = &networkv1alpha1.ModifyRouteAction{
-				AddCommunities:      communities,
-				AdditiveCommunities: &additive,
-			}
+		return networkv1alpha1.Action{
+			Type:        networkv1alpha1.Accept,
+			ModifyRoute: attrs.modifyRouteAction(),
 		}
-		return action
 	}
 
 	items := make([]networkv1alpha1.FilterItem, 0, len(ipv4)+len(ipv6))
@@ -295,6 +312,7 @@ func (*BGPPeeringBuilder) evpnExportItems(ipv4, ipv6 []string, export *nc.BGPPee
 		items = append(items, networkv1alpha1.FilterItem{
 			Matcher: networkv1alpha1.Matcher{
 				Prefix: &networkv1alpha1.PrefixMatcher{Prefix: pfx, Le: &le},
+				ASPath: asPath,
 			},
 			Action: newAction(),
 		})
@@ -304,6 +322,7 @@ func (*BGPPeeringBuilder) evpnExportItems(ipv4, ipv6 []string, export *nc.BGPPee
 		items = append(items, networkv1alpha1.FilterItem{
 			Matcher: networkv1alpha1.Matcher{
 				Prefix: &networkv1alpha1.PrefixMatcher{Prefix: pfx, Le: &le},
+				ASPath: asPath,
 			},
 			Action: newAction(),
 		})
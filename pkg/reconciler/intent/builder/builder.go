@@ -18,6 +18,9 @@ package builder
 
 import (
 	"context"
+	"maps"
+	"slices"
+	"strings"
 
 	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
 	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent/resolver"
@@ -89,6 +92,33 @@ func (nc *NodeContribution) SetOrigin(sectionKey, source string) {
 	nc.Origins[sectionKey] = source
 }
 
+// AddOrigin records source as one of the CRDs contributing to an NNC section
+// key. Sections shared by several CRDs (e.g. a FabricVRF used by an Inbound
+// and an Outbound) list all of them.
+func (nc *NodeContribution) AddOrigin(sectionKey, source string) {
+	if nc.Origins == nil {
+		nc.Origins = make(map[string]string)
+	}
+	nc.Origins[sectionKey] = MergeOrigins(nc.Origins[sectionKey], source)
+}
+
+// MergeOrigins merges two comma-separated lists of source CRDs into a single
+// sorted list without duplicates.
+func MergeOrigins(a, b string) string {
+	sources := make(map[string]bool)
+	for _, s := range strings.Split(a+","+b, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			sources[s] = true
+		}
+	}
+	return strings.Join(slices.Sorted(maps.Keys(sources)), ", ")
+}
+
+// originOf returns the origin reference ("Kind/name") of an intent CRD.
+func originOf(kind, name string) string {
+	return kind + "/" + name
+}
+
 // ensureContrib returns the existing contribution for a node or creates a new one.
 func ensureContrib(result map[string]*NodeContribution, nodeName string) *NodeContribution {
 	contrib, ok := result[nodeName]
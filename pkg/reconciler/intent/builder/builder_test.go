@@ -51,3 +51,23 @@ func TestSetOrigin_Overwrite(t *testing.T) {
 		t.Fatalf("expected overwritten origin 'new', got %q", got)
 	}
 }
+
+func TestAddOrigin_Merges(t *testing.T) {
+	nc := &NodeContribution{} // Origins is nil
+	nc.AddOrigin("fabricVRFs/m2m", "Outbound/ob")
+	nc.AddOrigin("fabricVRFs/m2m", "Inbound/ib")
+	nc.AddOrigin("fabricVRFs/m2m", "Outbound/ob")
+
+	if got := nc.Origins["fabricVRFs/m2m"]; got != "Inbound/ib, Outbound/ob" {
+		t.Fatalf("expected merged origin 'Inbound/ib, Outbound/ob', got %q", got)
+	}
+}
+
+func TestMergeOrigins(t *testing.T) {
+	if got := MergeOrigins("", "Inbound/ib"); got != "Inbound/ib" {
+		t.Fatalf("expected 'Inbound/ib', got %q", got)
+	}
+	if got := MergeOrigins("Outbound/ob, Inbound/ib", "BGPPeering/bp, Inbound/ib"); got != "BGPPeering/bp, Inbound/ib, Outbound/ob" {
+		t.Fatalf("expected sorted, deduplicated origins, got %q", got)
+	}
+}
@@ -110,6 +110,7 @@ func (*CollectorBuilder) Build(ctx context.Context, data *resolver.ResolvedData)
 			}
 
 			contrib.FabricVRFs[backboneVRF] = fvrf
+			contrib.AddOrigin("fabricVRFs/"+backboneVRF, originOf("Collector", col.Name))
 		}
 	}
 
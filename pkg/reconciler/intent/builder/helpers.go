@@ -29,6 +29,7 @@ import (
 
 	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
 	nc "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
+	"github.com/telekom/das-schiff-network-operator/pkg/bgpcommunity"
 	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent/resolver"
 )
 
@@ -75,7 +76,7 @@ const (
 )
 
 // buildFabricVRF creates a base FabricVRF with EVPN export filter and cluster VRFImport,
-// both defaulting to Reject (deny-by-default).
+// both defaulting to Reject (deny-by-default), and the VRF's EVPN import filter if set.
 func buildFabricVRF(vrfSpec *nc.VRFSpec) networkv1alpha1.FabricVRF {
 	fvrf := networkv1alpha1.FabricVRF{
 		EVPNExportFilter: &networkv1alpha1.Filter{
@@ -102,9 +103,37 @@ func buildFabricVRF(vrfSpec *nc.VRFSpec) networkv1alpha1.FabricVRF {
 		fvrf.EVPNExportRouteTargets = []string{*vrfSpec.RouteTarget}
 	}
 
+	if vrfSpec.EVPNImport != nil {
+		fvrf.EVPNImportFilter = evpnImportFilter(vrfSpec.EVPNImport)
+	}
+
 	return fvrf
 }
 
+// evpnImportFilter creates the EVPN import filter accepting the routes within
+// the imported prefixes, up to their max length, and rejecting all others.
+func evpnImportFilter(evpnImport *nc.EVPNImport) *networkv1alpha1.Filter {
+	filter := &networkv1alpha1.Filter{
+		DefaultAction: networkv1alpha1.Action{Type: networkv1alpha1.Reject},
+	}
+	for _, p := range evpnImport.Prefixes {
+		le := ipv4MaxPrefixLen
+		if strings.Contains(p.Prefix, ":") {
+			le = ipv6MaxPrefixLen
+		}
+		if p.MaxLength != nil {
+			le = int(*p.MaxLength)
+		}
+		filter.Items = append(filter.Items, networkv1alpha1.FilterItem{
+			Action: networkv1alpha1.Action{Type: networkv1alpha1.Accept},
+			Matcher: networkv1alpha1.Matcher{
+				Prefix: &networkv1alpha1.PrefixMatcher{Prefix: p.Prefix, Le: &le},
+			},
+		})
+	}
+	return filter
+}
+
 // findMatchingAP resolves the single AnnouncementPolicy that applies to a usage CRD.
 // It matches by VRF backbone name AND the AP's label selector against the usage CRD's labels.
 // Returns nil,nil if no AP matches. Returns an error if more than one matches.
@@ -205,13 +234,9 @@ func cidrFilterItems(cidr string, maxLen, hostLen int, ap *nc.AnnouncementPolicy
 	var items []networkv1alpha1.FilterItem
 
 	// 1. Host route item (most specific — matched first in FRR).
-	hostAction := networkv1alpha1.Action{Type: networkv1alpha1.Accept}
-	if ap.Spec.HostRoutes != nil && len(ap.Spec.HostRoutes.Communities) > 0 {
-		additive := true
-		hostAction.ModifyRoute = &networkv1alpha1.ModifyRouteAction{
-			AddCommunities:      ap.Spec.HostRoutes.Communities,
-			AdditiveCommunities: &additive,
-		}
+	hostAction := networkv1alpha1.Action{
+		Type:        networkv1alpha1.Accept,
+		ModifyRoute: hostRouteAttributes(ap).modifyRouteAction(),
 	}
 	ge := maxLen
 	le := maxLen
@@ -234,13 +259,9 @@ func cidrFilterItems(cidr string, maxLen, hostLen int, ap *nc.AnnouncementPolicy
 			Action: networkv1alpha1.Action{Type: networkv1alpha1.Reject},
 		})
 	} else {
-		aggAction := networkv1alpha1.Action{Type: networkv1alpha1.Accept}
-		if ap.Spec.Aggregate != nil && len(ap.Spec.Aggregate.Communities) > 0 {
-			additive := true
-			aggAction.ModifyRoute = &networkv1alpha1.ModifyRouteAction{
-				AddCommunities:      ap.Spec.Aggregate.Communities,
-				AdditiveCommunities: &additive,
-			}
+		aggAction := networkv1alpha1.Action{
+			Type:        networkv1alpha1.Accept,
+			ModifyRoute: aggregateRouteAttributes(ap).modifyRouteAction(),
 		}
 		items = append(items, networkv1alpha1.FilterItem{
 			Matcher: networkv1alpha1.Matcher{
@@ -253,8 +274,71 @@ func cidrFilterItems(cidr string, maxLen, hostLen int, ap *nc.AnnouncementPolicy
 	return items
 }
 
+// routeAttributes are the BGP attributes set on exported routes.
+type routeAttributes struct {
+	communities     []string
+	prepend         *nc.ASPathPrepend
+	localPreference *uint32
+	med             *uint32
+}
+
+// hostRouteAttributes returns the attributes the AP sets on host routes.
+func hostRouteAttributes(ap *nc.AnnouncementPolicy) routeAttributes {
+	if ap == nil || ap.Spec.HostRoutes == nil {
+		return routeAttributes{}
+	}
+	hr := ap.Spec.HostRoutes
+	return routeAttributes{
+		communities:     hr.Communities,
+		prepend:         hr.ASPathPrepend,
+		localPreference: hr.LocalPreference,
+		med:             hr.MED,
+	}
+}
+
+// aggregateRouteAttributes returns the attributes the AP sets on aggregate
+// routes.
+func aggregateRouteAttributes(ap *nc.AnnouncementPolicy) routeAttributes {
+	if ap == nil || ap.Spec.Aggregate == nil {
+		return routeAttributes{}
+	}
+	agg := ap.Spec.Aggregate
+	return routeAttributes{
+		communities:     agg.Communities,
+		prepend:         agg.ASPathPrepend,
+		localPreference: agg.LocalPreference,
+		med:             agg.MED,
+	}
+}
+
+// modifyRouteAction returns the action attaching the communities (additively,
+// split by type) and setting the other attributes of a route, nil if none is
+// configured.
+func (a routeAttributes) modifyRouteAction() *networkv1alpha1.ModifyRouteAction {
+	if len(a.communities) == 0 && a.prepend == nil && a.localPreference == nil && a.med == nil {
+		return nil
+	}
+
+	modify := &networkv1alpha1.ModifyRouteAction{
+		SetLocalPreference: a.localPreference,
+		SetMED:             a.med,
+	}
+	if len(a.communities) > 0 {
+		additive := true
+		modify.AddCommunities, modify.AddLargeCommunities, modify.AddExtCommunities = bgpcommunity.Split(a.communities)
+		modify.AdditiveCommunities = &additive
+	}
+	if a.prepend != nil {
+		modify.ASPathPrepend = &networkv1alpha1.ASPathPrepend{
+			OwnASCount: a.prepend.OwnASCount,
+			ASNs:       a.prepend.ASNs,
+		}
+	}
+	return modify
+}
+
 // addressFilterItems creates FilterItems for a list of CIDR addresses.
-// When ap is non-nil, host route communities from the AP are applied.
+// When ap is non-nil, the host route attributes from the AP are applied.
 func addressFilterItems(addresses []string, ap *nc.AnnouncementPolicy) []networkv1alpha1.FilterItem {
 	items := make([]networkv1alpha1.FilterItem, 0, len(addresses))
 	for _, addr := range addresses {
@@ -265,16 +349,11 @@ func addressFilterItems(addresses []string, ap *nc.AnnouncementPolicy) []network
 			suffix = "/128"
 		}
 		prefix := ensureCIDR(addr, suffix)
-		action := networkv1alpha1.Action{Type: networkv1alpha1.Accept}
-		if ap != nil && ap.Spec.HostRoutes != nil && len(ap.Spec.HostRoutes.Communities) > 0 {
-			additive := true
-			action.ModifyRoute = &networkv1alpha1.ModifyRouteAction{
-				AddCommunities:      ap.Spec.HostRoutes.Communities,
-				AdditiveCommunities: &additive,
-			}
-		}
 		items = append(items, networkv1alpha1.FilterItem{
-			Action: action,
+			Action: networkv1alpha1.Action{
+				Type:        networkv1alpha1.Accept,
+				ModifyRoute: hostRouteAttributes(ap).modifyRouteAction(),
+			},
 			Matcher: networkv1alpha1.Matcher{
 				Prefix: &networkv1alpha1.PrefixMatcher{Prefix: prefix, Le: &le},
 			},
@@ -318,13 +397,9 @@ func addAggregateRoutes(fvrf *networkv1alpha1.FabricVRF, net *resolver.ResolvedN
 		aggCfg = ap.Spec.Aggregate
 	}
 
-	action := networkv1alpha1.Action{Type: networkv1alpha1.Accept}
-	if ap != nil && ap.Spec.Aggregate != nil && len(ap.Spec.Aggregate.Communities) > 0 {
-		additive := true
-		action.ModifyRoute = &networkv1alpha1.ModifyRouteAction{
-			AddCommunities:      ap.Spec.Aggregate.Communities,
-			AdditiveCommunities: &additive,
-		}
+	action := networkv1alpha1.Action{
+		Type:        networkv1alpha1.Accept,
+		ModifyRoute: aggregateRouteAttributes(ap).modifyRouteAction(),
 	}
 
 	if net.Spec.IPv4 != nil && net.Spec.IPv4.CIDR != "" {
@@ -101,7 +101,7 @@ func (b *InboundBuilder) Build(ctx context.Context, data *resolver.ResolvedData)
 			if vrfSpec == nil {
 				continue
 			}
-			b.applyInboundToNodes(vrfName, vrfSpec, addresses, redistribute, net, data, result, aps[vrfName])
+			b.applyInboundToNodes(originOf("Inbound", ib.Name), vrfName, vrfSpec, addresses, redistribute, net, data, result, aps[vrfName])
 		}
 	}
 
@@ -110,6 +110,7 @@ func (b *InboundBuilder) Build(ctx context.Context, data *resolver.ResolvedData)
 
 // applyInboundToNodes applies inbound FabricVRF config to all nodes for a single VRF.
 func (*InboundBuilder) applyInboundToNodes(
+	origin string,
 	vrfName string,
 	vrfSpec *nc.VRFSpec,
 	addresses []string,
@@ -147,6 +148,7 @@ func (*InboundBuilder) applyInboundToNodes(
 
 		addAggregateRoutes(&fvrf, net, ap)
 		contrib.FabricVRFs[vrfName] = fvrf
+		contrib.AddOrigin("fabricVRFs/"+vrfName, origin)
 	}
 }
 
@@ -158,17 +158,27 @@ func (b *L2ABuilder) applyL2AToNodes(
 	if err != nil {
 		return fmt.Errorf("Layer2Attachment %q: %w", l2a.Name, err)
 	}
+	// On HBN nodes, gateways are routed in the VRF of the IRB they are reached through.
+	var gatewayRoutes []networkv1alpha1.StaticRoute
+	if vrfName != "" && layer2 != nil && layer2.IRB != nil {
+		gatewayRoutes, err = gatewayStaticRoutes(l2a, data)
+		if err != nil {
+			return fmt.Errorf("Layer2Attachment %q: %w", l2a.Name, err)
+		}
+	}
 
 	// Mutation phase — validation passed, so nothing below can fail.
 	for i := range claims {
 		ifOwner[claims[i].key] = l2a.Name
 	}
 
+	origin := originOf("Layer2Attachment", l2a.Name)
 	for i := range matchingNodes {
 		node := &matchingNodes[i]
 		contrib := ensureContrib(result, node.Name)
 		if layer2 != nil {
 			contrib.Layer2s[mapKey] = *layer2
+			contrib.AddOrigin("layer2s/"+mapKey, origin)
 		}
 
 		// Carry netplan-only device info for this VLAN (interface name/parent
@@ -178,7 +188,8 @@ func (b *L2ABuilder) applyL2AToNodes(
 		}
 
 		if vrfName != "" && vrfSpec != nil {
-			b.applyVRFContrib(net, vrfName, vrfSpec, contrib, ap)
+			b.applyVRFContrib(net, vrfName, vrfSpec, contrib, ap, gatewayRoutes)
+			contrib.AddOrigin("fabricVRFs/"+vrfName, origin)
 		}
 	}
 
@@ -192,6 +203,7 @@ func (*L2ABuilder) applyVRFContrib(
 	vrfSpec *nc.VRFSpec,
 	contrib *NodeContribution,
 	ap *nc.AnnouncementPolicy,
+	gatewayRoutes []networkv1alpha1.StaticRoute,
 ) {
 	fvrf, exists := contrib.FabricVRFs[vrfName]
 	if !exists {
@@ -199,6 +211,9 @@ func (*L2ABuilder) applyVRFContrib(
 	}
 	fvrf = addNetworkToFabricVRF(&fvrf, net, ap)
 	addAggregateRoutes(&fvrf, net, ap)
+	for i := range gatewayRoutes {
+		fvrf.StaticRoutes = appendUniqueStaticRoute(fvrf.StaticRoutes, *gatewayRoutes[i].DeepCopy())
+	}
 	contrib.FabricVRFs[vrfName] = fvrf
 }
 
@@ -497,30 +512,20 @@ func netplanDeviceEmpty(dev *NetplanNodeIP, tagged, hasMTUOverride bool) bool {
 }
 
 // destinationRoutes collects the static routes contributed by the Destinations
-// an L2A selects: each prefix routed via the next hop of its own address family.
-// It is node-independent. The result is sorted and de-duplicated so the rendered
-// netplan YAML is stable across reconciles regardless of Kubernetes list order.
-// An invalid destinations selector is returned as an error so the caller can
-// surface the misconfiguration instead of silently dropping routes.
+// an L2A selects: each prefix routed via the first next hop of its own address
+// family, as netplan cannot install a multipath route. It is node-independent.
+// The result is sorted and de-duplicated so the rendered netplan YAML is stable
+// across reconciles regardless of Kubernetes list order. An invalid
+// destinations selector is returned as an error so the caller can surface the
+// misconfiguration instead of silently dropping routes.
 func destinationRoutes(l2a *nc.Layer2Attachment, data *resolver.ResolvedData) ([]NetplanRoute, error) {
-	if l2a.Spec.Destinations == nil {
-		return nil, nil
-	}
-	selector, err := metav1.LabelSelectorAsSelector(l2a.Spec.Destinations)
+	dests, err := nextHopDestinations(l2a, data)
 	if err != nil {
-		return nil, fmt.Errorf("invalid destinations selector: %w", err)
+		return nil, err
 	}
 	seen := make(map[NetplanRoute]struct{})
 	var routes []NetplanRoute
-	for i := range data.RawDestinations {
-		destination := &data.RawDestinations[i]
-		if !selector.Matches(labels.Set(destination.Labels)) {
-			continue
-		}
-		resolved, ok := data.Destinations[destination.Name]
-		if !ok || resolved.Spec.NextHop == nil {
-			continue
-		}
+	for _, resolved := range dests {
 		v4, v6 := validNextHops(resolved.Spec.NextHop)
 		for _, prefix := range resolved.Spec.Prefixes {
 			route, ok := prefixRoute(prefix, v4, v6)
@@ -543,40 +548,125 @@ func destinationRoutes(l2a *nc.Layer2Attachment, data *resolver.ResolvedData) ([
 	return routes, nil
 }
 
+// nextHopDestinations returns the resolved Destinations with a next hop that
+// an L2A selects, in Kubernetes list order.
+func nextHopDestinations(l2a *nc.Layer2Attachment, data *resolver.ResolvedData) ([]*resolver.ResolvedDestination, error) {
+	if l2a.Spec.Destinations == nil {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(l2a.Spec.Destinations)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destinations selector: %w", err)
+	}
+	var dests []*resolver.ResolvedDestination
+	for i := range data.RawDestinations {
+		destination := &data.RawDestinations[i]
+		if !selector.Matches(labels.Set(destination.Labels)) {
+			continue
+		}
+		resolved, ok := data.Destinations[destination.Name]
+		if !ok || resolved.Spec.NextHop == nil {
+			continue
+		}
+		dests = append(dests, resolved)
+	}
+	return dests, nil
+}
+
 // validNextHops returns the syntactically valid IPv4 and IPv6 next-hop
-// addresses from a NextHopConfig. The CRD does not enforce an IP format, so a
-// value that is not a valid address of its declared family is dropped (returned
-// as "").
-func validNextHops(nh *nc.NextHopConfig) (v4, v6 string) {
+// addresses from a NextHopConfig, including its gateways. The CRD does not
+// enforce an IP format, so a value that is not a valid address of its declared
+// family is dropped.
+func validNextHops(nh *nc.NextHopConfig) (v4, v6 []string) {
 	if nh.IPv4 != nil {
 		if ip := stdnet.ParseIP(*nh.IPv4); ip != nil && ip.To4() != nil {
-			v4 = *nh.IPv4
+			v4 = append(v4, *nh.IPv4)
 		}
 	}
 	if nh.IPv6 != nil {
 		if ip := stdnet.ParseIP(*nh.IPv6); ip != nil && ip.To4() == nil {
-			v6 = *nh.IPv6
+			v6 = append(v6, *nh.IPv6)
+		}
+	}
+	for _, gw := range nh.Gateways {
+		switch ip := stdnet.ParseIP(gw.Address); {
+		case ip == nil:
+		case ip.To4() != nil:
+			v4 = append(v4, gw.Address)
+		default:
+			v6 = append(v6, gw.Address)
 		}
 	}
 	return v4, v6
 }
 
-// prefixRoute builds a route for a prefix via the next hop of its own address
-// family. It returns ok=false for an invalid CIDR or when no next hop of the
+// prefixRoute builds the route for a prefix via the first next hop of its own
+// address family. Further gateways are left to HBN nodes, which load share
+// across them. It returns false for an invalid CIDR or when no next hop of the
 // matching family is available.
-func prefixRoute(prefix, v4, v6 string) (NetplanRoute, bool) {
+func prefixRoute(prefix string, v4, v6 []string) (NetplanRoute, bool) {
 	_, ipNet, err := stdnet.ParseCIDR(prefix)
 	if err != nil {
 		return NetplanRoute{}, false
 	}
-	via := v4
+	vias := v4
 	if ipNet.IP.To4() == nil {
-		via = v6
+		vias = v6
 	}
-	if via == "" {
+	if len(vias) == 0 {
 		return NetplanRoute{}, false
 	}
-	return NetplanRoute{To: prefix, Via: via}, true
+	return NetplanRoute{To: prefix, Via: vias[0]}, true
+}
+
+// gatewayStaticRoutes builds the VRF static routes for the gateways of the
+// Destinations an L2A selects: each prefix load shared across the gateways of
+// its own address family, with their weights and BFD. It is node-independent.
+func gatewayStaticRoutes(l2a *nc.Layer2Attachment, data *resolver.ResolvedData) ([]networkv1alpha1.StaticRoute, error) {
+	dests, err := nextHopDestinations(l2a, data)
+	if err != nil {
+		return nil, err
+	}
+	var routes []networkv1alpha1.StaticRoute
+	for _, resolved := range dests {
+		nh := resolved.Spec.NextHop
+		for _, prefix := range resolved.Spec.Prefixes {
+			_, ipNet, err := stdnet.ParseCIDR(prefix)
+			if err != nil {
+				continue
+			}
+			route := networkv1alpha1.StaticRoute{Prefix: prefix}
+			for _, gw := range nh.Gateways {
+				ip := stdnet.ParseIP(gw.Address)
+				if ip == nil || (ip.To4() == nil) != (ipNet.IP.To4() == nil) {
+					continue
+				}
+				route.NextHops = append(route.NextHops, gatewayNextHop(gw))
+			}
+			if len(route.NextHops) == 0 {
+				continue
+			}
+			if nh.BFDProfile != nil {
+				route.BFDProfile = &networkv1alpha1.BFDProfile{MinInterval: nh.BFDProfile.MinInterval}
+			}
+			routes = append(routes, route)
+		}
+	}
+	return routes, nil
+}
+
+// gatewayNextHop converts a Destination gateway to a static route next hop.
+func gatewayNextHop(gw nc.NextHopGateway) networkv1alpha1.StaticRouteNextHop {
+	address := gw.Address
+	nh := networkv1alpha1.StaticRouteNextHop{
+		NextHop: networkv1alpha1.NextHop{Address: &address},
+		BFD:     gw.EnableBFD != nil && *gw.EnableBFD,
+	}
+	if gw.Weight != nil {
+		weight := uint32(*gw.Weight) //nolint:gosec // value validated by CRD schema (1-255)
+		nh.Weight = &weight
+	}
+	return nh
 }
 
 // buildNetplanNodeIP creates a NetplanNodeIP for a node from the L2A's allocated
@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
 	nc "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
 	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent/resolver"
 )
@@ -113,6 +114,9 @@ func TestL2ABuilder_BasicL2A(t *testing.T) {
 		if l2.IRB != nil {
 			t.Error("expected nil IRB (no destinations)")
 		}
+		if got := contrib.Origins["layer2s/200"]; got != "Layer2Attachment/storage-l2a" {
+			t.Errorf("expected origin 'Layer2Attachment/storage-l2a', got %q", got)
+		}
 	}
 }
 
@@ -1488,3 +1492,127 @@ func TestDestinationRoutes_SortedAndDeduplicated(t *testing.T) {
 	assert.Equal(t, "5.5.5.5/32", routes[1].To)
 	assert.Equal(t, "9.9.9.9/32", routes[2].To)
 }
+
+func TestDestinationRoutes_Gateways(t *testing.T) {
+	l2a := &nc.Layer2Attachment{
+		Spec: nc.Layer2AttachmentSpec{
+			Destinations: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "gw"}},
+		},
+	}
+
+	data := &resolver.ResolvedData{
+		Destinations: map[string]*resolver.ResolvedDestination{
+			"cluster": {
+				Name: "cluster",
+				Spec: nc.DestinationSpec{
+					NextHop: &nc.NextHopConfig{Gateways: []nc.NextHopGateway{
+						{Address: "10.0.0.2"}, {Address: "10.0.0.1"}, {Address: "2001:db8::1"}, {Address: "bogus"},
+					}},
+					Prefixes: []string{"1.1.1.1/32", "2001:db8:ffff::/48"},
+				},
+			},
+		},
+		RawDestinations: []nc.Destination{
+			{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Labels: map[string]string{"role": "gw"}}},
+		},
+	}
+
+	routes, err := destinationRoutes(l2a, data)
+	require.NoError(t, err)
+	// netplan cannot install a multipath route, only the first gateway of each
+	// address family is used.
+	assert.Equal(t, []NetplanRoute{
+		{To: "1.1.1.1/32", Via: "10.0.0.2"},
+		{To: "2001:db8:ffff::/48", Via: "2001:db8::1"},
+	}, routes)
+}
+
+func TestL2ABuilder_GatewayStaticRoutes(t *testing.T) {
+	b := NewL2ABuilder()
+
+	prodVRF := nc.VRFSpec{VRF: "prod", VNI: ptr(int32(5001)), RouteTarget: ptr("65000:5001")}
+	gateways := nc.DestinationSpec{
+		NextHop: &nc.NextHopConfig{
+			Gateways: []nc.NextHopGateway{
+				{Address: "10.100.0.10", Weight: ptr(int32(2)), EnableBFD: ptr(true)},
+				{Address: "10.100.0.11"},
+				{Address: "2001:db8::10"},
+			},
+			BFDProfile: &nc.BFDProfile{MinInterval: 300},
+		},
+		Prefixes: []string{"192.0.2.0/24", "198.51.100.0/24", "not-a-cidr"},
+	}
+	data := &resolver.ResolvedData{
+		Nodes: []corev1.Node{
+			{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		},
+		Networks: map[string]*resolver.ResolvedNetwork{
+			"tenant-net": {
+				Name: "tenant-net",
+				Spec: nc.NetworkSpec{
+					VLAN: ptr(int32(100)),
+					VNI:  ptr(int32(10100)),
+					IPv4: &nc.IPNetwork{CIDR: "10.100.0.0/24"},
+				},
+			},
+		},
+		VRFs: map[string]*resolver.ResolvedVRF{
+			"prod-vrf": {Name: "prod-vrf", Spec: prodVRF},
+		},
+		Destinations: map[string]*resolver.ResolvedDestination{
+			"corp-dc": {
+				Name:    "corp-dc",
+				Spec:    nc.DestinationSpec{VRFRef: ptr("prod-vrf")},
+				VRFSpec: &prodVRF,
+			},
+			"appliances": {Name: "appliances", Spec: gateways},
+		},
+		RawDestinations: []nc.Destination{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "corp-dc", Labels: map[string]string{"env": "prod"}},
+				Spec:       nc.DestinationSpec{VRFRef: ptr("prod-vrf")},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "appliances", Labels: map[string]string{"env": "prod"}},
+				Spec:       gateways,
+			},
+		},
+		Layer2Attachments: []nc.Layer2Attachment{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "tenant-l2a"},
+				Spec: nc.Layer2AttachmentSpec{
+					NetworkRef: "tenant-net",
+					Destinations: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"env": "prod"},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := b.Build(context.Background(), data)
+	require.NoError(t, err)
+
+	fvrf, ok := result["node-1"].FabricVRFs["prod"]
+	require.True(t, ok, "expected FabricVRF 'prod'")
+	routes := make(map[string]networkv1alpha1.StaticRoute)
+	for _, route := range fvrf.StaticRoutes {
+		if len(route.NextHops) > 0 {
+			routes[route.Prefix] = route
+		}
+	}
+	require.Len(t, routes, 2, "invalid CIDR must be skipped")
+	require.Contains(t, routes, "198.51.100.0/24")
+
+	route := routes["192.0.2.0/24"]
+	assert.Nil(t, route.NextHop)
+	require.NotNil(t, route.BFDProfile)
+	assert.Equal(t, uint32(300), route.BFDProfile.MinInterval)
+	require.Len(t, route.NextHops, 2, "IPv6 gateway must not be used for an IPv4 prefix")
+	assert.Equal(t, "10.100.0.10", *route.NextHops[0].Address)
+	assert.Equal(t, uint32(2), *route.NextHops[0].Weight)
+	assert.True(t, route.NextHops[0].BFD)
+	assert.Equal(t, "10.100.0.11", *route.NextHops[1].Address)
+	assert.Nil(t, route.NextHops[1].Weight)
+	assert.False(t, route.NextHops[1].BFD)
+}
@@ -138,13 +138,14 @@ func mirrorDirections(direction string) []networkv1alpha1.MirrorDirection {
 // attachToSource places the MirrorACL on the TrafficMirror's source (a Layer2
 // attachment, an Inbound's VRFs or an Outbound's VRFs) across all nodes.
 func (b *MirrorBuilder) attachToSource(tm *nc.TrafficMirror, acl *networkv1alpha1.MirrorACL, data *resolver.ResolvedData, result map[string]*NodeContribution) error {
+	origin := originOf("TrafficMirror", tm.Name)
 	switch tm.Spec.Source.Kind {
 	case mirrorSourceLayer2Attachment:
-		return b.addToLayer2(tm.Spec.Source.Name, acl, data, result)
+		return b.addToLayer2(tm.Spec.Source.Name, origin, acl, data, result)
 	case mirrorSourceInbound:
-		return b.addToInboundVRF(tm.Spec.Source.Name, acl, data, result)
+		return b.addToInboundVRF(tm.Spec.Source.Name, origin, acl, data, result)
 	case mirrorSourceOutbound:
-		return b.addToOutboundVRF(tm.Spec.Source.Name, acl, data, result)
+		return b.addToOutboundVRF(tm.Spec.Source.Name, origin, acl, data, result)
 	default:
 		return fmt.Errorf("unknown source kind %q", tm.Spec.Source.Kind)
 	}
@@ -183,7 +184,7 @@ func (*MirrorBuilder) convertTrafficMatch(tm *nc.TrafficMatch) networkv1alpha1.T
 }
 
 // addToLayer2 adds MirrorACL to a Layer2 entry identified by L2A name on all nodes.
-func (*MirrorBuilder) addToLayer2(l2aName string, acl *networkv1alpha1.MirrorACL, data *resolver.ResolvedData, result map[string]*NodeContribution) error {
+func (*MirrorBuilder) addToLayer2(l2aName, origin string, acl *networkv1alpha1.MirrorACL, data *resolver.ResolvedData, result map[string]*NodeContribution) error {
 	// Find the L2A.
 	var l2a *nc.Layer2Attachment
 	for j := range data.Layer2Attachments {
@@ -230,6 +231,7 @@ func (*MirrorBuilder) addToLayer2(l2aName string, acl *networkv1alpha1.MirrorACL
 		}
 		layer2.MirrorACLs = append(layer2.MirrorACLs, *acl)
 		contrib.Layer2s[mapKey] = layer2
+		contrib.AddOrigin("layer2s/"+mapKey, origin)
 	}
 
 	return nil
@@ -238,7 +240,7 @@ func (*MirrorBuilder) addToLayer2(l2aName string, acl *networkv1alpha1.MirrorACL
 // addToInboundVRF adds MirrorACL to every VRF associated with an Inbound's
 // Destinations selector, on all nodes. When the selector matches multiple
 // Destinations across different VRFs, the ACL is fanned out to each VRF.
-func (b *MirrorBuilder) addToInboundVRF(ibName string, acl *networkv1alpha1.MirrorACL, data *resolver.ResolvedData, result map[string]*NodeContribution) error {
+func (b *MirrorBuilder) addToInboundVRF(ibName, origin string, acl *networkv1alpha1.MirrorACL, data *resolver.ResolvedData, result map[string]*NodeContribution) error {
 	vrfs, err := b.resolveInboundVRFs(ibName, data)
 	if err != nil {
 		return err
@@ -246,13 +248,13 @@ func (b *MirrorBuilder) addToInboundVRF(ibName string, acl *networkv1alpha1.Mirr
 	if len(vrfs) == 0 {
 		return fmt.Errorf("inbound %q has no VRF", ibName)
 	}
-	b.applyMirrorACLToVRFs(vrfs, acl, data, result)
+	b.applyMirrorACLToVRFs(vrfs, origin, acl, data, result)
 	return nil
 }
 
 // addToOutboundVRF adds MirrorACL to every VRF associated with an Outbound's
 // Destinations selector, on all nodes.
-func (b *MirrorBuilder) addToOutboundVRF(obName string, acl *networkv1alpha1.MirrorACL, data *resolver.ResolvedData, result map[string]*NodeContribution) error {
+func (b *MirrorBuilder) addToOutboundVRF(obName, origin string, acl *networkv1alpha1.MirrorACL, data *resolver.ResolvedData, result map[string]*NodeContribution) error {
 	vrfs, err := b.resolveOutboundVRFs(obName, data)
 	if err != nil {
 		return err
@@ -260,13 +262,13 @@ func (b *MirrorBuilder) addToOutboundVRF(obName string, acl *networkv1alpha1.Mir
 	if len(vrfs) == 0 {
 		return fmt.Errorf("outbound %q has no VRF", obName)
 	}
-	b.applyMirrorACLToVRFs(vrfs, acl, data, result)
+	b.applyMirrorACLToVRFs(vrfs, origin, acl, data, result)
 	return nil
 }
 
 // applyMirrorACLToVRFs writes the ACL into each named VRF on every node,
 // creating the FabricVRF entry on demand.
-func (*MirrorBuilder) applyMirrorACLToVRFs(vrfs map[string]*nc.VRFSpec, acl *networkv1alpha1.MirrorACL, data *resolver.ResolvedData, result map[string]*NodeContribution) {
+func (*MirrorBuilder) applyMirrorACLToVRFs(vrfs map[string]*nc.VRFSpec, origin string, acl *networkv1alpha1.MirrorACL, data *resolver.ResolvedData, result map[string]*NodeContribution) {
 	// Sorted iteration for deterministic output.
 	names := make([]string, 0, len(vrfs))
 	for n := range vrfs {
@@ -288,6 +290,7 @@ func (*MirrorBuilder) applyMirrorACLToVRFs(vrfs map[string]*nc.VRFSpec, acl *net
 			}
 			fvrf.MirrorACLs = append(fvrf.MirrorACLs, *acl)
 			contrib.FabricVRFs[vrfName] = fvrf
+			contrib.AddOrigin("fabricVRFs/"+vrfName, origin)
 		}
 	}
 }
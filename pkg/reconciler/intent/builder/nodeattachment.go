@@ -82,7 +82,7 @@ func (b *NodeAttachmentBuilder) Build(ctx context.Context, data *resolver.Resolv
 				destPrefixes = append(destPrefixes, dests[di].Spec.Prefixes...)
 			}
 
-			b.applyToNodes(vrfName, vrfSpec, destPrefixes, matchedNodes, result)
+			b.applyToNodes(originOf("NodeAttachment", na.Name), vrfName, vrfSpec, destPrefixes, matchedNodes, result)
 		}
 	}
 
@@ -93,6 +93,7 @@ func (b *NodeAttachmentBuilder) Build(ctx context.Context, data *resolver.Resolv
 //   - FabricVRF: exports node IPs into the remote VRF (EVPN + cluster import)
 //   - ClusterVRF: imports destination prefixes from the remote VRF back into cluster
 func (*NodeAttachmentBuilder) applyToNodes(
+	origin string,
 	vrfName string,
 	vrfSpec *nc.VRFSpec,
 	destPrefixes []string,
@@ -130,6 +131,7 @@ func (*NodeAttachmentBuilder) applyToNodes(
 		}
 
 		contrib.FabricVRFs[vrfName] = fvrf
+		contrib.AddOrigin("fabricVRFs/"+vrfName, origin)
 
 		// --- ClusterVRF: import destination prefixes from the remote VRF ---
 		if len(destFilterItems) > 0 {
@@ -137,6 +139,7 @@ func (*NodeAttachmentBuilder) applyToNodes(
 				contrib.ClusterVRF = &networkv1alpha1.VRF{}
 			}
 			contrib.ClusterVRF.VRFImports = appendVRFImport(contrib.ClusterVRF.VRFImports, vrfName, destFilterItems)
+			contrib.AddOrigin("clusterVRF", origin)
 		}
 	}
 }
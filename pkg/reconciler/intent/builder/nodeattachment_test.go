@@ -297,6 +297,40 @@ func TestNodeAttachmentBuilder_ClusterVRFImport(t *testing.T) {
 	}
 }
 
+func TestBuildFabricVRF_EVPNImport(t *testing.T) {
+	maxLength := int32(24)
+	fvrf := buildFabricVRF(&nc.VRFSpec{
+		VRF: "san",
+		EVPNImport: &nc.EVPNImport{Prefixes: []nc.EVPNImportPrefix{
+			{Prefix: "10.0.0.0/8", MaxLength: &maxLength},
+			{Prefix: "fd00::/48"},
+		}},
+	})
+
+	filter := fvrf.EVPNImportFilter
+	if filter == nil {
+		t.Fatal("expected EVPNImportFilter")
+	}
+	if filter.DefaultAction.Type != networkv1alpha1.Reject {
+		t.Errorf("expected Reject default action, got %q", filter.DefaultAction.Type)
+	}
+	if len(filter.Items) != 2 {
+		t.Fatalf("expected 2 EVPN import items, got %d", len(filter.Items))
+	}
+	assertFilterItemPrefix(t, filter.Items[0], "10.0.0.0/8")
+	assertFilterItemPrefix(t, filter.Items[1], "fd00::/48")
+	if le := filter.Items[0].Matcher.Prefix.Le; le == nil || *le != 24 {
+		t.Errorf("expected le 24 for 10.0.0.0/8, got %v", le)
+	}
+	if le := filter.Items[1].Matcher.Prefix.Le; le == nil || *le != ipv6MaxPrefixLen {
+		t.Errorf("expected le %d for fd00::/48, got %v", ipv6MaxPrefixLen, le)
+	}
+
+	if fvrf := buildFabricVRF(&nc.VRFSpec{VRF: "san"}); fvrf.EVPNImportFilter != nil {
+		t.Errorf("expected no EVPNImportFilter without evpnImport, got %+v", fvrf.EVPNImportFilter)
+	}
+}
+
 // assertFilterItemPrefix checks that a FilterItem matches a specific prefix.
 func assertFilterItemPrefix(t *testing.T, item networkv1alpha1.FilterItem, expected string) {
 	t.Helper()
@@ -137,6 +137,7 @@ func (b *OutboundBuilder) applyOutbound(
 
 			addAggregateRoutes(&fvrf, net, c.ap)
 			contrib.FabricVRFs[c.vrfName] = fvrf
+			contrib.AddOrigin("fabricVRFs/"+c.vrfName, originOf("Outbound", ob.Name))
 		}
 	}
 
@@ -104,6 +104,7 @@ func (b *PodNetworkBuilder) Build(ctx context.Context, data *resolver.ResolvedDa
 
 			addAggregateRoutes(&fvrf, net, ap)
 			contrib.FabricVRFs[vrfName] = fvrf
+			contrib.AddOrigin("fabricVRFs/"+vrfName, originOf("PodNetwork", pn.Name))
 		}
 	}
 
@@ -59,6 +59,7 @@ type sbrGroup struct {
 	key            string              // sorted destination names joined by "+" (dedup key)
 	vrfRoutes      map[string][]string // vrfName → destination prefixes
 	sourcePrefixes []string            // consumer source addresses that need SBR
+	consumers      []string            // origins of the consumers sharing the group
 }
 
 // Build produces per-node LocalVRFs and ClusterVRF PolicyRoutes for SBR.
@@ -74,7 +75,7 @@ func (b *SBRBuilder) Build(_ context.Context, data *resolver.ResolvedData) (map[
 		if len(sources) == 0 {
 			continue
 		}
-		b.addConsumerToGroups(inb.Spec.Destinations, sources, data, groups)
+		b.addConsumerToGroups(originOf("Inbound", inb.Name), inb.Spec.Destinations, sources, data, groups)
 	}
 
 	// Scan Outbound consumers.
@@ -84,7 +85,7 @@ func (b *SBRBuilder) Build(_ context.Context, data *resolver.ResolvedData) (map[
 		if len(sources) == 0 {
 			continue
 		}
-		b.addConsumerToGroups(outb.Spec.Destinations, sources, data, groups)
+		b.addConsumerToGroups(originOf("Outbound", outb.Name), outb.Spec.Destinations, sources, data, groups)
 	}
 
 	// Scan PodNetwork consumers.
@@ -94,7 +95,7 @@ func (b *SBRBuilder) Build(_ context.Context, data *resolver.ResolvedData) (map[
 		if len(sources) == 0 {
 			continue
 		}
-		b.addConsumerToGroups(pnet.Spec.Destinations, sources, data, groups)
+		b.addConsumerToGroups(originOf("PodNetwork", pnet.Name), pnet.Spec.Destinations, sources, data, groups)
 	}
 
 	if len(groups) == 0 {
@@ -136,6 +137,11 @@ func (b *SBRBuilder) Build(_ context.Context, data *resolver.ResolvedData) (map[
 					NextHop: networkv1alpha1.NextHop{Vrf: &intermediateName},
 				})
 			}
+
+			for _, consumer := range group.consumers {
+				contrib.AddOrigin("localVRFs/"+intermediateName, consumer)
+				contrib.AddOrigin("clusterVRF/policyRoutes/"+intermediateName, consumer)
+			}
 		}
 
 		if len(contrib.LocalVRFs) > 0 || contrib.ClusterVRF != nil {
@@ -153,6 +159,7 @@ func (b *SBRBuilder) Build(_ context.Context, data *resolver.ResolvedData) (map[
 // Multi-VRF consumers get a combo group keyed by sorted destination names → "s-<hash>".
 // Two consumers selecting the same destinations share the same combo group.
 func (*SBRBuilder) addConsumerToGroups(
+	consumer string,
 	destSelector *metav1.LabelSelector,
 	sourcePrefixes []string,
 	data *resolver.ResolvedData,
@@ -179,6 +186,7 @@ func (*SBRBuilder) addConsumerToGroups(
 				groups[vrfName] = group
 			}
 			group.sourcePrefixes = appendUnique(group.sourcePrefixes, sourcePrefixes...)
+			group.consumers = appendUnique(group.consumers, consumer)
 			for di := range dests {
 				group.vrfRoutes[vrfName] = appendUnique(group.vrfRoutes[vrfName], dests[di].Spec.Prefixes...)
 			}
@@ -199,6 +207,7 @@ func (*SBRBuilder) addConsumerToGroups(
 	}
 
 	group.sourcePrefixes = appendUnique(group.sourcePrefixes, sourcePrefixes...)
+	group.consumers = appendUnique(group.consumers, consumer)
 	for vrfName, dests := range grouped {
 		for di := range dests {
 			group.vrfRoutes[vrfName] = appendUnique(group.vrfRoutes[vrfName], dests[di].Spec.Prefixes...)
@@ -0,0 +1,220 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package intent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	nc "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent/builder"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent/resolver"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/nncdiff"
+)
+
+// RenderedConfig is the NodeNetworkConfig rendered for a single node.
+type RenderedConfig struct {
+	Spec *networkv1alpha1.NodeNetworkConfigSpec
+	// Origins maps NNC section keys to their source intent CRDs.
+	Origins map[string]string
+	// Err is set if the node's config could not be assembled.
+	Err error
+}
+
+// NodePlan lists the NodeNetworkConfig changes of a single node.
+type NodePlan struct {
+	Node    string
+	Changes []nncdiff.Change
+	// Err is set if the node's proposed config could not be assembled.
+	Err error
+}
+
+// Plan is the difference between the NodeNetworkConfigs rendered from the
+// current and the proposed intent CRDs.
+type Plan struct {
+	// Nodes are the nodes whose config changes (or fails), sorted by name.
+	Nodes []NodePlan
+	// Issues are the proposed intent CRDs the builders skipped.
+	Issues []builder.BuildIssue
+}
+
+// Render runs the resolver, the builders and the assembler on the fetched
+// intent CRDs and returns the NodeNetworkConfig of each node, keyed by node
+// name. It does not access the cluster: IPAM and Collector addresses are taken
+// from the status of the fetched objects. Build issues are returned alongside.
+func Render(ctx context.Context, fetched *resolver.FetchedResources, logger logr.Logger) (map[string]*RenderedConfig, []builder.BuildIssue, error) {
+	resolved, err := resolver.ResolveAll(fetched)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve references: %w", err)
+	}
+
+	report := builder.NewBuildReport()
+	contributions, failed := runBuilders(builder.WithReport(ctx, report), defaultBuilders(), resolved, logger)
+	if failed {
+		return nil, nil, fmt.Errorf("builder failed")
+	}
+
+	configs := make(map[string]*RenderedConfig, len(fetched.Nodes))
+	for i := range fetched.Nodes {
+		cfg, err := assembleNodeConfig(&fetched.Nodes[i], contributions[fetched.Nodes[i].Name])
+		if err != nil {
+			configs[fetched.Nodes[i].Name] = &RenderedConfig{Err: err}
+			continue
+		}
+		configs[fetched.Nodes[i].Name] = &RenderedConfig{Spec: cfg.spec, Origins: cfg.origins}
+	}
+	return configs, report.Issues(), nil
+}
+
+// ComputePlan renders the current and the proposed intent CRDs and returns
+// the per-node NodeNetworkConfig changes. Nothing is applied.
+func ComputePlan(ctx context.Context, current, proposed *resolver.FetchedResources, logger logr.Logger) (*Plan, error) {
+	from, _, err := Render(ctx, current, logger)
+	if err != nil {
+		return nil, fmt.Errorf("rendering current intent: %w", err)
+	}
+	to, issues, err := Render(ctx, proposed, logger)
+	if err != nil {
+		return nil, fmt.Errorf("rendering proposed intent: %w", err)
+	}
+
+	nodes := make(map[string]bool, len(to))
+	for name := range from {
+		nodes[name] = true
+	}
+	for name := range to {
+		nodes[name] = true
+	}
+
+	plan := &Plan{Issues: issues}
+	for name := range nodes {
+		var fromCfg, toCfg nncdiff.Config
+		if cfg, ok := from[name]; ok && cfg.Err == nil {
+			fromCfg = nncdiff.Config{Spec: cfg.Spec, Origins: cfg.Origins}
+		}
+		if cfg, ok := to[name]; ok {
+			if cfg.Err != nil {
+				plan.Nodes = append(plan.Nodes, NodePlan{Node: name, Err: cfg.Err})
+				continue
+			}
+			toCfg = nncdiff.Config{Spec: cfg.Spec, Origins: cfg.Origins}
+		}
+		if changes := nncdiff.Diff(fromCfg, toCfg); len(changes) > 0 {
+			plan.Nodes = append(plan.Nodes, NodePlan{Node: name, Changes: changes})
+		}
+	}
+
+	sort.Slice(plan.Nodes, func(i, j int) bool { return plan.Nodes[i].Node < plan.Nodes[j].Node })
+	return plan, nil
+}
+
+// IsDryRun returns true if the object is marked as a dry-run proposal.
+func IsDryRun(obj metav1.Object) bool {
+	return obj.GetAnnotations()[nc.AnnotationDryRun] == "true"
+}
+
+// WithoutDryRun returns a copy of fetched without the intent CRDs marked as
+// dry-run. The All* lists are kept, so finalizers of dry-run objects are
+// still cleaned up.
+func WithoutDryRun(fetched *resolver.FetchedResources) *resolver.FetchedResources {
+	f := *fetched
+	f.VRFs = withoutDryRun(f.VRFs)
+	f.Networks = withoutDryRun(f.Networks)
+	f.Destinations = withoutDryRun(f.Destinations)
+	f.Layer2Attachments = withoutDryRun(f.Layer2Attachments)
+	f.Inbounds = withoutDryRun(f.Inbounds)
+	f.Outbounds = withoutDryRun(f.Outbounds)
+	f.PodNetworks = withoutDryRun(f.PodNetworks)
+	f.BGPPeerings = withoutDryRun(f.BGPPeerings)
+	f.Collectors = withoutDryRun(f.Collectors)
+	f.TrafficMirrors = withoutDryRun(f.TrafficMirrors)
+	f.AnnouncementPolicies = withoutDryRun(f.AnnouncementPolicies)
+	f.NodeAttachments = withoutDryRun(f.NodeAttachments)
+	return &f
+}
+
+func withoutDryRun[T any, PT interface {
+	*T
+	metav1.Object
+}](items []T) []T {
+	out := make([]T, 0, len(items))
+	for i := range items {
+		if !IsDryRun(PT(&items[i])) {
+			out = append(out, items[i])
+		}
+	}
+	return out
+}
+
+// Overlay returns a copy of fetched with the given nodes and intent CRDs
+// added, replacing the objects of the same kind, namespace and name.
+func Overlay(fetched *resolver.FetchedResources, objs []client.Object) (*resolver.FetchedResources, error) {
+	f := *fetched
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *corev1.Node:
+			f.Nodes = overlay(f.Nodes, o)
+		case *nc.VRF:
+			f.VRFs = overlay(f.VRFs, o)
+		case *nc.Network:
+			f.Networks = overlay(f.Networks, o)
+		case *nc.Destination:
+			f.Destinations = overlay(f.Destinations, o)
+		case *nc.Layer2Attachment:
+			f.Layer2Attachments = overlay(f.Layer2Attachments, o)
+		case *nc.Inbound:
+			f.Inbounds = overlay(f.Inbounds, o)
+		case *nc.Outbound:
+			f.Outbounds = overlay(f.Outbounds, o)
+		case *nc.PodNetwork:
+			f.PodNetworks = overlay(f.PodNetworks, o)
+		case *nc.BGPPeering:
+			f.BGPPeerings = overlay(f.BGPPeerings, o)
+		case *nc.Collector:
+			f.Collectors = overlay(f.Collectors, o)
+		case *nc.TrafficMirror:
+			f.TrafficMirrors = overlay(f.TrafficMirrors, o)
+		case *nc.AnnouncementPolicy:
+			f.AnnouncementPolicies = overlay(f.AnnouncementPolicies, o)
+		case *nc.NodeAttachment:
+			f.NodeAttachments = overlay(f.NodeAttachments, o)
+		default:
+			return nil, fmt.Errorf("unsupported object %T %s", obj, client.ObjectKeyFromObject(obj))
+		}
+	}
+	return &f, nil
+}
+
+func overlay[T any, PT interface {
+	*T
+	client.Object
+}](items []T, obj PT) []T {
+	out := make([]T, 0, len(items)+1)
+	for i := range items {
+		if client.ObjectKeyFromObject(PT(&items[i])) != client.ObjectKeyFromObject(obj) {
+			out = append(out, items[i])
+		}
+	}
+	return append(out, *obj)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package intent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	nc "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent/resolver"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/nncdiff"
+)
+
+func planFixture() *resolver.FetchedResources {
+	return &resolver.FetchedResources{
+		Nodes: []corev1.Node{
+			{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"rack": "a"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "node-2", Labels: map[string]string{"rack": "b"}}},
+		},
+		VRFs:         []nc.VRF{*makeVRF("m2m", "m2m", 2000, "65000:2000")},
+		Networks:     []nc.Network{*makeNetwork("net-100", 100, 10100, "10.100.0.0/24", "")},
+		Destinations: []nc.Destination{*makeDestination("dest-m2m", "m2m", map[string]string{"type": "m2m"}, []string{"10.0.0.0/8"})},
+	}
+}
+
+func TestRender_RecordsOrigins(t *testing.T) {
+	fetched := planFixture()
+	fetched.Layer2Attachments = []nc.Layer2Attachment{*makeL2A("l2a-100", "net-100", destSelector("m2m"), nil)}
+
+	configs, issues, err := Render(context.Background(), fetched, logf.Log.WithName("test-plan"))
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+	require.Len(t, configs, 2)
+
+	cfg := configs["node-1"]
+	require.NoError(t, cfg.Err)
+	assert.Contains(t, cfg.Spec.Layer2s, "100")
+	assert.Contains(t, cfg.Spec.FabricVRFs, "m2m")
+	assert.Equal(t, "Layer2Attachment/l2a-100", cfg.Origins["layer2s/100"])
+	assert.Equal(t, "Layer2Attachment/l2a-100", cfg.Origins["fabricVRFs/m2m"])
+}
+
+func TestComputePlan_DryRunObjects(t *testing.T) {
+	l2a := makeL2A("l2a-100", "net-100", destSelector("m2m"), &metav1.LabelSelector{
+		MatchLabels: map[string]string{"rack": "a"},
+	})
+	l2a.Annotations = map[string]string{nc.AnnotationDryRun: "true"}
+	fetched := planFixture()
+	fetched.Layer2Attachments = []nc.Layer2Attachment{*l2a}
+
+	plan, err := ComputePlan(context.Background(), WithoutDryRun(fetched), fetched, logf.Log.WithName("test-plan"))
+	require.NoError(t, err)
+
+	// Only the selected node changes.
+	require.Len(t, plan.Nodes, 1)
+	assert.Equal(t, "node-1", plan.Nodes[0].Node)
+	assert.Contains(t, plan.Nodes[0].Changes, nncdiff.Change{
+		Op: nncdiff.Added, Section: "layer2s/100", Origin: "Layer2Attachment/l2a-100",
+	})
+	assert.Contains(t, plan.Nodes[0].Changes, nncdiff.Change{
+		Op: nncdiff.Added, Section: "fabricVRFs/m2m", Origin: "Layer2Attachment/l2a-100",
+	})
+}
+
+func TestComputePlan_NoChanges(t *testing.T) {
+	fetched := planFixture()
+	fetched.Layer2Attachments = []nc.Layer2Attachment{*makeL2A("l2a-100", "net-100", destSelector("m2m"), nil)}
+
+	plan, err := ComputePlan(context.Background(), fetched, fetched, logf.Log.WithName("test-plan"))
+	require.NoError(t, err)
+	assert.Empty(t, plan.Nodes)
+}
+
+func TestOverlay_ReplacesAndAdds(t *testing.T) {
+	fetched := planFixture()
+	fetched.Layer2Attachments = []nc.Layer2Attachment{*makeL2A("l2a-100", "net-100", destSelector("m2m"), nil)}
+
+	changed := makeNetwork("net-100", 100, 10100, "10.100.0.0/23", "")
+	added := makeL2A("l2a-200", "net-100", nil, nil)
+	overlaid, err := Overlay(fetched, []client.Object{changed, added})
+	require.NoError(t, err)
+
+	require.Len(t, overlaid.Networks, 1)
+	assert.Equal(t, "10.100.0.0/23", overlaid.Networks[0].Spec.IPv4.CIDR)
+	assert.Len(t, overlaid.Layer2Attachments, 2)
+	// the input is not modified
+	assert.Len(t, fetched.Layer2Attachments, 1)
+
+	_, err = Overlay(fetched, []client.Object{&corev1.ConfigMap{}})
+	assert.Error(t, err)
+}
+
+func TestWithoutDryRun(t *testing.T) {
+	fetched := planFixture()
+	proposed := makeVRF("proposed", "proposed", 3000, "65000:3000")
+	proposed.Annotations = map[string]string{nc.AnnotationDryRun: "true"}
+	fetched.VRFs = append(fetched.VRFs, *proposed)
+	fetched.AllVRFs = fetched.VRFs
+
+	applied := WithoutDryRun(fetched)
+	require.Len(t, applied.VRFs, 1)
+	assert.Equal(t, "m2m", applied.VRFs[0].Name)
+	assert.Len(t, applied.AllVRFs, 2)
+	assert.Len(t, fetched.VRFs, 2)
+}
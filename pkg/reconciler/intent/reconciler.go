@@ -64,29 +64,20 @@ type Reconciler struct {
 	statusUpdater    *status.Updater
 	ipamAllocator    *ipam.Allocator
 	legacyDetector   *legacy.Detector
+	rollout          RolloutConfig
 }
 
 // NewReconciler creates a new intent reconciler.
 // The namespace parameter restricts which namespace intent CRDs are read from.
 // An empty string means all namespaces (cluster-wide).
-func NewReconciler(clusterClient client.Client, logger logr.Logger, timeout time.Duration, namespace string) (*Reconciler, error) {
+func NewReconciler(clusterClient client.Client, logger logr.Logger, timeout time.Duration, namespace string, rollout RolloutConfig) (*Reconciler, error) {
 	r := &Reconciler{
-		logger:    logger,
-		timeout:   timeout,
-		client:    clusterClient,
-		namespace: namespace,
-		builders: []builder.Builder{
-			builder.NewL2ABuilder(),
-			builder.NewInboundBuilder(),
-			builder.NewOutboundBuilder(),
-			builder.NewPodNetworkBuilder(),
-			builder.NewBGPPeeringBuilder(),
-			builder.NewCollectorBuilder(),
-			builder.NewMirrorBuilder(),
-			builder.NewAnnouncementBuilder(),
-			builder.NewNodeAttachmentBuilder(),
-			builder.NewSBRBuilder(),
-		},
+		logger:           logger,
+		timeout:          timeout,
+		client:           clusterClient,
+		namespace:        namespace,
+		rollout:          rollout,
+		builders:         defaultBuilders(),
 		finalizerManager: finalizer.NewManager(clusterClient, logger),
 		statusUpdater:    status.NewUpdater(clusterClient, logger),
 		ipamAllocator:    ipam.NewAllocator(clusterClient, logger),
@@ -98,6 +89,23 @@ func NewReconciler(clusterClient client.Client, logger logr.Logger, timeout time
 	return r, nil
 }
 
+// defaultBuilders returns the builders that turn intent CRDs into
+// NodeNetworkConfig contributions.
+func defaultBuilders() []builder.Builder {
+	return []builder.Builder{
+		builder.NewL2ABuilder(),
+		builder.NewInboundBuilder(),
+		builder.NewOutboundBuilder(),
+		builder.NewPodNetworkBuilder(),
+		builder.NewBGPPeeringBuilder(),
+		builder.NewCollectorBuilder(),
+		builder.NewMirrorBuilder(),
+		builder.NewAnnouncementBuilder(),
+		builder.NewNodeAttachmentBuilder(),
+		builder.NewSBRBuilder(),
+	}
+}
+
 // Reconcile triggers the debounced reconciliation.
 func (r *Reconciler) Reconcile(ctx context.Context) {
 	r.debouncer.Debounce(ctx)
@@ -117,11 +125,13 @@ func (r *Reconciler) ReconcileDebounced(ctx context.Context) error {
 		r.logger.Info("legacy CRDs detected — reconciliation continues", "conflicts", len(conflicts))
 	}
 
-	// 1. Fetch all intent CRDs + nodes.
-	fetched, err := r.fetchAll(timeoutCtx)
+	// 1. Fetch all intent CRDs + nodes. CRDs marked as dry-run are proposals
+	// only shown by `kubectl nnc plan` and never applied.
+	fetched, err := FetchAll(timeoutCtx, r.client, r.namespace, r.logger)
 	if err != nil {
 		return fmt.Errorf("failed to fetch intent resources: %w", err)
 	}
+	fetched = WithoutDryRun(fetched)
 
 	// 1b. Clean up orphaned NNCs and NodeNetplanConfigs (nodes that no longer exist).
 	if err := r.cleanupOrphanedNNCs(timeoutCtx, fetched.Nodes); err != nil {
@@ -161,21 +171,8 @@ func (r *Reconciler) ReconcileDebounced(ctx context.Context) error {
 	// so a returned error is unexpected. When one occurs, skip applying the
 	// (potentially incomplete) contribution set — fail closed, preserving the
 	// last-good NNC — but still run the status update so the failure surfaces.
-	contributions := make(map[string][]*builder.NodeContribution) // nodeName → contributions
-	buildFailed := false
 	report := builder.NewBuildReport()
-	buildCtx := builder.WithReport(ctx, report)
-	for _, b := range r.builders {
-		nodeContribs, err := b.Build(buildCtx, resolved)
-		if err != nil {
-			r.logger.Error(err, "builder failed", "builder", b.Name())
-			buildFailed = true
-			continue
-		}
-		for nodeName, contrib := range nodeContribs {
-			contributions[nodeName] = append(contributions[nodeName], contrib)
-		}
-	}
+	contributions, buildFailed := runBuilders(builder.WithReport(ctx, report), r.builders, resolved, r.logger)
 
 	// 6-8. Assemble and apply NNC + netplan per node. Skipped when a builder
 	// failed, because an incomplete contribution set could push partially-wired
@@ -224,50 +221,139 @@ func (r *Reconciler) nodeLocalASNs(ctx context.Context) map[string]int64 {
 	return asns
 }
 
-// applyNodeConfigs assembles each node's contributions and applies the resulting
-// NodeNetworkConfig and NodeNetplanConfig. Per-node failures are logged and
-// skipped so one bad node does not block the others.
+// runBuilders runs all builders and returns the contributions per node name.
+// A failing builder is logged and skipped; failed reports whether any did.
+func runBuilders(
+	ctx context.Context,
+	builders []builder.Builder,
+	resolved *resolver.ResolvedData,
+	logger logr.Logger,
+) (contributions map[string][]*builder.NodeContribution, failed bool) {
+	contributions = make(map[string][]*builder.NodeContribution) // nodeName → contributions
+	for _, b := range builders {
+		nodeContribs, err := b.Build(ctx, resolved)
+		if err != nil {
+			logger.Error(err, "builder failed", "builder", b.Name())
+			failed = true
+			continue
+		}
+		for nodeName, contrib := range nodeContribs {
+			contributions[nodeName] = append(contributions[nodeName], contrib)
+		}
+	}
+	return contributions, failed
+}
+
+// applyNodeConfigs assembles each node's contributions into a revision and
+// rolls it out: at most RolloutConfig.MaxUpdating nodes provision a new
+// NodeNetworkConfig (and NodeNetplanConfig) at the same time, and the rollout
+// stops when a node fails to provision its config. Per-node assembly failures
+// are logged and the node is skipped so one bad node does not block the others.
 func (r *Reconciler) applyNodeConfigs(
 	ctx context.Context,
 	fetched *resolver.FetchedResources,
 	contributions map[string][]*builder.NodeContribution,
 ) {
-	for i := range fetched.Nodes {
-		node := &fetched.Nodes[i]
-		result, err := assembler.Assemble(contributions[node.Name])
-		if err != nil {
-			r.logger.Error(err, "assembly failed", "node", node.Name)
-			continue
-		}
+	configs := assembleNodeConfigs(fetched, contributions, r.logger)
+	if len(configs) == 0 {
+		return
+	}
 
-		// Reduce all VRF names (map keys and cross-references) to their
-		// datapath-safe form before hashing and applying the config.
-		if err := nncnames.Reduce(result.Spec); err != nil {
-			r.logger.Error(err, "VRF name reduction failed", "node", node.Name)
-			continue
-		}
+	revision, err := r.ensureRevision(ctx, configs)
+	if err != nil {
+		r.logger.Error(err, "failed to get NetworkConfigRevision")
+		return
+	}
 
-		// Compute revision hash.
-		revision, err := computeRevision(result.Spec)
-		if err != nil {
-			r.logger.Error(err, "revision hash failed", "node", node.Name)
+	nodesToDeploy, heldBack, err := r.rolloutRevision(ctx, revision, configs)
+	if err != nil {
+		r.logger.Error(err, "failed to roll out NetworkConfigRevision", "revision", revision.Name)
+		return
+	}
+
+	deploy := make(map[string]bool, len(nodesToDeploy))
+	for _, cfg := range nodesToDeploy {
+		deploy[cfg.node.Name] = true
+	}
+	held := make(map[string]bool, len(heldBack))
+	for _, cfg := range heldBack {
+		held[cfg.node.Name] = true
+	}
+
+	for _, cfg := range configs {
+		// Nodes waiting for their turn keep their current configs.
+		if held[cfg.node.Name] {
 			continue
 		}
-		result.Spec.Revision = revision
 
 		// 7. Create or update NNC.
-		if err := r.applyNNC(ctx, node, result.Spec, result.Origins); err != nil {
-			r.logger.Error(err, "failed to apply NodeNetworkConfig", "node", node.Name)
-			continue
+		if deploy[cfg.node.Name] {
+			if err := r.applyNNC(ctx, cfg.node, cfg.spec, cfg.origins); err != nil {
+				r.logger.Error(err, "failed to apply NodeNetworkConfig", "node", cfg.node.Name)
+				continue
+			}
 		}
 
 		// 8. Create or update NodeNetplanConfig (host-side VLANs for HBN-L2 agent).
-		netplanState := buildNetplanState(result.Spec, result.NetplanNodeIPs)
-		if err := r.applyNetplanConfig(ctx, node, netplanState); err != nil {
-			r.logger.Error(err, "failed to apply NodeNetplanConfig", "node", node.Name)
+		if err := r.applyNetplanConfig(ctx, cfg.node, cfg.netplan); err != nil {
+			r.logger.Error(err, "failed to apply NodeNetplanConfig", "node", cfg.node.Name)
+			continue
+		}
+	}
+
+	if err := r.cleanupRevisions(ctx, revision); err != nil {
+		r.logger.Error(err, "NetworkConfigRevision cleanup failed")
+	}
+}
+
+// assembleNodeConfigs assembles the contributions of each node into its
+// NodeNetworkConfig spec and NodeNetplanConfig state. Nodes that fail to
+// assemble are logged and skipped.
+func assembleNodeConfigs(
+	fetched *resolver.FetchedResources,
+	contributions map[string][]*builder.NodeContribution,
+	logger logr.Logger,
+) []*nodeConfig {
+	configs := make([]*nodeConfig, 0, len(fetched.Nodes))
+	for i := range fetched.Nodes {
+		cfg, err := assembleNodeConfig(&fetched.Nodes[i], contributions[fetched.Nodes[i].Name])
+		if err != nil {
+			logger.Error(err, "assembly failed", "node", fetched.Nodes[i].Name)
 			continue
 		}
+		configs = append(configs, cfg)
+	}
+
+	sort.Slice(configs, func(i, j int) bool { return configs[i].node.Name < configs[j].node.Name })
+	return configs
+}
+
+// assembleNodeConfig assembles the contributions of a single node.
+func assembleNodeConfig(node *corev1.Node, contributions []*builder.NodeContribution) (*nodeConfig, error) {
+	result, err := assembler.Assemble(contributions)
+	if err != nil {
+		return nil, fmt.Errorf("assembly failed: %w", err)
+	}
+
+	// Reduce all VRF names (map keys and cross-references) to their
+	// datapath-safe form before hashing and applying the config.
+	if err := nncnames.Reduce(result.Spec); err != nil {
+		return nil, fmt.Errorf("VRF name reduction failed: %w", err)
 	}
+
+	// Compute revision hash.
+	revision, err := computeRevision(result.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("revision hash failed: %w", err)
+	}
+	result.Spec.Revision = revision
+
+	return &nodeConfig{
+		node:    node,
+		spec:    result.Spec,
+		origins: nncnames.ReduceOrigins(result.Origins),
+		netplan: buildNetplanState(result.Spec, result.NetplanNodeIPs),
+	}, nil
 }
 
 // filterActive returns only items without a DeletionTimestamp (not being deleted).
@@ -290,7 +376,7 @@ func filterActive[T any, PT interface {
 func listInto[L interface {
 	client.ObjectList
 	*U
-}, U any](ctx context.Context, c client.Client, baseOpts []client.ListOption, consume func(list L)) error {
+}, U any](ctx context.Context, c client.Reader, baseOpts []client.ListOption, consume func(list L)) error {
 	list := L(new(U))
 	if err := c.List(ctx, list, baseOpts...); err != nil {
 		return fmt.Errorf("list: %w", err)
@@ -299,90 +385,93 @@ func listInto[L interface {
 	return nil
 }
 
-func (r *Reconciler) fetchAll(ctx context.Context) (*resolver.FetchedResources, error) {
+// FetchAll lists the nodes and all intent CRDs in the given namespace (all
+// namespaces if empty) and resolves the BGPPeering passwords. Objects being
+// deleted are only kept in the All* lists.
+func FetchAll(ctx context.Context, c client.Reader, namespace string, logger logr.Logger) (*resolver.FetchedResources, error) {
 	f := &resolver.FetchedResources{}
 
 	var nsOpts []client.ListOption
-	if r.namespace != "" {
-		nsOpts = append(nsOpts, client.InNamespace(r.namespace))
+	if namespace != "" {
+		nsOpts = append(nsOpts, client.InNamespace(namespace))
 	}
 
-	if err := listInto[*corev1.NodeList](ctx, r.client, nil, func(l *corev1.NodeList) {
+	if err := listInto[*corev1.NodeList](ctx, c, nil, func(l *corev1.NodeList) {
 		f.Nodes = append(f.Nodes, l.Items...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing Nodes: %w", err)
 	}
 
-	if err := listInto[*nc.VRFList](ctx, r.client, nsOpts, func(l *nc.VRFList) {
+	if err := listInto[*nc.VRFList](ctx, c, nsOpts, func(l *nc.VRFList) {
 		f.AllVRFs = append(f.AllVRFs, l.Items...)
 		f.VRFs = append(f.VRFs, filterActive(l.Items)...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing VRFs: %w", err)
 	}
 
-	if err := listInto[*nc.NetworkList](ctx, r.client, nsOpts, func(l *nc.NetworkList) {
+	if err := listInto[*nc.NetworkList](ctx, c, nsOpts, func(l *nc.NetworkList) {
 		f.AllNetworks = append(f.AllNetworks, l.Items...)
 		f.Networks = append(f.Networks, filterActive(l.Items)...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing Networks: %w", err)
 	}
 
-	if err := listInto[*nc.DestinationList](ctx, r.client, nsOpts, func(l *nc.DestinationList) {
+	if err := listInto[*nc.DestinationList](ctx, c, nsOpts, func(l *nc.DestinationList) {
 		f.AllDestinations = append(f.AllDestinations, l.Items...)
 		f.Destinations = append(f.Destinations, filterActive(l.Items)...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing Destinations: %w", err)
 	}
 
-	if err := listInto[*nc.Layer2AttachmentList](ctx, r.client, nsOpts, func(l *nc.Layer2AttachmentList) {
+	if err := listInto[*nc.Layer2AttachmentList](ctx, c, nsOpts, func(l *nc.Layer2AttachmentList) {
 		f.Layer2Attachments = append(f.Layer2Attachments, filterActive(l.Items)...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing Layer2Attachments: %w", err)
 	}
 
-	if err := listInto[*nc.InboundList](ctx, r.client, nsOpts, func(l *nc.InboundList) {
+	if err := listInto[*nc.InboundList](ctx, c, nsOpts, func(l *nc.InboundList) {
 		f.Inbounds = append(f.Inbounds, filterActive(l.Items)...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing Inbounds: %w", err)
 	}
 
-	if err := listInto[*nc.OutboundList](ctx, r.client, nsOpts, func(l *nc.OutboundList) {
+	if err := listInto[*nc.OutboundList](ctx, c, nsOpts, func(l *nc.OutboundList) {
 		f.Outbounds = append(f.Outbounds, filterActive(l.Items)...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing Outbounds: %w", err)
 	}
 
-	if err := listInto[*nc.PodNetworkList](ctx, r.client, nsOpts, func(l *nc.PodNetworkList) {
+	if err := listInto[*nc.PodNetworkList](ctx, c, nsOpts, func(l *nc.PodNetworkList) {
 		f.PodNetworks = append(f.PodNetworks, filterActive(l.Items)...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing PodNetworks: %w", err)
 	}
 
-	if err := listInto[*nc.BGPPeeringList](ctx, r.client, nsOpts, func(l *nc.BGPPeeringList) {
+	if err := listInto[*nc.BGPPeeringList](ctx, c, nsOpts, func(l *nc.BGPPeeringList) {
 		f.BGPPeerings = append(f.BGPPeerings, filterActive(l.Items)...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing BGPPeerings: %w", err)
 	}
 
-	if err := listInto[*nc.CollectorList](ctx, r.client, nsOpts, func(l *nc.CollectorList) {
+	if err := listInto[*nc.CollectorList](ctx, c, nsOpts, func(l *nc.CollectorList) {
 		f.Collectors = append(f.Collectors, filterActive(l.Items)...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing Collectors: %w", err)
 	}
 
-	if err := listInto[*nc.TrafficMirrorList](ctx, r.client, nsOpts, func(l *nc.TrafficMirrorList) {
+	if err := listInto[*nc.TrafficMirrorList](ctx, c, nsOpts, func(l *nc.TrafficMirrorList) {
 		f.TrafficMirrors = append(f.TrafficMirrors, filterActive(l.Items)...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing TrafficMirrors: %w", err)
 	}
 
-	if err := listInto[*nc.AnnouncementPolicyList](ctx, r.client, nsOpts, func(l *nc.AnnouncementPolicyList) {
+	if err := listInto[*nc.AnnouncementPolicyList](ctx, c, nsOpts, func(l *nc.AnnouncementPolicyList) {
 		f.AnnouncementPolicies = append(f.AnnouncementPolicies, filterActive(l.Items)...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing AnnouncementPolicies: %w", err)
 	}
 
-	if err := listInto[*nc.NodeAttachmentList](ctx, r.client, nsOpts, func(l *nc.NodeAttachmentList) {
+	if err := listInto[*nc.NodeAttachmentList](ctx, c, nsOpts, func(l *nc.NodeAttachmentList) {
 		f.NodeAttachments = append(f.NodeAttachments, filterActive(l.Items)...)
 	}); err != nil {
 		return nil, fmt.Errorf("error listing NodeAttachments: %w", err)
@@ -391,7 +480,7 @@ func (r *Reconciler) fetchAll(ctx context.Context) (*resolver.FetchedResources,
 	// Resolve BGPPeering AuthSecretRefs to inline passwords. Skipping (with a
 	// log) is preferred over failing the whole reconcile: a missing or
 	// malformed Secret should degrade only the affected peering.
-	f.BGPPasswords = r.resolveBGPPasswords(ctx, f.BGPPeerings)
+	f.BGPPasswords = resolveBGPPasswords(ctx, c, logger, f.BGPPeerings)
 
 	return f, nil
 }
@@ -400,7 +489,7 @@ func (r *Reconciler) fetchAll(ctx context.Context) (*resolver.FetchedResources,
 // AuthSecretRef (in the same namespace) and returns a map keyed by
 // "<namespace>/<name>" of the BGPPeering. Missing/malformed Secrets are
 // logged and skipped — the affected BGPPeering will simply have no password.
-func (r *Reconciler) resolveBGPPasswords(ctx context.Context, peerings []nc.BGPPeering) map[string]string {
+func resolveBGPPasswords(ctx context.Context, c client.Reader, logger logr.Logger, peerings []nc.BGPPeering) map[string]string {
 	out := map[string]string{}
 	for i := range peerings {
 		bp := &peerings[i]
@@ -409,8 +498,8 @@ func (r *Reconciler) resolveBGPPasswords(ctx context.Context, peerings []nc.BGPP
 		}
 		secret := &corev1.Secret{}
 		key := client.ObjectKey{Namespace: bp.Namespace, Name: bp.Spec.AuthSecretRef.Name}
-		if err := r.client.Get(ctx, key, secret); err != nil {
-			r.logger.Info("BGPPeering authSecretRef not resolvable; peering will have no password",
+		if err := c.Get(ctx, key, secret); err != nil {
+			logger.Info("BGPPeering authSecretRef not resolvable; peering will have no password",
 				"bgppeering", client.ObjectKeyFromObject(bp).String(),
 				"secret", key.String(),
 				"error", err.Error())
@@ -418,7 +507,7 @@ func (r *Reconciler) resolveBGPPasswords(ctx context.Context, peerings []nc.BGPP
 		}
 		raw, ok := secret.Data["password"]
 		if !ok || len(raw) == 0 {
-			r.logger.Info("BGPPeering authSecretRef Secret has no 'password' key; peering will have no password",
+			logger.Info("BGPPeering authSecretRef Secret has no 'password' key; peering will have no password",
 				"bgppeering", client.ObjectKeyFromObject(bp).String(),
 				"secret", key.String())
 			continue
@@ -428,7 +517,8 @@ func (r *Reconciler) resolveBGPPasswords(ctx context.Context, peerings []nc.BGPP
 	return out
 }
 
-const originsAnnotation = "network-connector.sylvaproject.org/origins"
+// OriginsAnnotation records the source CRDs of the sections of a NodeNetworkConfig.
+const OriginsAnnotation = "network-connector.sylvaproject.org/origins"
 
 // applyNNC creates or updates a NodeNetworkConfig for a node.
 // It skips nodes that are currently provisioning (rolling update gate).
@@ -477,7 +567,7 @@ func (r *Reconciler) tryApplyNNC(ctx context.Context, node *corev1.Node, spec *n
 		r.stripLegacyOwnerRefs(existing, node)
 		setIntentManagedLabel(existing)
 		existing.Spec = *spec
-		setOriginsAnnotation(existing, origins)
+		SetOriginsAnnotation(existing, origins)
 		if err := r.client.Update(ctx, existing); err != nil {
 			return fmt.Errorf("error updating NodeNetworkConfig for node %s: %w", node.Name, err)
 		}
@@ -492,7 +582,7 @@ func (r *Reconciler) tryApplyNNC(ctx context.Context, node *corev1.Node, spec *n
 		Spec: *spec,
 	}
 	setIntentManagedLabel(nnc)
-	setOriginsAnnotation(nnc, origins)
+	SetOriginsAnnotation(nnc, origins)
 
 	if err := controllerutil.SetOwnerReference(node, nnc, r.client.Scheme()); err != nil {
 		return fmt.Errorf("error setting owner reference for NNC %s: %w", node.Name, err)
@@ -504,8 +594,9 @@ func (r *Reconciler) tryApplyNNC(ctx context.Context, node *corev1.Node, spec *n
 	return nil
 }
 
-// setOriginsAnnotation writes the origins map as a JSON annotation on the NNC.
-func setOriginsAnnotation(nnc *networkv1alpha1.NodeNetworkConfig, origins map[string]string) {
+// SetOriginsAnnotation writes the origins map as a JSON annotation on the NNC.
+// Nothing is written for empty origins.
+func SetOriginsAnnotation(nnc *networkv1alpha1.NodeNetworkConfig, origins map[string]string) {
 	if len(origins) == 0 {
 		return
 	}
@@ -516,7 +607,7 @@ func setOriginsAnnotation(nnc *networkv1alpha1.NodeNetworkConfig, origins map[st
 	if nnc.Annotations == nil {
 		nnc.Annotations = make(map[string]string)
 	}
-	nnc.Annotations[originsAnnotation] = string(data)
+	nnc.Annotations[OriginsAnnotation] = string(data)
 }
 
 // computeRevision computes a SHA256 hash of the NNC spec for change detection.
@@ -534,8 +625,8 @@ func computeRevision(spec *networkv1alpha1.NodeNetworkConfigSpec) (string, error
 	return fmt.Sprintf("%x", hash), nil
 }
 
-const intentManagedLabel = "network-connector.sylvaproject.org/managed-by"
-const intentLabelValue = "intent"
+const intentManagedLabel = networkv1alpha1.ManagedByLabel
+const intentLabelValue = networkv1alpha1.ManagedByIntent
 
 // setIntentManagedLabel marks an NNC as managed by the intent reconciler.
 func setIntentManagedLabel(nnc *networkv1alpha1.NodeNetworkConfig) {
@@ -88,7 +88,7 @@ func TestMain(m *testing.M) {
 	}
 
 	logger := logf.Log.WithName("test-reconciler")
-	reconciler, err = NewReconciler(k8sClient, logger, 60*time.Second, "default")
+	reconciler, err = NewReconciler(k8sClient, logger, 60*time.Second, "default", RolloutConfig{MaxUpdating: 1000})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to create reconciler: %v\n", err)
 		os.Exit(1)
@@ -562,11 +562,9 @@ func TestOriginTracking(t *testing.T) {
 
 	nnc := reconcileAndGetNNC(t, ctx, nodeName)
 
-	// Check origins annotation exists
-	ann, ok := nnc.Annotations[originsAnnotation]
+	ann, ok := nnc.Annotations[OriginsAnnotation]
 	if !ok {
-		t.Skip("origins annotation not set (builders may not call SetOrigin yet)")
-		return
+		t.Fatal("origins annotation not set")
 	}
 
 	var origins map[string]string
@@ -574,8 +572,8 @@ func TestOriginTracking(t *testing.T) {
 		t.Fatalf("failed to parse origins annotation: %v", err)
 	}
 
-	if len(origins) == 0 {
-		t.Skip("origins map is empty (builders may not call SetOrigin yet)")
+	if got := origins["layer2s/540"]; got != "Layer2Attachment/l2a-origin" {
+		t.Errorf("expected layer2s/540 to originate from Layer2Attachment/l2a-origin, got %q", got)
 	}
 }
 
@@ -0,0 +1,283 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package intent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/network/netplan"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/operator"
+)
+
+// revisionNameLength is the length of the revision hash prefix used as
+// NetworkConfigRevision name (same as for legacy revisions).
+const revisionNameLength = 10
+
+// RolloutConfig configures how NodeNetworkConfigs are rolled out to the nodes.
+type RolloutConfig struct {
+	// MaxUpdating is the maximum number of nodes provisioning a new config at
+	// the same time.
+	MaxUpdating int
+	// ConfigTimeout is the time a node may take to provision a config once
+	// its agent picked it up.
+	ConfigTimeout time.Duration
+	// PreconfigTimeout is the time a node's agent may take to pick up a config.
+	PreconfigTimeout time.Duration
+}
+
+// nodeConfig is the assembled configuration of a single node.
+type nodeConfig struct {
+	node    *corev1.Node
+	spec    *networkv1alpha1.NodeNetworkConfigSpec
+	origins map[string]string
+	netplan *netplan.State
+}
+
+// rolloutCounters is the provisioning state of a revision's nodes.
+type rolloutCounters struct {
+	ready, ongoing, invalid int
+	failedNode              string
+	failedMessage           string
+	failedAt                metav1.Time
+	// outdated are the nodes whose NodeNetworkConfig is not yet the one of the revision.
+	outdated []*nodeConfig
+}
+
+// newIntentRevision returns the NetworkConfigRevision for the given node
+// configs. Its revision is a hash of the per-node revisions, so it changes
+// whenever any node's config changes.
+func newIntentRevision(configs []*nodeConfig) (*networkv1alpha1.NetworkConfigRevision, error) {
+	nodeRevisions := make(map[string]string, len(configs))
+	for _, cfg := range configs {
+		nodeRevisions[cfg.node.Name] = cfg.spec.Revision
+	}
+
+	// map keys are marshaled in sorted order, so the hash is stable
+	data, err := json.Marshal(nodeRevisions)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling node revisions: %w", err)
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256(data))
+
+	return &networkv1alpha1.NetworkConfigRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   hash[:revisionNameLength],
+			Labels: map[string]string{intentManagedLabel: intentLabelValue},
+		},
+		Spec: networkv1alpha1.NetworkConfigRevisionSpec{
+			Revision:      hash,
+			NodeRevisions: nodeRevisions,
+		},
+	}, nil
+}
+
+// ensureRevision returns the stored NetworkConfigRevision for the node configs,
+// creating it if it does not exist yet. An invalid revision is reused as is,
+// unless a retry was requested with the retry annotation.
+func (r *Reconciler) ensureRevision(ctx context.Context, configs []*nodeConfig) (*networkv1alpha1.NetworkConfigRevision, error) {
+	revision, err := newIntentRevision(configs)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := &networkv1alpha1.NetworkConfigRevision{}
+	err = r.client.Get(ctx, client.ObjectKeyFromObject(revision), existing)
+	switch {
+	case err == nil:
+		if existing.Spec.Revision != revision.Spec.Revision {
+			return nil, fmt.Errorf("NetworkConfigRevision %s already exists with a different revision", revision.Name)
+		}
+		if existing.Status.IsInvalid && existing.Annotations[networkv1alpha1.RetryAnnotation] == "true" {
+			if err := r.retryRevision(ctx, existing, configs); err != nil {
+				return nil, err
+			}
+		}
+		return existing, nil
+	case !apierrors.IsNotFound(err):
+		return nil, fmt.Errorf("error getting NetworkConfigRevision %s: %w", revision.Name, err)
+	}
+
+	r.logger.Info("creating NetworkConfigRevision", "name", revision.Name, "nodes", len(configs))
+	if err := r.client.Create(ctx, revision); err != nil {
+		return nil, fmt.Errorf("error creating NetworkConfigRevision %s: %w", revision.Name, err)
+	}
+	return revision, nil
+}
+
+// retryRevision makes an invalid revision valid again, so it is rolled out
+// once more: the NodeNetworkConfigs of the revision that were not provisioned
+// are reset, so the agents apply them again. The retry annotation is removed.
+func (r *Reconciler) retryRevision(ctx context.Context, revision *networkv1alpha1.NetworkConfigRevision, configs []*nodeConfig) error {
+	r.logger.Info("retrying NetworkConfigRevision", "name", revision.Name, "failedNode", revision.Status.FailedNode)
+
+	for _, cfg := range configs {
+		nnc := &networkv1alpha1.NodeNetworkConfig{}
+		if err := r.client.Get(ctx, client.ObjectKey{Name: cfg.node.Name}, nnc); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("error getting NodeNetworkConfig %s: %w", cfg.node.Name, err)
+		}
+		if nnc.Spec.Revision != cfg.spec.Revision || nnc.Status.ConfigStatus == operator.StatusProvisioned {
+			continue
+		}
+		nnc.Status.ConfigStatus = ""
+		nnc.Status.ErrorMessage = ""
+		nnc.Status.LastUpdate = metav1.Now()
+		if err := r.client.Status().Update(ctx, nnc); err != nil {
+			return fmt.Errorf("error resetting status of NodeNetworkConfig %s: %w", nnc.Name, err)
+		}
+	}
+
+	revision.Status.IsInvalid = false
+	revision.Status.FailedNode = ""
+	revision.Status.FailedMessage = ""
+	revision.Status.FailedAt = nil
+	if err := r.client.Status().Update(ctx, revision); err != nil {
+		return fmt.Errorf("error resetting status of NetworkConfigRevision %s: %w", revision.Name, err)
+	}
+
+	delete(revision.Annotations, networkv1alpha1.RetryAnnotation)
+	if err := r.client.Update(ctx, revision); err != nil {
+		return fmt.Errorf("error removing retry annotation from NetworkConfigRevision %s: %w", revision.Name, err)
+	}
+	return nil
+}
+
+// getRolloutCounters compares the nodes' current NodeNetworkConfigs with the
+// configs of the revision. A config that was reported invalid or did not get
+// provisioned in time counts as invalid.
+func (r *Reconciler) getRolloutCounters(configs []*nodeConfig, nncs map[string]*networkv1alpha1.NodeNetworkConfig, now time.Time) *rolloutCounters {
+	cnt := &rolloutCounters{}
+	for _, cfg := range configs {
+		nnc, ok := nncs[cfg.node.Name]
+		if !ok || nnc.Spec.Revision != cfg.spec.Revision || !hasIntentManagedLabel(nnc) {
+			cnt.outdated = append(cnt.outdated, cfg)
+			continue
+		}
+
+		timeout := r.rollout.ConfigTimeout
+		switch nnc.Status.ConfigStatus {
+		case operator.StatusProvisioned:
+			cnt.ready++
+		case operator.StatusInvalid:
+			cnt.addFailure(nnc.Name, nnc.Status.ErrorMessage, nnc.Status.LastUpdate)
+		case "":
+			// the agent did not pick up the config yet
+			timeout = r.rollout.PreconfigTimeout
+			fallthrough
+		case operator.StatusProvisioning:
+			cnt.ongoing++
+			if !nnc.Status.LastUpdate.IsZero() && now.After(nnc.Status.LastUpdate.Add(timeout)) {
+				cnt.addFailure(nnc.Name, "provisioning timeout reached", metav1.NewTime(nnc.Status.LastUpdate.Add(timeout)))
+			}
+		}
+	}
+	return cnt
+}
+
+// addFailure records a failed node; the lexicographically smallest node name
+// is reported for determinism.
+func (c *rolloutCounters) addFailure(node, message string, at metav1.Time) {
+	c.invalid++
+	if c.failedNode == "" || node < c.failedNode {
+		c.failedNode = node
+		c.failedMessage = message
+		c.failedAt = at
+	}
+}
+
+// rolloutRevision updates the revision's status from the nodes' current
+// NodeNetworkConfigs and returns the nodes that may be updated now and the
+// outdated nodes that are held back. Nothing is rolled out once a node failed to
+// provision a config of the revision, until the intent changes and a new
+// revision is created or a retry is requested (see retryRevision).
+func (r *Reconciler) rolloutRevision(ctx context.Context, revision *networkv1alpha1.NetworkConfigRevision,
+	configs []*nodeConfig) (deploy, held []*nodeConfig, err error) {
+	nncList := &networkv1alpha1.NodeNetworkConfigList{}
+	if err := r.client.List(ctx, nncList); err != nil {
+		return nil, nil, fmt.Errorf("error listing NodeNetworkConfigs: %w", err)
+	}
+	nncs := make(map[string]*networkv1alpha1.NodeNetworkConfig, len(nncList.Items))
+	for i := range nncList.Items {
+		nncs[nncList.Items[i].Name] = &nncList.Items[i]
+	}
+
+	cnt := r.getRolloutCounters(configs, nncs, time.Now())
+
+	// Invalidate when transitioning to invalid state, or when the failed node has changed.
+	if cnt.invalid > 0 && (!revision.Status.IsInvalid || revision.Status.FailedNode != cnt.failedNode) {
+		r.logger.Info("invalidating NetworkConfigRevision", "name", revision.Name, "failedNode", cnt.failedNode, "failedMessage", cnt.failedMessage)
+		revision.Status.IsInvalid = true
+		revision.Status.FailedNode = cnt.failedNode
+		revision.Status.FailedMessage = cnt.failedMessage
+		if !cnt.failedAt.IsZero() {
+			revision.Status.FailedAt = &cnt.failedAt
+		}
+	}
+
+	revision.Status.Ready = cnt.ready
+	revision.Status.Ongoing = cnt.ongoing
+	revision.Status.Queued = len(cnt.outdated)
+	revision.Status.Total = len(configs)
+	if err := r.client.Status().Update(ctx, revision); err != nil {
+		return nil, nil, fmt.Errorf("error updating status of NetworkConfigRevision %s: %w", revision.Name, err)
+	}
+
+	if revision.Status.IsInvalid {
+		if len(cnt.outdated) > 0 {
+			r.logger.Info("rollout stopped, revision is invalid", "revision", revision.Name, "failedNode", revision.Status.FailedNode)
+		}
+		return nil, cnt.outdated, nil
+	}
+
+	available := min(max(r.rollout.MaxUpdating-cnt.ongoing, 0), len(cnt.outdated))
+	return cnt.outdated[:available], cnt.outdated[available:], nil
+}
+
+// cleanupRevisions deletes all other intent revisions once the revision is
+// fully rolled out.
+func (r *Reconciler) cleanupRevisions(ctx context.Context, revision *networkv1alpha1.NetworkConfigRevision) error {
+	if revision.Status.IsInvalid || revision.Status.Ready != revision.Status.Total {
+		return nil
+	}
+
+	revisions := &networkv1alpha1.NetworkConfigRevisionList{}
+	if err := r.client.List(ctx, revisions, client.MatchingLabels{intentManagedLabel: intentLabelValue}); err != nil {
+		return fmt.Errorf("error listing NetworkConfigRevisions: %w", err)
+	}
+	for i := range revisions.Items {
+		if revisions.Items[i].Name == revision.Name {
+			continue
+		}
+		r.logger.Info("deleting NetworkConfigRevision", "name", revisions.Items[i].Name)
+		if err := r.client.Delete(ctx, &revisions.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting NetworkConfigRevision %s: %w", revisions.Items[i].Name, err)
+		}
+	}
+	return nil
+}
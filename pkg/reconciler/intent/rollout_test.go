@@ -0,0 +1,283 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package intent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/operator"
+)
+
+func newRolloutReconciler(t *testing.T, rollout RolloutConfig, objs ...client.Object) *Reconciler {
+	t.Helper()
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, networkv1alpha1.AddToScheme(s))
+
+	return &Reconciler{
+		logger: logf.Log.WithName("test-rollout"),
+		client: fake.NewClientBuilder().
+			WithScheme(s).
+			WithObjects(objs...).
+			WithStatusSubresource(&networkv1alpha1.NetworkConfigRevision{}, &networkv1alpha1.NodeNetworkConfig{}).
+			Build(),
+		rollout: rollout,
+	}
+}
+
+func makeRolloutConfig(nodeName, revision string) *nodeConfig {
+	return &nodeConfig{
+		node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}},
+		spec: &networkv1alpha1.NodeNetworkConfigSpec{Revision: revision},
+	}
+}
+
+func makeRolloutNNC(nodeName, revision, status string, lastUpdate time.Time) *networkv1alpha1.NodeNetworkConfig {
+	return &networkv1alpha1.NodeNetworkConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   nodeName,
+			Labels: map[string]string{intentManagedLabel: intentLabelValue},
+		},
+		Spec: networkv1alpha1.NodeNetworkConfigSpec{Revision: revision},
+		Status: networkv1alpha1.NodeNetworkConfigStatus{
+			ConfigStatus: status,
+			LastUpdate:   metav1.NewTime(lastUpdate),
+		},
+	}
+}
+
+func rolloutNodeNames(configs []*nodeConfig) []string {
+	names := make([]string, 0, len(configs))
+	for _, cfg := range configs {
+		names = append(names, cfg.node.Name)
+	}
+	return names
+}
+
+func TestNewIntentRevision_Stable(t *testing.T) {
+	a, err := newIntentRevision([]*nodeConfig{makeRolloutConfig("node-a", "rev-a"), makeRolloutConfig("node-b", "rev-b")})
+	require.NoError(t, err)
+	b, err := newIntentRevision([]*nodeConfig{makeRolloutConfig("node-b", "rev-b"), makeRolloutConfig("node-a", "rev-a")})
+	require.NoError(t, err)
+	c, err := newIntentRevision([]*nodeConfig{makeRolloutConfig("node-a", "rev-a"), makeRolloutConfig("node-b", "rev-c")})
+	require.NoError(t, err)
+
+	assert.Equal(t, a.Spec.Revision, b.Spec.Revision, "revision must not depend on the node order")
+	assert.NotEqual(t, a.Spec.Revision, c.Spec.Revision, "revision must change with any node's config")
+	assert.Equal(t, a.Spec.Revision[:revisionNameLength], a.Name)
+	assert.Equal(t, intentLabelValue, a.Labels[intentManagedLabel])
+	assert.Equal(t, map[string]string{"node-a": "rev-a", "node-b": "rev-b"}, a.Spec.NodeRevisions)
+}
+
+func TestGetRolloutCounters(t *testing.T) {
+	now := time.Now()
+	r := &Reconciler{rollout: RolloutConfig{ConfigTimeout: 2 * time.Minute, PreconfigTimeout: 10 * time.Minute}}
+
+	configs := []*nodeConfig{
+		makeRolloutConfig("ready", "new"),
+		makeRolloutConfig("provisioning", "new"),
+		makeRolloutConfig("pending", "new"),
+		makeRolloutConfig("old", "new"),
+		makeRolloutConfig("missing", "new"),
+	}
+	nncs := map[string]*networkv1alpha1.NodeNetworkConfig{
+		"ready":        makeRolloutNNC("ready", "new", operator.StatusProvisioned, now),
+		"provisioning": makeRolloutNNC("provisioning", "new", operator.StatusProvisioning, now.Add(-time.Minute)),
+		"pending":      makeRolloutNNC("pending", "new", "", now.Add(-5*time.Minute)),
+		"old":          makeRolloutNNC("old", "old", operator.StatusProvisioned, now),
+	}
+
+	cnt := r.getRolloutCounters(configs, nncs, now)
+	assert.Equal(t, 1, cnt.ready)
+	assert.Equal(t, 2, cnt.ongoing)
+	assert.Equal(t, 0, cnt.invalid)
+	assert.Equal(t, []string{"old", "missing"}, rolloutNodeNames(cnt.outdated))
+}
+
+func TestGetRolloutCounters_Failures(t *testing.T) {
+	now := time.Now()
+	r := &Reconciler{rollout: RolloutConfig{ConfigTimeout: 2 * time.Minute, PreconfigTimeout: 10 * time.Minute}}
+
+	configs := []*nodeConfig{
+		makeRolloutConfig("node-b", "new"),
+		makeRolloutConfig("node-a", "new"),
+	}
+	invalid := makeRolloutNNC("node-b", "new", operator.StatusInvalid, now)
+	invalid.Status.ErrorMessage = "bad config"
+	nncs := map[string]*networkv1alpha1.NodeNetworkConfig{
+		"node-b": invalid,
+		"node-a": makeRolloutNNC("node-a", "new", operator.StatusProvisioning, now.Add(-5*time.Minute)),
+	}
+
+	cnt := r.getRolloutCounters(configs, nncs, now)
+	assert.Equal(t, 2, cnt.invalid)
+	assert.Equal(t, "node-a", cnt.failedNode, "smallest failed node is reported")
+	assert.Equal(t, "provisioning timeout reached", cnt.failedMessage)
+}
+
+func TestRolloutRevision_MaxUpdating(t *testing.T) {
+	ctx := context.Background()
+	configs := []*nodeConfig{
+		makeRolloutConfig("node-a", "new"),
+		makeRolloutConfig("node-b", "new"),
+		makeRolloutConfig("node-c", "new"),
+		makeRolloutConfig("node-d", "new"),
+	}
+	revision, err := newIntentRevision(configs)
+	require.NoError(t, err)
+
+	r := newRolloutReconciler(t, RolloutConfig{MaxUpdating: 2, ConfigTimeout: time.Hour, PreconfigTimeout: time.Hour},
+		revision,
+		makeRolloutNNC("node-a", "new", operator.StatusProvisioning, time.Now()),
+		makeRolloutNNC("node-b", "old", operator.StatusProvisioned, time.Now()),
+	)
+
+	deploy, held, err := r.rolloutRevision(ctx, revision, configs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node-b"}, rolloutNodeNames(deploy))
+	assert.Equal(t, []string{"node-c", "node-d"}, rolloutNodeNames(held))
+
+	stored := &networkv1alpha1.NetworkConfigRevision{}
+	require.NoError(t, r.client.Get(ctx, client.ObjectKeyFromObject(revision), stored))
+	assert.False(t, stored.Status.IsInvalid)
+	assert.Equal(t, 0, stored.Status.Ready)
+	assert.Equal(t, 1, stored.Status.Ongoing)
+	assert.Equal(t, 3, stored.Status.Queued)
+	assert.Equal(t, 4, stored.Status.Total)
+}
+
+func TestRolloutRevision_StopsOnFailure(t *testing.T) {
+	ctx := context.Background()
+	configs := []*nodeConfig{
+		makeRolloutConfig("node-a", "new"),
+		makeRolloutConfig("node-b", "new"),
+	}
+	revision, err := newIntentRevision(configs)
+	require.NoError(t, err)
+
+	failed := makeRolloutNNC("node-a", "new", operator.StatusInvalid, time.Now())
+	failed.Status.ErrorMessage = "bad config"
+	r := newRolloutReconciler(t, RolloutConfig{MaxUpdating: 10}, revision, failed)
+
+	deploy, held, err := r.rolloutRevision(ctx, revision, configs)
+	require.NoError(t, err)
+	assert.Empty(t, deploy)
+	assert.Equal(t, []string{"node-b"}, rolloutNodeNames(held))
+
+	stored := &networkv1alpha1.NetworkConfigRevision{}
+	require.NoError(t, r.client.Get(ctx, client.ObjectKeyFromObject(revision), stored))
+	assert.True(t, stored.Status.IsInvalid)
+	assert.Equal(t, "node-a", stored.Status.FailedNode)
+	assert.Equal(t, "bad config", stored.Status.FailedMessage)
+}
+
+func TestEnsureRevision_ReusesInvalidRevision(t *testing.T) {
+	ctx := context.Background()
+	configs := []*nodeConfig{makeRolloutConfig("node-a", "new")}
+	revision, err := newIntentRevision(configs)
+	require.NoError(t, err)
+	revision.Status.IsInvalid = true
+	revision.Status.FailedNode = "node-a"
+
+	r := newRolloutReconciler(t, RolloutConfig{MaxUpdating: 1}, revision,
+		makeRolloutNNC("node-a", "new", operator.StatusInvalid, time.Now()))
+
+	stored, err := r.ensureRevision(ctx, configs)
+	require.NoError(t, err)
+	assert.True(t, stored.Status.IsInvalid)
+	assert.Equal(t, "node-a", stored.Status.FailedNode)
+}
+
+func TestEnsureRevision_Retry(t *testing.T) {
+	ctx := context.Background()
+	configs := []*nodeConfig{
+		makeRolloutConfig("node-a", "new"),
+		makeRolloutConfig("node-b", "new"),
+	}
+	revision, err := newIntentRevision(configs)
+	require.NoError(t, err)
+	revision.Annotations = map[string]string{networkv1alpha1.RetryAnnotation: "true"}
+	revision.Status.IsInvalid = true
+	revision.Status.FailedNode = "node-a"
+	revision.Status.FailedMessage = "bad config"
+
+	failed := makeRolloutNNC("node-a", "new", operator.StatusInvalid, time.Now().Add(-time.Hour))
+	failed.Status.ErrorMessage = "bad config"
+	r := newRolloutReconciler(t, RolloutConfig{MaxUpdating: 1, ConfigTimeout: time.Minute, PreconfigTimeout: time.Minute},
+		revision, failed, makeRolloutNNC("node-b", "new", operator.StatusProvisioned, time.Now().Add(-time.Hour)))
+
+	stored, err := r.ensureRevision(ctx, configs)
+	require.NoError(t, err)
+	assert.False(t, stored.Status.IsInvalid)
+	assert.Empty(t, stored.Status.FailedNode)
+	assert.NotContains(t, stored.Annotations, networkv1alpha1.RetryAnnotation)
+
+	nnc := &networkv1alpha1.NodeNetworkConfig{}
+	require.NoError(t, r.client.Get(ctx, client.ObjectKey{Name: "node-a"}, nnc))
+	assert.Empty(t, nnc.Status.ConfigStatus)
+	assert.Empty(t, nnc.Status.ErrorMessage)
+	require.NoError(t, r.client.Get(ctx, client.ObjectKey{Name: "node-b"}, nnc))
+	assert.Equal(t, operator.StatusProvisioned, nnc.Status.ConfigStatus)
+
+	// the reset config is provisioned again instead of invalidating the revision
+	_, _, err = r.rolloutRevision(ctx, stored, configs)
+	require.NoError(t, err)
+	require.NoError(t, r.client.Get(ctx, client.ObjectKeyFromObject(revision), stored))
+	assert.False(t, stored.Status.IsInvalid)
+	assert.Equal(t, 1, stored.Status.Ongoing)
+}
+
+func TestCleanupRevisions(t *testing.T) {
+	ctx := context.Background()
+	configs := []*nodeConfig{makeRolloutConfig("node-a", "new")}
+	revision, err := newIntentRevision(configs)
+	require.NoError(t, err)
+	previous, err := newIntentRevision([]*nodeConfig{makeRolloutConfig("node-a", "old")})
+	require.NoError(t, err)
+	legacy := &networkv1alpha1.NetworkConfigRevision{ObjectMeta: metav1.ObjectMeta{Name: "legacy"}}
+
+	r := newRolloutReconciler(t, RolloutConfig{MaxUpdating: 1}, revision, previous, legacy)
+
+	// Not fully rolled out yet: nothing is deleted.
+	revision.Status.Total = 1
+	require.NoError(t, r.cleanupRevisions(ctx, revision))
+	list := &networkv1alpha1.NetworkConfigRevisionList{}
+	require.NoError(t, r.client.List(ctx, list))
+	assert.Len(t, list.Items, 3)
+
+	revision.Status.Ready = 1
+	require.NoError(t, r.cleanupRevisions(ctx, revision))
+	require.NoError(t, r.client.List(ctx, list))
+	names := []string{}
+	for i := range list.Items {
+		names = append(names, list.Items[i].Name)
+	}
+	assert.ElementsMatch(t, []string{revision.Name, "legacy"}, names)
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package intent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent/builder"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent/resolver"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/operator"
+)
+
+// NodeTrace lists the NodeNetworkConfig sections of a node that originate
+// from a traced intent CRD.
+type NodeTrace struct {
+	Node string
+	// Sections are the sections of the node's NodeNetworkConfig that
+	// originate from the intent CRD, according to its origins annotation.
+	Sections []string
+	// Missing are the sections the intent CRD renders for the node that its
+	// NodeNetworkConfig lacks; Stale those it still has but no longer should.
+	Missing, Stale []string
+	// Reason explains why the NodeNetworkConfig differs from the rendered one.
+	Reason string
+}
+
+// Trace is the per-node effect of an intent CRD.
+type Trace struct {
+	// Source is the traced intent CRD as "Kind/name".
+	Source string
+	// Nodes are the nodes carrying or expected to carry sections of the
+	// intent CRD, sorted by name.
+	Nodes []NodeTrace
+	// Issues are the build issues of the intent CRD, explaining why it is
+	// skipped on all nodes.
+	Issues []builder.BuildIssue
+	// Found is false if no intent CRD of that kind and name exists.
+	Found bool
+	// DryRun is true if the intent CRD is a dry-run proposal, which is never
+	// applied to the nodes.
+	DryRun bool
+}
+
+// TraceSource finds the nodes whose NodeNetworkConfigs carry sections that
+// originate from the given intent CRD ("Kind/name", the kind is matched
+// case-insensitively) and compares them with the sections rendering the
+// fetched intent CRDs assigns to each node. Nodes expected to carry sections
+// they do not have (or the other way round) are listed with a reason.
+func TraceSource(ctx context.Context, fetched *resolver.FetchedResources, nncs []networkv1alpha1.NodeNetworkConfig, source string, logger logr.Logger) (*Trace, error) {
+	kind, name, ok := strings.Cut(source, "/")
+	if !ok || kind == "" || name == "" {
+		return nil, fmt.Errorf("invalid intent object %q, expected Kind/name", source)
+	}
+
+	rendered, issues, err := Render(ctx, WithoutDryRun(fetched), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	trace := &Trace{Source: source}
+	for _, obj := range intentObjects(fetched)[strings.ToLower(kind)] {
+		if obj.GetName() == name {
+			trace.Found = true
+			trace.DryRun = trace.DryRun || IsDryRun(obj)
+		}
+	}
+	for _, issue := range issues {
+		if strings.EqualFold(issue.Kind, kind) && issue.Name == name {
+			trace.Issues = append(trace.Issues, issue)
+		}
+	}
+
+	actual := make(map[string]*networkv1alpha1.NodeNetworkConfig, len(nncs))
+	for i := range nncs {
+		actual[nncs[i].Name] = &nncs[i]
+	}
+
+	nodes := make(map[string]bool)
+	for node := range actual {
+		nodes[node] = true
+	}
+	for node := range rendered {
+		nodes[node] = true
+	}
+
+	for node := range nodes {
+		var carried, expected []string
+		nnc, hasNNC := actual[node]
+		if hasNNC {
+			carried = sectionsOf(ParseOrigins(nnc.Annotations), kind, name)
+		}
+		cfg, hasCfg := rendered[node]
+		if hasCfg && cfg.Err == nil {
+			expected = sectionsOf(cfg.Origins, kind, name)
+		}
+		if len(carried) == 0 && len(expected) == 0 && (!hasCfg || cfg.Err == nil) {
+			continue
+		}
+
+		nt := NodeTrace{Node: node, Sections: carried, Missing: subtract(expected, carried), Stale: subtract(carried, expected)}
+		if hasCfg && cfg.Err != nil {
+			nt.Reason = fmt.Sprintf("config cannot be rendered: %v", cfg.Err)
+		} else if len(nt.Missing) > 0 || len(nt.Stale) > 0 {
+			nt.Reason = traceReason(fetched, node, nnc, cfg)
+		}
+		trace.Nodes = append(trace.Nodes, nt)
+	}
+
+	sort.Slice(trace.Nodes, func(i, j int) bool { return trace.Nodes[i].Node < trace.Nodes[j].Node })
+	return trace, nil
+}
+
+// intentObjects returns the fetched intent CRDs by lower-case kind.
+func intentObjects(fetched *resolver.FetchedResources) map[string][]metav1.Object {
+	return map[string][]metav1.Object{
+		"vrf":                objectsOf(fetched.VRFs),
+		"network":            objectsOf(fetched.Networks),
+		"destination":        objectsOf(fetched.Destinations),
+		"layer2attachment":   objectsOf(fetched.Layer2Attachments),
+		"inbound":            objectsOf(fetched.Inbounds),
+		"outbound":           objectsOf(fetched.Outbounds),
+		"podnetwork":         objectsOf(fetched.PodNetworks),
+		"bgppeering":         objectsOf(fetched.BGPPeerings),
+		"collector":          objectsOf(fetched.Collectors),
+		"trafficmirror":      objectsOf(fetched.TrafficMirrors),
+		"announcementpolicy": objectsOf(fetched.AnnouncementPolicies),
+		"nodeattachment":     objectsOf(fetched.NodeAttachments),
+	}
+}
+
+func objectsOf[T any, PT interface {
+	*T
+	metav1.Object
+}](items []T) []metav1.Object {
+	out := make([]metav1.Object, 0, len(items))
+	for i := range items {
+		out = append(out, PT(&items[i]))
+	}
+	return out
+}
+
+// traceReason explains why the NodeNetworkConfig of a node differs from its
+// rendered config.
+func traceReason(fetched *resolver.FetchedResources, node string, nnc *networkv1alpha1.NodeNetworkConfig, cfg *RenderedConfig) string {
+	switch {
+	case cfg == nil:
+		return "node no longer exists, its NodeNetworkConfig is pending deletion"
+	case nnc == nil:
+		return "node has no NodeNetworkConfig yet"
+	}
+
+	for i := range fetched.Nodes {
+		if fetched.Nodes[i].Name == node && fetched.Nodes[i].Labels[networkv1alpha1.QuarantinedLabel] != "" {
+			return "node is quarantined"
+		}
+	}
+
+	switch {
+	case nnc.Spec.Revision != cfg.Spec.Revision && nnc.Status.ConfigStatus == operator.StatusInvalid:
+		return fmt.Sprintf("rollout pending: the node's config %s is invalid: %s", shortRevision(nnc.Spec.Revision), nnc.Status.ErrorMessage)
+	case nnc.Spec.Revision != cfg.Spec.Revision:
+		return fmt.Sprintf("rollout pending: the node has config %s, the intent CRDs render %s",
+			shortRevision(nnc.Spec.Revision), shortRevision(cfg.Spec.Revision))
+	default:
+		return "the node's config is up to date, but its origins annotation is missing or outdated"
+	}
+}
+
+// sectionsOf returns the sorted section keys whose origins include Kind/name.
+func sectionsOf(origins map[string]string, kind, name string) []string {
+	var sections []string
+	for section, sources := range origins {
+		for _, src := range strings.Split(sources, ",") {
+			k, n, _ := strings.Cut(strings.TrimSpace(src), "/")
+			if strings.EqualFold(k, kind) && n == name {
+				sections = append(sections, section)
+				break
+			}
+		}
+	}
+	sort.Strings(sections)
+	return sections
+}
+
+// subtract returns the items of a not in b, keeping the order of a.
+func subtract(a, b []string) []string {
+	var out []string
+	for _, item := range a {
+		found := false
+		for _, other := range b {
+			if other == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// ParseOrigins returns the origins recorded on a NodeNetworkConfig by
+// SetOriginsAnnotation.
+func ParseOrigins(annotations map[string]string) map[string]string {
+	raw, ok := annotations[OriginsAnnotation]
+	if !ok {
+		return nil
+	}
+	var origins map[string]string
+	if err := json.Unmarshal([]byte(raw), &origins); err != nil {
+		return nil
+	}
+	return origins
+}
+
+func shortRevision(revision string) string {
+	if len(revision) > revisionNameLength {
+		return revision[:revisionNameLength]
+	}
+	return revision
+}
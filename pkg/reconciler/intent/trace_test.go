@@ -0,0 +1,116 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package intent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	networkv1alpha1 "github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+	nc "github.com/telekom/das-schiff-network-operator/api/v1alpha1/network-connector"
+	"github.com/telekom/das-schiff-network-operator/pkg/reconciler/intent/resolver"
+)
+
+func traceFixture() *resolver.FetchedResources {
+	fetched := planFixture()
+	fetched.Layer2Attachments = []nc.Layer2Attachment{*makeL2A("l2a-100", "net-100", destSelector("m2m"), &metav1.LabelSelector{
+		MatchLabels: map[string]string{"rack": "a"},
+	})}
+	return fetched
+}
+
+// appliedNNCs returns the NodeNetworkConfigs the reconciler writes for fetched.
+func appliedNNCs(t *testing.T, fetched *resolver.FetchedResources) []networkv1alpha1.NodeNetworkConfig {
+	t.Helper()
+	configs, _, err := Render(context.Background(), fetched, logf.Log.WithName("test-trace"))
+	require.NoError(t, err)
+
+	var nncs []networkv1alpha1.NodeNetworkConfig
+	for node, cfg := range configs {
+		nnc := networkv1alpha1.NodeNetworkConfig{ObjectMeta: metav1.ObjectMeta{Name: node}, Spec: *cfg.Spec}
+		SetOriginsAnnotation(&nnc, cfg.Origins)
+		nncs = append(nncs, nnc)
+	}
+	return nncs
+}
+
+func TestTraceSource_UpToDate(t *testing.T) {
+	fetched := traceFixture()
+
+	trace, err := TraceSource(context.Background(), fetched, appliedNNCs(t, fetched), "layer2attachment/l2a-100", logf.Log.WithName("test-trace"))
+	require.NoError(t, err)
+
+	assert.True(t, trace.Found)
+	assert.False(t, trace.DryRun)
+	require.Len(t, trace.Nodes, 1)
+	assert.Equal(t, "node-1", trace.Nodes[0].Node)
+	assert.Equal(t, []string{"fabricVRFs/m2m", "layer2s/100"}, trace.Nodes[0].Sections)
+	assert.Empty(t, trace.Nodes[0].Missing)
+	assert.Empty(t, trace.Nodes[0].Reason)
+}
+
+func TestTraceSource_MissingAndStale(t *testing.T) {
+	fetched := traceFixture()
+	nncs := appliedNNCs(t, fetched)
+
+	// The L2A moves from rack a to rack b: node-2 has not been updated yet and
+	// node-1 still carries the old sections.
+	fetched.Layer2Attachments[0].Spec.NodeSelector.MatchLabels["rack"] = "b"
+
+	trace, err := TraceSource(context.Background(), fetched, nncs, "Layer2Attachment/l2a-100", logf.Log.WithName("test-trace"))
+	require.NoError(t, err)
+	require.Len(t, trace.Nodes, 2)
+
+	assert.Equal(t, "node-1", trace.Nodes[0].Node)
+	assert.Equal(t, []string{"fabricVRFs/m2m", "layer2s/100"}, trace.Nodes[0].Stale)
+	assert.Contains(t, trace.Nodes[0].Reason, "rollout pending")
+
+	assert.Equal(t, "node-2", trace.Nodes[1].Node)
+	assert.Empty(t, trace.Nodes[1].Sections)
+	assert.Equal(t, []string{"fabricVRFs/m2m", "layer2s/100"}, trace.Nodes[1].Missing)
+	assert.Contains(t, trace.Nodes[1].Reason, "rollout pending")
+}
+
+func TestTraceSource_NoNodeNetworkConfig(t *testing.T) {
+	trace, err := TraceSource(context.Background(), traceFixture(), nil, "Layer2Attachment/l2a-100", logf.Log.WithName("test-trace"))
+	require.NoError(t, err)
+	require.Len(t, trace.Nodes, 1)
+	assert.Equal(t, "node has no NodeNetworkConfig yet", trace.Nodes[0].Reason)
+}
+
+func TestTraceSource_DryRunAndUnknown(t *testing.T) {
+	fetched := traceFixture()
+	fetched.Layer2Attachments[0].Annotations = map[string]string{nc.AnnotationDryRun: "true"}
+
+	trace, err := TraceSource(context.Background(), fetched, nil, "Layer2Attachment/l2a-100", logf.Log.WithName("test-trace"))
+	require.NoError(t, err)
+	assert.True(t, trace.Found)
+	assert.True(t, trace.DryRun)
+	assert.Empty(t, trace.Nodes)
+
+	trace, err = TraceSource(context.Background(), fetched, nil, "Layer2Attachment/unknown", logf.Log.WithName("test-trace"))
+	require.NoError(t, err)
+	assert.False(t, trace.Found)
+
+	_, err = TraceSource(context.Background(), fetched, nil, "l2a-100", logf.Log.WithName("test-trace"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,234 @@
+// Package nncdiff computes the section-level differences between two
+// NodeNetworkConfigSpecs: the Layer2s, VRFs and BGP peers that are added,
+// removed or changed, and for changed sections the values that differ. Sections are identified by the same keys the intent
+// reconciler uses for its origins annotation ("layer2s/<key>",
+// "fabricVRFs/<vrf>", "localVRFs/<vrf>", "clusterVRF" and
+// "<vrf section>/bgpPeers/<peer>"), so each change can be traced back to the
+// intent CRDs it originates from.
+package nncdiff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+// Op is the kind of change to a section.
+type Op string
+
+const (
+	// Added means the section only exists in the new spec.
+	Added Op = "added"
+	// Removed means the section only exists in the old spec.
+	Removed Op = "removed"
+	// Changed means the section exists in both specs with different content.
+	Changed Op = "changed"
+)
+
+const (
+	sectionLayer2s    = "layer2s"
+	sectionFabricVRFs = "fabricVRFs"
+	sectionLocalVRFs  = "localVRFs"
+	sectionClusterVRF = "clusterVRF"
+	sectionBGPPeers   = "bgpPeers"
+)
+
+// Config is a NodeNetworkConfigSpec together with its origins
+// (section key → source CRDs). Origins may be nil.
+type Config struct {
+	Spec    *v1alpha1.NodeNetworkConfigSpec
+	Origins map[string]string
+}
+
+// Change is a single added, removed or changed section.
+type Change struct {
+	Op Op
+	// Section is the section key, e.g. "layer2s/100" or "fabricVRFs/m2m/bgpPeers/10.0.0.0/24".
+	Section string
+	// Origin lists the source CRDs of the section: of the new spec for added
+	// and changed sections, of the old spec for removed ones. Empty if unknown.
+	Origin string
+	// Fields are the differing values of a changed section, sorted by path.
+	// BGP peers are sections of their own and not part of their VRF's fields.
+	Fields []Field
+}
+
+// PeerKey identifies a BGP peer within a VRF: its address, its listen range,
+// or its remote AS number for peers with neither.
+func PeerKey(peer *v1alpha1.BGPPeer) string {
+	switch {
+	case peer.Address != nil:
+		return *peer.Address
+	case peer.ListenRange != nil:
+		return *peer.ListenRange
+	default:
+		return fmt.Sprintf("as%d", peer.RemoteASN)
+	}
+}
+
+// PeerSection returns the section key of a BGP peer in the given VRF section.
+func PeerSection(vrfSection string, peer *v1alpha1.BGPPeer) string {
+	return vrfSection + "/" + sectionBGPPeers + "/" + PeerKey(peer)
+}
+
+// Diff returns the changes from one config to another, sorted by section key.
+// A nil spec is treated as empty, so diffing against a node without config
+// lists all of its sections as added (or removed). The revision is ignored.
+func Diff(from, to Config) []Change {
+	d := &differ{from: from, to: to}
+	oldSpec, newSpec := specOrEmpty(from.Spec), specOrEmpty(to.Spec)
+
+	diffMap(d, sectionLayer2s, oldSpec.Layer2s, newSpec.Layer2s, func(section string, o, n v1alpha1.Layer2) {
+		d.change(section, o, n)
+	})
+	diffMap(d, sectionFabricVRFs, oldSpec.FabricVRFs, newSpec.FabricVRFs, func(section string, o, n v1alpha1.FabricVRF) {
+		d.diffPeers(section, o.BGPPeers, n.BGPPeers)
+		o.BGPPeers, n.BGPPeers = nil, nil
+		d.change(section, o, n)
+	})
+	diffMap(d, sectionLocalVRFs, oldSpec.LocalVRFs, newSpec.LocalVRFs, func(section string, o, n v1alpha1.VRF) {
+		d.diffVRF(section, &o, &n)
+	})
+
+	switch {
+	case oldSpec.ClusterVRF == nil && newSpec.ClusterVRF != nil:
+		d.addVRF(Added, sectionClusterVRF, newSpec.ClusterVRF)
+	case oldSpec.ClusterVRF != nil && newSpec.ClusterVRF == nil:
+		d.addVRF(Removed, sectionClusterVRF, oldSpec.ClusterVRF)
+	case oldSpec.ClusterVRF != nil:
+		d.diffVRF(sectionClusterVRF, oldSpec.ClusterVRF, newSpec.ClusterVRF)
+	}
+
+	sort.Slice(d.changes, func(i, j int) bool { return d.changes[i].Section < d.changes[j].Section })
+	return d.changes
+}
+
+type differ struct {
+	from, to Config
+	changes  []Change
+}
+
+func (d *differ) add(op Op, section string) {
+	origins := d.to.Origins
+	if op == Removed {
+		origins = d.from.Origins
+	}
+	d.changes = append(d.changes, Change{Op: op, Section: section, Origin: Origin(origins, section)})
+}
+
+// change reports the section as changed if the two versions differ.
+func (d *differ) change(section string, o, n any) {
+	fields := diffFields(o, n)
+	if len(fields) == 0 {
+		return
+	}
+	d.add(Changed, section)
+	d.changes[len(d.changes)-1].Fields = fields
+}
+
+// addVRF reports a whole VRF and each of its BGP peers as added or removed.
+func (d *differ) addVRF(op Op, section string, vrf *v1alpha1.VRF) {
+	d.add(op, section)
+	for key := range keyPeers(section, vrf.BGPPeers) {
+		d.add(op, key)
+	}
+}
+
+// diffVRF compares two versions of a VRF: BGP peers are compared one by one,
+// all other changes are reported on the VRF itself.
+func (d *differ) diffVRF(section string, o, n *v1alpha1.VRF) {
+	d.diffPeers(section, o.BGPPeers, n.BGPPeers)
+	oCopy, nCopy := *o, *n
+	oCopy.BGPPeers, nCopy.BGPPeers = nil, nil
+	d.change(section, oCopy, nCopy)
+}
+
+func (d *differ) diffPeers(section string, from, to []v1alpha1.BGPPeer) {
+	oldPeers, newPeers := keyPeers(section, from), keyPeers(section, to)
+	for key, peer := range newPeers {
+		oldPeer, ok := oldPeers[key]
+		if !ok {
+			d.add(Added, key)
+			continue
+		}
+		d.change(key, oldPeer, peer)
+	}
+	for key := range oldPeers {
+		if _, ok := newPeers[key]; !ok {
+			d.add(Removed, key)
+		}
+	}
+}
+
+// diffMap reports the keys only present in one of the maps and calls diffEntry
+// for the keys present in both.
+func diffMap[T any](d *differ, prefix string, from, to map[string]T, diffEntry func(section string, o, n T)) {
+	for k := range to {
+		section := prefix + "/" + k
+		o, ok := from[k]
+		if !ok {
+			d.addEntry(Added, section, to[k])
+			continue
+		}
+		diffEntry(section, o, to[k])
+	}
+	for k := range from {
+		if _, ok := to[k]; !ok {
+			d.addEntry(Removed, prefix+"/"+k, from[k])
+		}
+	}
+}
+
+func (d *differ) addEntry(op Op, section string, entry any) {
+	switch v := entry.(type) {
+	case v1alpha1.FabricVRF:
+		d.addVRF(op, section, &v.VRF)
+	case v1alpha1.VRF:
+		d.addVRF(op, section, &v)
+	default:
+		d.add(op, section)
+	}
+}
+
+// keyPeers maps the peers of a VRF by section key. Peers sharing a key are
+// numbered in order so no peer is lost.
+func keyPeers(section string, peers []v1alpha1.BGPPeer) map[string]v1alpha1.BGPPeer {
+	out := make(map[string]v1alpha1.BGPPeer, len(peers))
+	for i := range peers {
+		key := PeerSection(section, &peers[i])
+		for n := 2; ; n++ {
+			if _, dup := out[key]; !dup {
+				break
+			}
+			key = fmt.Sprintf("%s#%d", PeerSection(section, &peers[i]), n)
+		}
+		out[key] = peers[i]
+	}
+	return out
+}
+
+// Origin returns the source CRDs of a section. Sections without an origin of
+// their own inherit the origin of the closest enclosing section, e.g. a BGP
+// peer the origin of its VRF.
+func Origin(origins map[string]string, section string) string {
+	for key := section; key != ""; {
+		if origin, ok := origins[key]; ok {
+			return origin
+		}
+		i := strings.LastIndex(key, "/")
+		if i < 0 {
+			break
+		}
+		key = key[:i]
+	}
+	return ""
+}
+
+func specOrEmpty(spec *v1alpha1.NodeNetworkConfigSpec) *v1alpha1.NodeNetworkConfigSpec {
+	if spec == nil {
+		return &v1alpha1.NodeNetworkConfigSpec{}
+	}
+	return spec
+}
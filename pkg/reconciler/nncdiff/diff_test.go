@@ -0,0 +1,165 @@
+package nncdiff
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+func strptr(s string) *string { return &s }
+
+func baseSpec() *v1alpha1.NodeNetworkConfigSpec {
+	return &v1alpha1.NodeNetworkConfigSpec{
+		Revision: "rev1",
+		Layer2s: map[string]v1alpha1.Layer2{
+			"100": {VNI: 10100, VLAN: 100, MTU: 1500},
+		},
+		FabricVRFs: map[string]v1alpha1.FabricVRF{
+			"m2m": {
+				VNI: 2000,
+				VRF: v1alpha1.VRF{
+					BGPPeers: []v1alpha1.BGPPeer{
+						{ListenRange: strptr("10.0.0.0/24"), RemoteASN: 65000},
+					},
+				},
+			},
+		},
+		ClusterVRF: &v1alpha1.VRF{
+			BGPPeers: []v1alpha1.BGPPeer{{RemoteASN: 65001}},
+		},
+	}
+}
+
+func TestDiff_Identical(t *testing.T) {
+	to := baseSpec()
+	to.Revision = "rev2"
+	if changes := Diff(Config{Spec: baseSpec()}, Config{Spec: to}); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestDiff_AddedRemovedAndChanged(t *testing.T) {
+	to := baseSpec()
+	to.Layer2s["200"] = v1alpha1.Layer2{VNI: 10200, VLAN: 200, MTU: 1500}
+	delete(to.Layer2s, "100")
+	m2m := to.FabricVRFs["m2m"]
+	m2m.BGPPeers = []v1alpha1.BGPPeer{{ListenRange: strptr("10.0.0.0/24"), RemoteASN: 65100}}
+	m2m.EVPNExportRouteTargets = []string{"65000:2000"}
+	to.FabricVRFs["m2m"] = m2m
+	to.LocalVRFs = map[string]v1alpha1.VRF{
+		"s-1234": {BGPPeers: []v1alpha1.BGPPeer{{Address: strptr("192.0.2.1"), RemoteASN: 65002}}},
+	}
+	to.ClusterVRF = nil
+
+	origins := map[string]string{
+		"layer2s/200":      "Layer2Attachment/l2a-200",
+		"fabricVRFs/m2m":   "BGPPeering/bp, Inbound/ib",
+		"localVRFs/s-1234": "Inbound/ib",
+		"clusterVRF":       "BGPPeering/loopback",
+		"layer2s/100":      "Layer2Attachment/l2a-100",
+	}
+
+	changes := Diff(Config{Spec: baseSpec(), Origins: origins}, Config{Spec: to, Origins: origins})
+
+	want := []Change{
+		{Op: Removed, Section: "clusterVRF", Origin: "BGPPeering/loopback"},
+		{Op: Removed, Section: "clusterVRF/bgpPeers/as65001", Origin: "BGPPeering/loopback"},
+		{Op: Changed, Section: "fabricVRFs/m2m", Origin: "BGPPeering/bp, Inbound/ib"},
+		{Op: Changed, Section: "fabricVRFs/m2m/bgpPeers/10.0.0.0/24", Origin: "BGPPeering/bp, Inbound/ib"},
+		{Op: Removed, Section: "layer2s/100", Origin: "Layer2Attachment/l2a-100"},
+		{Op: Added, Section: "layer2s/200", Origin: "Layer2Attachment/l2a-200"},
+		{Op: Added, Section: "localVRFs/s-1234", Origin: "Inbound/ib"},
+		{Op: Added, Section: "localVRFs/s-1234/bgpPeers/192.0.2.1", Origin: "Inbound/ib"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %v", len(changes), len(want), changes)
+	}
+	for i := range want {
+		got := changes[i]
+		if got.Op != want[i].Op || got.Section != want[i].Section || got.Origin != want[i].Origin {
+			t.Errorf("change %d = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	wantFields := map[string][]Field{
+		"fabricVRFs/m2m":                      {{Op: Added, Path: "evpnExportRouteTargets", To: `["65000:2000"]`}},
+		"fabricVRFs/m2m/bgpPeers/10.0.0.0/24": {{Op: Changed, Path: "remoteAsn", From: "65000", To: "65100"}},
+	}
+	for _, c := range changes {
+		if c.Op != Changed {
+			continue
+		}
+		if fmt.Sprint(c.Fields) != fmt.Sprint(wantFields[c.Section]) {
+			t.Errorf("fields of %s = %+v, want %+v", c.Section, c.Fields, wantFields[c.Section])
+		}
+	}
+}
+
+func TestDiffFields_Lists(t *testing.T) {
+	from := v1alpha1.VRF{
+		StaticRoutes: []v1alpha1.StaticRoute{
+			{Prefix: "10.0.0.0/8"},
+			{Prefix: "10.1.0.0/16", NextHop: &v1alpha1.NextHop{Address: strptr("192.0.2.1")}},
+		},
+		Loopbacks: map[string]v1alpha1.Loopback{"lo.a": {IPAddresses: []string{"192.0.2.10", "192.0.2.11"}}},
+	}
+	to := v1alpha1.VRF{
+		StaticRoutes: []v1alpha1.StaticRoute{
+			{Prefix: "10.1.0.0/16", NextHop: &v1alpha1.NextHop{Address: strptr("192.0.2.2")}},
+			{Prefix: "10.2.0.0/16"},
+		},
+		Loopbacks: map[string]v1alpha1.Loopback{"lo.a": {IPAddresses: []string{"192.0.2.11", "192.0.2.12"}}},
+	}
+
+	want := []Field{
+		{Op: Removed, Path: "loopbacks.lo.a.ipAddresses", From: "192.0.2.10"},
+		{Op: Added, Path: "loopbacks.lo.a.ipAddresses", To: "192.0.2.12"},
+		{Op: Removed, Path: "staticRoutes[10.0.0.0/8]", From: `{"prefix":"10.0.0.0/8"}`},
+		{Op: Changed, Path: "staticRoutes[10.1.0.0/16].nextHop.address", From: "192.0.2.1", To: "192.0.2.2"},
+		{Op: Added, Path: "staticRoutes[10.2.0.0/16]", To: `{"prefix":"10.2.0.0/16"}`},
+	}
+	if got := diffFields(from, to); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("diffFields = %+v, want %+v", got, want)
+	}
+	if got := diffFields(v1alpha1.VRF{}, v1alpha1.VRF{StaticRoutes: []v1alpha1.StaticRoute{}}); len(got) != 0 {
+		t.Errorf("expected empty and nil lists to be equal, got %+v", got)
+	}
+}
+
+func TestDiffFields_RedactsPasswords(t *testing.T) {
+	from := v1alpha1.BGPPeer{Address: strptr("10.0.0.1"), Password: strptr("old-secret")}
+	to := v1alpha1.BGPPeer{Address: strptr("10.0.0.1"), Password: strptr("new-secret")}
+
+	want := []Field{{Op: Changed, Path: "password", From: Redacted, To: Redacted}}
+	if got := diffFields(from, to); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("diffFields = %+v, want %+v", got, want)
+	}
+
+	want = []Field{{Op: Added, Path: "password", To: Redacted}}
+	if got := diffFields(v1alpha1.BGPPeer{Address: strptr("10.0.0.1")}, to); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("diffFields = %+v, want %+v", got, want)
+	}
+}
+
+func TestDiff_NilSpecs(t *testing.T) {
+	changes := Diff(Config{}, Config{Spec: baseSpec()})
+	for _, c := range changes {
+		if c.Op != Added {
+			t.Errorf("expected only added sections, got %+v", c)
+		}
+	}
+	if len(changes) != 5 {
+		t.Errorf("expected 5 added sections, got %v", changes)
+	}
+}
+
+func TestKeyPeers_DuplicateKeys(t *testing.T) {
+	peers := keyPeers("clusterVRF", []v1alpha1.BGPPeer{{RemoteASN: 65000}, {RemoteASN: 65000}})
+	if _, ok := peers["clusterVRF/bgpPeers/as65000"]; !ok {
+		t.Errorf("missing first peer: %v", peers)
+	}
+	if _, ok := peers["clusterVRF/bgpPeers/as65000#2"]; !ok {
+		t.Errorf("missing second peer: %v", peers)
+	}
+}
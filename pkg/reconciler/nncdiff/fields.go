@@ -0,0 +1,222 @@
+package nncdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces the values of BGP peer passwords in the fields.
+const Redacted = "<redacted>"
+
+// Field is a single added, removed or changed value within a changed section.
+type Field struct {
+	Op Op
+	// Path is the JSON path of the value within the section, e.g. "irb.ipAddresses"
+	// or "staticRoutes[10.0.0.0/8].nextHop". List items are identified by their
+	// key field (prefix, address, ...) if they have one.
+	Path string
+	// From and To are the compact JSON values; From is empty for added values,
+	// To for removed ones.
+	From, To string
+}
+
+// listKeys are the fields identifying the items of the lists in a
+// NodeNetworkConfigSpec, in order of preference.
+var listKeys = []string{"prefix", "address", "listenRange", "fromVrf", "name", "cidr"}
+
+// diffFields returns the differences between two values of the same type,
+// compared by their JSON representation. Null and empty values are equal.
+// Passwords are redacted.
+func diffFields(from, to any) []Field {
+	var f fieldDiffer
+	f.walk("", toJSON(from), toJSON(to))
+	for i := range f.fields {
+		redactPassword(&f.fields[i])
+	}
+	return f.fields
+}
+
+// redactPassword replaces the values of a password field with Redacted, so a
+// changed password is still shown as changed.
+func redactPassword(field *Field) {
+	if field.Path != "password" && !strings.HasSuffix(field.Path, ".password") {
+		return
+	}
+	if field.From != "" {
+		field.From = Redacted
+	}
+	if field.To != "" {
+		field.To = Redacted
+	}
+}
+
+type fieldDiffer struct {
+	fields []Field
+}
+
+func (f *fieldDiffer) walk(path string, from, to any) {
+	switch {
+	case isEmpty(from) && isEmpty(to):
+		return
+	case isEmpty(from):
+		f.fields = append(f.fields, Field{Op: Added, Path: path, To: compact(to)})
+		return
+	case isEmpty(to):
+		f.fields = append(f.fields, Field{Op: Removed, Path: path, From: compact(from)})
+		return
+	}
+
+	fromMap, fromIsMap := from.(map[string]any)
+	toMap, toIsMap := to.(map[string]any)
+	if fromIsMap && toIsMap {
+		for _, k := range unionKeys(fromMap, toMap) {
+			f.walk(joinPath(path, k), fromMap[k], toMap[k])
+		}
+		return
+	}
+
+	fromList, fromIsList := from.([]any)
+	toList, toIsList := to.([]any)
+	if fromIsList && toIsList {
+		f.walkList(path, fromList, toList)
+		return
+	}
+
+	if compact(from) != compact(to) {
+		f.fields = append(f.fields, Field{Op: Changed, Path: path, From: compact(from), To: compact(to)})
+	}
+}
+
+// walkList compares list items by their key field if all items have the same
+// one, and as a multiset of values otherwise.
+func (f *fieldDiffer) walkList(path string, from, to []any) {
+	if key := commonListKey(from, to); key != "" {
+		fromItems, toItems := keyItems(from, key), keyItems(to, key)
+		for _, k := range unionKeys(fromItems, toItems) {
+			f.walk(fmt.Sprintf("%s[%s]", path, k), fromItems[k], toItems[k])
+		}
+		return
+	}
+
+	remaining := make(map[string]int, len(from))
+	for _, item := range from {
+		remaining[compact(item)]++
+	}
+	var added []string
+	for _, item := range to {
+		v := compact(item)
+		if remaining[v] > 0 {
+			remaining[v]--
+			continue
+		}
+		added = append(added, v)
+	}
+	for _, item := range from {
+		v := compact(item)
+		if remaining[v] > 0 {
+			remaining[v]--
+			f.fields = append(f.fields, Field{Op: Removed, Path: path, From: v})
+		}
+	}
+	for _, v := range added {
+		f.fields = append(f.fields, Field{Op: Added, Path: path, To: v})
+	}
+}
+
+// commonListKey returns the first list key all items of both lists have, or
+// "" if there is none.
+func commonListKey(from, to []any) string {
+	for _, key := range listKeys {
+		all := true
+		for _, item := range append(append([]any{}, from...), to...) {
+			m, ok := item.(map[string]any)
+			if !ok {
+				return ""
+			}
+			if _, ok := m[key].(string); !ok {
+				all = false
+				break
+			}
+		}
+		if all {
+			return key
+		}
+	}
+	return ""
+}
+
+// keyItems maps list items by their key field. Items sharing a key are
+// numbered in order so no item is lost.
+func keyItems(items []any, key string) map[string]any {
+	out := make(map[string]any, len(items))
+	for _, item := range items {
+		k := item.(map[string]any)[key].(string) //nolint:forcetypeassert // checked by commonListKey
+		id := k
+		for n := 2; ; n++ {
+			if _, dup := out[id]; !dup {
+				break
+			}
+			id = fmt.Sprintf("%s#%d", k, n)
+		}
+		out[id] = item
+	}
+	return out
+}
+
+func toJSON(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return string(data)
+	}
+	return out
+}
+
+func compact(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+func isEmpty(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(t) == 0
+	case []any:
+		return len(t) == 0
+	}
+	return false
+}
+
+func unionKeys[T any](a, b map[string]T) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
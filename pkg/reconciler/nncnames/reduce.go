@@ -10,6 +10,7 @@ package nncnames
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
 	"github.com/telekom/das-schiff-network-operator/pkg/vrfname"
@@ -96,6 +97,12 @@ func reduceVRFRefs(v *v1alpha1.VRF) {
 			r := vrfname.Reduce(*v.StaticRoutes[i].NextHop.Vrf)
 			v.StaticRoutes[i].NextHop.Vrf = &r
 		}
+		for j := range v.StaticRoutes[i].NextHops {
+			if nh := &v.StaticRoutes[i].NextHops[j]; nh.Vrf != nil {
+				r := vrfname.Reduce(*nh.Vrf)
+				nh.Vrf = &r
+			}
+		}
 	}
 	for i := range v.PolicyRoutes {
 		if v.PolicyRoutes[i].NextHop.Vrf != nil {
@@ -104,3 +111,31 @@ func reduceVRFRefs(v *v1alpha1.VRF) {
 		}
 	}
 }
+
+// ReduceOrigins returns a copy of an origins map (NNC section key → source
+// CRDs) whose keys use the reduced VRF names, so they match the sections of a
+// spec reduced by Reduce.
+func ReduceOrigins(origins map[string]string) map[string]string {
+	if origins == nil {
+		return nil
+	}
+	out := make(map[string]string, len(origins))
+	for k, v := range origins {
+		out[reduceOriginKey(k)] = v
+	}
+	return out
+}
+
+// reduceOriginKey reduces the VRF names in a section key: the VRF map key
+// ("fabricVRFs/<vrf>/...", "localVRFs/<vrf>/...") and the next-hop VRF of a
+// policy route ("<vrf section>/policyRoutes/<vrf>").
+func reduceOriginKey(key string) string {
+	parts := strings.Split(key, "/")
+	if len(parts) > 1 && (parts[0] == "fabricVRFs" || parts[0] == "localVRFs") {
+		parts[1] = vrfname.Reduce(parts[1])
+	}
+	if n := len(parts); n > 1 && parts[n-2] == "policyRoutes" {
+		parts[n-1] = vrfname.Reduce(parts[n-1])
+	}
+	return strings.Join(parts, "/")
+}
@@ -40,6 +40,7 @@ func TestReduce_RewritesKeysAndReferences(t *testing.T) {
 				},
 				StaticRoutes: []v1alpha1.StaticRoute{
 					{Prefix: "0.0.0.0/0", NextHop: &v1alpha1.NextHop{Vrf: strptr(longName)}},
+					{Prefix: "::/0", NextHops: []v1alpha1.StaticRouteNextHop{{NextHop: v1alpha1.NextHop{Vrf: strptr(longName)}}}},
 				},
 			},
 		},
@@ -78,6 +79,9 @@ func TestReduce_RewritesKeysAndReferences(t *testing.T) {
 	if got := *local.StaticRoutes[0].NextHop.Vrf; got != reduced {
 		t.Errorf("LocalVRF static route NextHop.Vrf = %q, want %q", got, reduced)
 	}
+	if got := *local.StaticRoutes[1].NextHops[0].Vrf; got != reduced {
+		t.Errorf("LocalVRF static route NextHops[0].Vrf = %q, want %q", got, reduced)
+	}
 }
 
 func TestReduce_ShortNamesUnchanged(t *testing.T) {
@@ -143,3 +147,30 @@ func keysFabric(m map[string]v1alpha1.FabricVRF) []string {
 	}
 	return out
 }
+
+func TestReduceOrigins_RewritesVRFKeys(t *testing.T) {
+	reduced := vrfname.Reduce(longName)
+	origins := map[string]string{
+		"layer2s/100":                            "Layer2Attachment/l2a",
+		"fabricVRFs/" + longName:                 "Inbound/ib",
+		"fabricVRFs/" + longName + "/bgpPeers/x": "BGPPeering/bp",
+		"clusterVRF/policyRoutes/" + longName:    "Inbound/ib",
+	}
+
+	got := ReduceOrigins(origins)
+
+	want := map[string]string{
+		"layer2s/100":                           "Layer2Attachment/l2a",
+		"fabricVRFs/" + reduced:                 "Inbound/ib",
+		"fabricVRFs/" + reduced + "/bgpPeers/x": "BGPPeering/bp",
+		"clusterVRF/policyRoutes/" + reduced:    "Inbound/ib",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d origins, want %d: %v", len(got), len(want), got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("origin %q = %q, want %q", k, got[k], v)
+		}
+	}
+}
@@ -359,6 +359,11 @@ func listRevisions(ctx context.Context, c client.Client) (*v1alpha1.NetworkConfi
 		return nil, fmt.Errorf("error listing NetworkConfigRevisions: %w", err)
 	}
 
+	// revisions of the intent reconciler are rolled out by it
+	revisions.Items = slices.DeleteFunc(revisions.Items, func(r v1alpha1.NetworkConfigRevision) bool {
+		return r.Labels[v1alpha1.ManagedByLabel] == v1alpha1.ManagedByIntent
+	})
+
 	slices.SortFunc(revisions.Items, lessRevision)
 
 	return revisions, nil
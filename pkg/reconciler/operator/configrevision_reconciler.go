@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 	"time"
@@ -70,6 +71,14 @@ type ConfigRevisionReconciler struct {
 
 	importMode ImportMode
 
+	healthMonitoring HealthMonitoring
+
+	revisionHistory RevisionHistory
+
+	// quarantineAfter is the number of consecutive failed revisions after which
+	// a node is quarantined. Zero disables the quarantine.
+	quarantineAfter int
+
 	// mirrorAllocCache memoises the per-node loopback allocation so that building
 	// NodeNetworkConfigs node-by-node during a rollout does not recompute it (and
 	// re-list all configs) for every single node.
@@ -92,17 +101,41 @@ func (crr *ConfigRevisionReconciler) Reconcile(ctx context.Context) {
 	crr.debouncer.Debounce(ctx)
 }
 
-// // NewNodeConfigReconciler creates new reconciler that creates NodeConfig objects.
-func NewNodeConfigReconciler(clusterClient client.Client, logger logr.Logger, apiTimeout, configTimeout, preconfigTimeout time.Duration, s *runtime.Scheme, maxUpdating int, importMode ImportMode) (*ConfigRevisionReconciler, error) {
+// NodeConfigReconcilerOptions contains the settings of the ConfigRevisionReconciler.
+type NodeConfigReconcilerOptions struct {
+	// APITimeout is the timeout of the API server requests.
+	APITimeout time.Duration
+	// ConfigTimeout is the time a node has to provision a config.
+	ConfigTimeout time.Duration
+	// PreconfigTimeout is the time a node has to provision its first config.
+	PreconfigTimeout time.Duration
+	// MaxUpdating is the number of nodes updated at the same time.
+	MaxUpdating int
+	// ImportMode controls how VRF imports are rendered.
+	ImportMode ImportMode
+	// HealthMonitoring rolls back revisions that degrade node health.
+	HealthMonitoring HealthMonitoring
+	// RevisionHistory controls how many superseded revisions are kept.
+	RevisionHistory RevisionHistory
+	// QuarantineAfter is the number of consecutive failed revisions after
+	// which a node is quarantined. Zero disables the quarantine.
+	QuarantineAfter int
+}
+
+// NewNodeConfigReconciler creates new reconciler that creates NodeConfig objects.
+func NewNodeConfigReconciler(clusterClient client.Client, logger logr.Logger, s *runtime.Scheme, opts NodeConfigReconcilerOptions) (*ConfigRevisionReconciler, error) {
 	reconciler := &ConfigRevisionReconciler{
 		logger:           logger,
-		apiTimeout:       apiTimeout,
-		configTimeout:    configTimeout,
-		preconfigTimeout: preconfigTimeout,
+		apiTimeout:       opts.APITimeout,
+		configTimeout:    opts.ConfigTimeout,
+		preconfigTimeout: opts.PreconfigTimeout,
 		client:           clusterClient,
 		scheme:           s,
-		maxUpdating:      maxUpdating,
-		importMode:       importMode,
+		maxUpdating:      opts.MaxUpdating,
+		importMode:       opts.ImportMode,
+		healthMonitoring: opts.HealthMonitoring,
+		revisionHistory:  opts.RevisionHistory,
+		quarantineAfter:  opts.QuarantineAfter,
 	}
 
 	cfg, err := config.LoadConfig()
@@ -132,6 +165,19 @@ func (crr *ConfigRevisionReconciler) reconcileDebounced(ctx context.Context) err
 		return fmt.Errorf("error listing configs: %w", err)
 	}
 
+	// invalidate the revisions newer than the one a rollback was requested to
+	if err := crr.processRollbackRequest(ctx, revisions.Items); err != nil {
+		return fmt.Errorf("error processing rollback request: %w", err)
+	}
+
+	// quarantined and held nodes are excluded from the rollout, so their failures do not block the other nodes
+	quarantined, held, err := crr.quarantineFailingNodes(ctx, revisions.Items, nodeConfigs.Items)
+	if err != nil {
+		return fmt.Errorf("error quarantining failing nodes: %w", err)
+	}
+	excludeNodes(quarantined, nodes, nodeConfigs)
+	excludeNodes(held, nodes, nodeConfigs)
+
 	totalNodes := len(nodes)
 	cntMap := map[string]*counters{}
 	for i := range revisions.Items {
@@ -143,11 +189,32 @@ func (crr *ConfigRevisionReconciler) reconcileDebounced(ctx context.Context) err
 		cntMap[revisions.Items[i].Spec.Revision] = cnt
 	}
 
+	// invalidate the revision if nodes already updated to it regressed - the previous valid revision is deployed to them instead
+	if err := crr.checkHealthRegression(ctx, getFirstValidRevision(revisions.Items), nodes, nodeConfigs.Items); err != nil {
+		return fmt.Errorf("error checking health regression: %w", err)
+	}
+
 	revisionToDeploy := getFirstValidRevision(revisions.Items)
 
-	nodesToDeploy := getOutdatedNodes(nodes, nodeConfigs.Items, revisionToDeploy)
+	outdatedNodes := getOutdatedNodes(maps.Clone(nodes), nodeConfigs.Items, revisionToDeploy)
+
+	// limit the nodes to the current stage if a RolloutPolicy is defined
+	nodesToDeploy := outdatedNodes
+	if isRollback(revisions.Items, revisionToDeploy) {
+		// rolling back to the last valid revision is not staged
+		revisionToDeploy.Status.Rollout = nil
+	} else {
+		nodesToDeploy, err = crr.getNodesToDeploy(ctx, nodes, outdatedNodes, nodeConfigs.Items, revisionToDeploy)
+		if err != nil {
+			return fmt.Errorf("error getting nodes to deploy: %w", err)
+		}
+	}
+	if revisionToDeploy != nil {
+		revisionToDeploy.Status.Gate = getRolloutGate(revisionToDeploy)
+		revisionToDeploy.Status.QuarantinedNodes = quarantinedNodeNames(quarantined)
+	}
 
-	if err := crr.updateRevisionCounters(ctx, revisions.Items, revisionToDeploy, len(nodesToDeploy), totalNodes, cntMap); err != nil {
+	if err := crr.updateRevisionCounters(ctx, revisions.Items, revisionToDeploy, len(outdatedNodes), totalNodes, cntMap); err != nil {
 		return fmt.Errorf("failed to update queue counters: %w", err)
 	}
 
@@ -157,7 +224,8 @@ func (crr *ConfigRevisionReconciler) reconcileDebounced(ctx context.Context) err
 		return nil
 	}
 
-	if revisionToDeploy.Status.Ongoing < crr.maxUpdating && len(nodesToDeploy) > 0 {
+	// nodes are only updated if the rollout is neither paused nor waiting for soak time or approval
+	if revisionToDeploy.Status.Gate == v1alpha1.RolloutGateOpen && revisionToDeploy.Status.Ongoing < crr.maxUpdating && len(nodesToDeploy) > 0 {
 		if err := crr.deployNodeConfig(ctx, nodesToDeploy[0], revisionToDeploy); err != nil {
 			return fmt.Errorf("error deploying node configurations: %w", err)
 		}
@@ -168,7 +236,7 @@ func (crr *ConfigRevisionReconciler) reconcileDebounced(ctx context.Context) err
 		return fmt.Errorf("error reconciling mirror status: %w", err)
 	}
 
-	// remove all but last known valid revision
+	// remove all but last known valid revision, the revision history (and the one to roll back to while it is monitored)
 	if err := crr.revisionCleanup(ctx); err != nil {
 		return fmt.Errorf("error cleaning redundant revisions: %w", err)
 	}
@@ -325,6 +393,9 @@ func (crr *ConfigRevisionReconciler) updateRevisionCounters(ctx context.Context,
 		q := 0
 		if currentRevision != nil && revisions[i].Spec.Revision == currentRevision.Spec.Revision {
 			q = queued
+		} else {
+			revisions[i].Status.Gate = ""
+			revisions[i].Status.QuarantinedNodes = nil
 		}
 		revisions[i].Status.Queued = q
 		revisions[i].Status.Ongoing = cnt[revisions[i].Spec.Revision].ongoing
@@ -349,7 +420,15 @@ func (crr *ConfigRevisionReconciler) revisionCleanup(ctx context.Context) error
 			return fmt.Errorf("failed to list configs: %w", err)
 		}
 		if !revisions.Items[0].Status.IsInvalid && revisions.Items[0].Status.Ready == revisions.Items[0].Status.Total {
+			rollbackRevision := crr.getRollbackRevision(revisions.Items, nodeConfigs.Items)
+			now := time.Now()
 			for i := 1; i < len(revisions.Items); i++ {
+				if rollbackRevision != nil && revisions.Items[i].Spec.Revision == rollbackRevision.Spec.Revision {
+					continue
+				}
+				if crr.revisionHistory.retains(&revisions.Items[i], i, now) {
+					continue
+				}
 				if countReferences(&revisions.Items[i], nodeConfigs.Items) == 0 {
 					crr.logger.Info("deleting NetworkConfigRevision", "name", revisions.Items[i].Name)
 					if err := crr.client.Delete(ctx, &revisions.Items[i]); err != nil {
@@ -434,6 +513,47 @@ func matchSelector(node *corev1.Node, selector *metav1.LabelSelector) bool {
 }
 
 func (crr *ConfigRevisionReconciler) CreateNodeNetworkConfig(ctx context.Context, node *corev1.Node, revision *v1alpha1.NetworkConfigRevision) (*v1alpha1.NodeNetworkConfig, error) {
+	if err := crr.vrfConfig.ReloadConfig(); err != nil {
+		return nil, fmt.Errorf("error reloading config: %w", err)
+	}
+
+	c, err := crr.buildNodeNetworkConfig(ctx, node, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := controllerutil.SetOwnerReference(node, c, scheme.Scheme); err != nil {
+		return nil, fmt.Errorf("error setting owner references (node): %w", err)
+	}
+
+	if err := controllerutil.SetOwnerReference(revision, c, crr.scheme); err != nil {
+		return nil, fmt.Errorf("error setting owner references (revision): %w", err)
+	}
+
+	// set config as next config for the node
+	return c, nil
+}
+
+// RenderNodeNetworkConfig builds the NodeNetworkConfig the node gets from the
+// revision like the operator does, with the VRF config read from configPath.
+// The cluster is only read, so revisions can be rendered outside the operator.
+func RenderNodeNetworkConfig(ctx context.Context, clusterClient client.Client, node *corev1.Node, revision *v1alpha1.NetworkConfigRevision, configPath string, importMode ImportMode) (*v1alpha1.NodeNetworkConfig, error) {
+	cfg, err := config.LoadConfigFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading config: %w", err)
+	}
+	crr := &ConfigRevisionReconciler{
+		logger:     logr.Discard(),
+		client:     clusterClient,
+		vrfConfig:  cfg,
+		importMode: importMode,
+	}
+	return crr.buildNodeNetworkConfig(ctx, node, revision)
+}
+
+// buildNodeNetworkConfig builds the NodeNetworkConfig of the node from the
+// revision, without owner references.
+func (crr *ConfigRevisionReconciler) buildNodeNetworkConfig(ctx context.Context, node *corev1.Node, revision *v1alpha1.NetworkConfigRevision) (*v1alpha1.NodeNetworkConfig, error) {
 	// create new config
 	c := &v1alpha1.NodeNetworkConfig{
 		ObjectMeta: metav1.ObjectMeta{
@@ -441,10 +561,6 @@ func (crr *ConfigRevisionReconciler) CreateNodeNetworkConfig(ctx context.Context
 		},
 	}
 
-	if err := crr.vrfConfig.ReloadConfig(); err != nil {
-		return nil, fmt.Errorf("error reloading config: %w", err)
-	}
-
 	if err := crr.buildNodeVrf(node, revision, c); err != nil {
 		return nil, fmt.Errorf("error building node VRFs: %w", err)
 	}
@@ -461,15 +577,6 @@ func (crr *ConfigRevisionReconciler) CreateNodeNetworkConfig(ctx context.Context
 	c.Spec.Revision = revision.Spec.Revision
 	c.Name = node.Name
 
-	if err := controllerutil.SetOwnerReference(node, c, scheme.Scheme); err != nil {
-		return nil, fmt.Errorf("error setting owner references (node): %w", err)
-	}
-
-	if err := controllerutil.SetOwnerReference(revision, c, crr.scheme); err != nil {
-		return nil, fmt.Errorf("error setting owner references (revision): %w", err)
-	}
-
-	// set config as next config for the node
 	return c, nil
 }
 
@@ -378,6 +378,7 @@ var _ = Describe("ConfigRevisionReconciler helpers", func() {
 		It("should update status counters for each revision", func() {
 			rev1 := makeRevision("rev001", false, time.Now())
 			rev2 := makeRevision("rev002", false, time.Now().Add(-time.Minute))
+			rev2.Status.Gate = v1alpha1.RolloutGatePaused
 
 			fakeClient := fake.NewClientBuilder().
 				WithScheme(testScheme).
@@ -420,6 +421,7 @@ var _ = Describe("ConfigRevisionReconciler helpers", func() {
 			Expect(updated2.Status.Ongoing).To(Equal(0))
 			Expect(updated2.Status.Queued).To(Equal(0))
 			Expect(updated2.Status.Total).To(Equal(5))
+			Expect(updated2.Status.Gate).To(BeEmpty())
 		})
 	})
 })
@@ -0,0 +1,75 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+// RevisionHistory configures how many superseded revisions are kept, so that
+// the nodes can be rolled back to them.
+type RevisionHistory struct {
+	// Limit is the number of revisions kept in addition to the latest one.
+	Limit int
+	// MaxAge keeps all revisions younger than it. Zero disables it.
+	MaxAge time.Duration
+}
+
+// retains returns true if the revision at the given position of the (newest
+// first) revision list is part of the history.
+func (h *RevisionHistory) retains(revision *v1alpha1.NetworkConfigRevision, position int, now time.Time) bool {
+	if position <= h.Limit {
+		return true
+	}
+	return h.MaxAge > 0 && now.Before(revision.CreationTimestamp.Add(h.MaxAge))
+}
+
+// processRollbackRequest handles a revision annotated with the rollback
+// annotation: all newer revisions are invalidated, which makes the annotated
+// revision the first valid one and thus the one to deploy. The annotation is
+// removed afterwards. Only the newest request is handled if there are several.
+func (crr *ConfigRevisionReconciler) processRollbackRequest(ctx context.Context, revisions []v1alpha1.NetworkConfigRevision) error {
+	target := -1
+	for i := range revisions {
+		if revisions[i].Annotations[v1alpha1.RollbackAnnotation] == "true" {
+			target = i
+			break
+		}
+	}
+	if target < 0 {
+		return nil
+	}
+
+	if revisions[target].Status.IsInvalid {
+		crr.logger.Info("ignoring rollback to invalid revision", "name", revisions[target].Name)
+	} else {
+		crr.logger.Info("rolling back to revision", "name", revisions[target].Name)
+		message := fmt.Sprintf("rolled back to revision %s", revisions[target].Name)
+		for i := 0; i < target; i++ {
+			if revisions[i].Status.IsInvalid {
+				continue
+			}
+			if err := crr.invalidateRevision(ctx, &revisions[i], "", message, metav1.Now()); err != nil {
+				return fmt.Errorf("failed to invalidate revision %s: %w", revisions[i].Name, err)
+			}
+		}
+	}
+
+	for i := range revisions {
+		if _, ok := revisions[i].Annotations[v1alpha1.RollbackAnnotation]; !ok {
+			continue
+		}
+		patch := client.MergeFrom(revisions[i].DeepCopy())
+		delete(revisions[i].Annotations, v1alpha1.RollbackAnnotation)
+		if err := crr.client.Patch(ctx, &revisions[i], patch); err != nil {
+			return fmt.Errorf("failed to remove rollback annotation from revision %s: %w", revisions[i].Name, err)
+		}
+	}
+
+	return nil
+}
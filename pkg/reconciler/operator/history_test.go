@@ -0,0 +1,130 @@
+package operator
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+var _ = Describe("Revision history", func() {
+	var now time.Time
+
+	BeforeEach(func() {
+		now = time.Now().Truncate(time.Second)
+	})
+
+	Describe("retains", func() {
+		It("should keep the configured number of revisions", func() {
+			history := &RevisionHistory{Limit: 2}
+			revision := makeRevision("rev001", false, now.Add(-time.Hour))
+			Expect(history.retains(&revision, 1, now)).To(BeTrue())
+			Expect(history.retains(&revision, 2, now)).To(BeTrue())
+			Expect(history.retains(&revision, 3, now)).To(BeFalse())
+		})
+
+		It("should keep revisions younger than the max age", func() {
+			history := &RevisionHistory{MaxAge: 24 * time.Hour}
+			young := makeRevision("rev001", false, now.Add(-time.Hour))
+			old := makeRevision("rev000", false, now.Add(-48*time.Hour))
+			Expect(history.retains(&young, 5, now)).To(BeTrue())
+			Expect(history.retains(&old, 5, now)).To(BeFalse())
+		})
+	})
+
+	Describe("revisionCleanup", func() {
+		It("should keep the revision history", func() {
+			head := makeRevision("rev002", false, now.Add(-time.Minute))
+			head.Status.Ready = 1
+			head.Status.Total = 1
+			previous := makeRevision("rev001", false, now.Add(-time.Hour))
+			older := makeRevision("rev000", false, now.Add(-2*time.Hour))
+			cfg := makeNodeConfig("node1", "rev002", StatusProvisioned, now.Add(-time.Minute))
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithRuntimeObjects(&head, &previous, &older, &cfg).
+				Build()
+			crr := &ConfigRevisionReconciler{
+				logger:          ctrl.Log.WithName("test"),
+				client:          fakeClient,
+				revisionHistory: RevisionHistory{Limit: 1},
+			}
+
+			Expect(crr.revisionCleanup(context.Background())).To(Succeed())
+
+			list := &v1alpha1.NetworkConfigRevisionList{}
+			Expect(fakeClient.List(context.Background(), list)).To(Succeed())
+			names := []string{}
+			for i := range list.Items {
+				names = append(names, list.Items[i].Spec.Revision)
+			}
+			Expect(names).To(ConsistOf("rev002", "rev001"))
+		})
+	})
+
+	Describe("processRollbackRequest", func() {
+		It("should invalidate all revisions newer than the requested one", func() {
+			head := makeRevision("rev002", false, now.Add(-time.Minute))
+			middle := makeRevision("rev001", false, now.Add(-time.Hour))
+			target := makeRevision("rev000", false, now.Add(-2*time.Hour))
+			target.Annotations = map[string]string{v1alpha1.RollbackAnnotation: "true"}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithRuntimeObjects(&head, &middle, &target).
+				WithStatusSubresource(&head, &middle, &target).
+				Build()
+			crr := &ConfigRevisionReconciler{
+				logger: ctrl.Log.WithName("test"),
+				client: fakeClient,
+			}
+			revisions := []v1alpha1.NetworkConfigRevision{head, middle, target}
+
+			Expect(crr.processRollbackRequest(context.Background(), revisions)).To(Succeed())
+
+			for _, name := range []string{head.Name, middle.Name} {
+				updated := &v1alpha1.NetworkConfigRevision{}
+				Expect(fakeClient.Get(context.Background(), types.NamespacedName{Name: name}, updated)).To(Succeed())
+				Expect(updated.Status.IsInvalid).To(BeTrue())
+				Expect(updated.Status.FailedMessage).To(Equal("rolled back to revision " + target.Name))
+			}
+
+			updated := &v1alpha1.NetworkConfigRevision{}
+			Expect(fakeClient.Get(context.Background(), types.NamespacedName{Name: target.Name}, updated)).To(Succeed())
+			Expect(updated.Status.IsInvalid).To(BeFalse())
+			Expect(updated.Annotations).ToNot(HaveKey(v1alpha1.RollbackAnnotation))
+
+			Expect(getFirstValidRevision(revisions).Spec.Revision).To(Equal("rev000"))
+			Expect(isRollback(revisions, getFirstValidRevision(revisions))).To(BeTrue())
+		})
+
+		It("should drop a request to roll back to an invalid revision", func() {
+			head := makeRevision("rev001", false, now.Add(-time.Minute))
+			target := makeRevision("rev000", true, now.Add(-time.Hour))
+			target.Annotations = map[string]string{v1alpha1.RollbackAnnotation: "true"}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithRuntimeObjects(&head, &target).
+				WithStatusSubresource(&head, &target).
+				Build()
+			crr := &ConfigRevisionReconciler{
+				logger: ctrl.Log.WithName("test"),
+				client: fakeClient,
+			}
+			revisions := []v1alpha1.NetworkConfigRevision{head, target}
+
+			Expect(crr.processRollbackRequest(context.Background(), revisions)).To(Succeed())
+
+			updated := &v1alpha1.NetworkConfigRevision{}
+			Expect(fakeClient.Get(context.Background(), types.NamespacedName{Name: head.Name}, updated)).To(Succeed())
+			Expect(updated.Status.IsInvalid).To(BeFalse())
+			Expect(fakeClient.Get(context.Background(), types.NamespacedName{Name: target.Name}, updated)).To(Succeed())
+			Expect(updated.Annotations).ToNot(HaveKey(v1alpha1.RollbackAnnotation))
+		})
+	})
+})
@@ -0,0 +1,154 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+// quarantineFailingNodes counts the consecutive revisions each node failed to
+// provision and quarantines a node once the count reaches quarantineAfter.
+// Quarantined nodes are excluded from the rollout, so their failures neither
+// invalidate revisions nor block the other nodes. Nodes stay quarantined until
+// the label is removed.
+//
+// A node that failed the latest revision but is still below the threshold is
+// held: it is excluded from the rollout of that revision as well, so the other
+// nodes continue. Only if quarantineAfter nodes fail the same revision, the
+// revision rather than the nodes is considered broken and the failures
+// invalidate it as usual. The names of the quarantined and the held nodes are
+// returned.
+func (crr *ConfigRevisionReconciler) quarantineFailingNodes(ctx context.Context, revisions []v1alpha1.NetworkConfigRevision,
+	configs []v1alpha1.NodeNetworkConfig) (quarantined, held map[string]bool, err error) {
+	quarantined = map[string]bool{}
+	held = map[string]bool{}
+	if crr.quarantineAfter <= 0 {
+		return quarantined, held, nil
+	}
+
+	latest := ""
+	if len(revisions) > 0 {
+		latest = revisions[0].Spec.Revision
+	}
+
+	list := &corev1.NodeList{}
+	if err := crr.client.List(ctx, list); err != nil {
+		return nil, nil, fmt.Errorf("unable to list nodes: %w", err)
+	}
+	nodes := make(map[string]*corev1.Node, len(list.Items))
+	for i := range list.Items {
+		nodes[list.Items[i].Name] = &list.Items[i]
+		if list.Items[i].Labels[v1alpha1.QuarantinedLabel] == "true" {
+			quarantined[list.Items[i].Name] = true
+		}
+	}
+
+	for i := range configs {
+		node, ok := nodes[configs[i].Name]
+		if !ok || quarantined[node.Name] {
+			continue
+		}
+		updated := false
+		switch {
+		case crr.isConfigFailed(&configs[i]):
+			updated = crr.recordConfigFailure(node, configs[i].Spec.Revision)
+			switch {
+			case node.Labels[v1alpha1.QuarantinedLabel] == "true":
+				quarantined[node.Name] = true
+			case configs[i].Spec.Revision == latest:
+				held[node.Name] = true
+			}
+		case configs[i].Status.ConfigStatus == StatusProvisioned && configs[i].Spec.Revision == latest:
+			// rolling back to a previous revision after a failure does not count as success
+			updated = resetConfigFailures(node)
+		}
+		if updated {
+			if err := crr.client.Update(ctx, node); err != nil {
+				return nil, nil, fmt.Errorf("error updating node %s: %w", node.Name, err)
+			}
+		}
+	}
+
+	if len(held) >= crr.quarantineAfter {
+		crr.logger.Info("revision failed on too many nodes, not holding them back", "revision", latest, "nodes", len(held))
+		held = map[string]bool{}
+	}
+
+	return quarantined, held, nil
+}
+
+func (crr *ConfigRevisionReconciler) isConfigFailed(cfg *v1alpha1.NodeNetworkConfig) bool {
+	switch cfg.Status.ConfigStatus {
+	case StatusInvalid:
+		return true
+	case "":
+		return wasConfigTimeoutReached(cfg, crr.preconfigTimeout)
+	case StatusProvisioning:
+		return wasConfigTimeoutReached(cfg, crr.configTimeout)
+	}
+	return false
+}
+
+// recordConfigFailure counts the revision as failed on the node (once) and
+// labels the node as quarantined if it failed too many consecutive revisions.
+// It returns true if the node was modified.
+func (crr *ConfigRevisionReconciler) recordConfigFailure(node *corev1.Node, revision string) bool {
+	if node.Annotations[v1alpha1.LastFailedRevisionAnnotation] == revision {
+		return false
+	}
+
+	failures, err := strconv.Atoi(node.Annotations[v1alpha1.ConfigFailuresAnnotation])
+	if err != nil {
+		failures = 0
+	}
+	failures++
+
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	node.Annotations[v1alpha1.ConfigFailuresAnnotation] = strconv.Itoa(failures)
+	node.Annotations[v1alpha1.LastFailedRevisionAnnotation] = revision
+
+	if failures >= crr.quarantineAfter {
+		crr.logger.Info("quarantining node", "node", node.Name, "failures", failures, "revision", revision)
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+		node.Labels[v1alpha1.QuarantinedLabel] = "true"
+	}
+	return true
+}
+
+// resetConfigFailures clears the failure count of a node that provisioned the
+// latest revision. It returns true if the node was modified.
+func resetConfigFailures(node *corev1.Node) bool {
+	if _, ok := node.Annotations[v1alpha1.ConfigFailuresAnnotation]; !ok {
+		return false
+	}
+	delete(node.Annotations, v1alpha1.ConfigFailuresAnnotation)
+	delete(node.Annotations, v1alpha1.LastFailedRevisionAnnotation)
+	return true
+}
+
+// excludeNodes removes the given nodes and their configs from the rollout.
+func excludeNodes(excluded map[string]bool, nodes map[string]*corev1.Node, configs *v1alpha1.NodeNetworkConfigList) {
+	for name := range excluded {
+		delete(nodes, name)
+	}
+	configs.Items = slices.DeleteFunc(configs.Items, func(c v1alpha1.NodeNetworkConfig) bool {
+		return excluded[c.Name]
+	})
+}
+
+func quarantinedNodeNames(quarantined map[string]bool) []string {
+	if len(quarantined) == 0 {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(quarantined))
+}
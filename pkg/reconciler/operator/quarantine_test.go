@@ -0,0 +1,182 @@
+package operator
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+var _ = Describe("Node quarantine", func() {
+	var (
+		now       time.Time
+		revisions []v1alpha1.NetworkConfigRevision
+	)
+
+	BeforeEach(func() {
+		now = time.Now().Truncate(time.Second)
+		revisions = []v1alpha1.NetworkConfigRevision{
+			makeRevision("rev002", false, now.Add(-time.Minute)),
+			makeRevision("rev001", false, now.Add(-time.Hour)),
+		}
+	})
+
+	newReconciler := func(quarantineAfter int, objs ...client.Object) *ConfigRevisionReconciler {
+		return &ConfigRevisionReconciler{
+			logger:           ctrl.Log.WithName("test"),
+			client:           fake.NewClientBuilder().WithScheme(testScheme).WithObjects(objs...).Build(),
+			configTimeout:    2 * time.Minute,
+			preconfigTimeout: 10 * time.Minute,
+			quarantineAfter:  quarantineAfter,
+		}
+	}
+
+	getNode := func(crr *ConfigRevisionReconciler, name string) *corev1.Node {
+		node := &corev1.Node{}
+		Expect(crr.client.Get(context.Background(), types.NamespacedName{Name: name}, node)).To(Succeed())
+		return node
+	}
+
+	It("should do nothing if the quarantine is disabled", func() {
+		crr := newReconciler(0, makeNode("node1", true))
+		configs := []v1alpha1.NodeNetworkConfig{makeNodeConfig("node1", "rev002", StatusInvalid, now)}
+
+		quarantined, held, err := crr.quarantineFailingNodes(context.Background(), revisions, configs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(quarantined).To(BeEmpty())
+		Expect(held).To(BeEmpty())
+		Expect(getNode(crr, "node1").Annotations).ToNot(HaveKey(v1alpha1.ConfigFailuresAnnotation))
+	})
+
+	It("should count each failed revision once", func() {
+		crr := newReconciler(3, makeNode("node1", true))
+		configs := []v1alpha1.NodeNetworkConfig{makeNodeConfig("node1", "rev002", StatusInvalid, now)}
+
+		for range 2 {
+			quarantined, held, err := crr.quarantineFailingNodes(context.Background(), revisions, configs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(quarantined).To(BeEmpty())
+			Expect(quarantinedNodeNames(held)).To(Equal([]string{"node1"}))
+		}
+
+		node := getNode(crr, "node1")
+		Expect(node.Annotations).To(HaveKeyWithValue(v1alpha1.ConfigFailuresAnnotation, "1"))
+		Expect(node.Annotations).To(HaveKeyWithValue(v1alpha1.LastFailedRevisionAnnotation, "rev002"))
+		Expect(node.Labels).ToNot(HaveKey(v1alpha1.QuarantinedLabel))
+	})
+
+	It("should quarantine a node after the configured number of failed revisions", func() {
+		node := makeNode("node1", true)
+		node.Annotations = map[string]string{
+			v1alpha1.ConfigFailuresAnnotation:     "1",
+			v1alpha1.LastFailedRevisionAnnotation: "rev001",
+		}
+		crr := newReconciler(2, node, makeNode("node2", true))
+		configs := []v1alpha1.NodeNetworkConfig{
+			makeNodeConfig("node1", "rev002", StatusProvisioning, now.Add(-5*time.Minute)),
+			makeNodeConfig("node2", "rev002", StatusProvisioned, now),
+		}
+
+		quarantined, held, err := crr.quarantineFailingNodes(context.Background(), revisions, configs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(quarantinedNodeNames(quarantined)).To(Equal([]string{"node1"}))
+		Expect(held).To(BeEmpty())
+		Expect(getNode(crr, "node1").Labels).To(HaveKeyWithValue(v1alpha1.QuarantinedLabel, "true"))
+
+		// the quarantined node neither invalidates the revision nor is it updated
+		nodes := map[string]*corev1.Node{"node1": makeNode("node1", true), "node2": makeNode("node2", true)}
+		configList := &v1alpha1.NodeNetworkConfigList{Items: configs}
+		excludeNodes(quarantined, nodes, configList)
+		Expect(nodes).To(HaveLen(1))
+		Expect(crr.getRevisionCounters(configList.Items, &revisions[0]).invalid).To(BeZero())
+	})
+
+	It("should not reset the failures when the node is rolled back to a previous revision", func() {
+		node := makeNode("node1", true)
+		node.Annotations = map[string]string{
+			v1alpha1.ConfigFailuresAnnotation:     "1",
+			v1alpha1.LastFailedRevisionAnnotation: "rev002",
+		}
+		crr := newReconciler(2, node)
+		configs := []v1alpha1.NodeNetworkConfig{makeNodeConfig("node1", "rev001", StatusProvisioned, now)}
+
+		_, _, err := crr.quarantineFailingNodes(context.Background(), revisions, configs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getNode(crr, "node1").Annotations).To(HaveKeyWithValue(v1alpha1.ConfigFailuresAnnotation, "1"))
+	})
+
+	It("should reset the failures once the node provisioned the latest revision", func() {
+		node := makeNode("node1", true)
+		node.Annotations = map[string]string{
+			v1alpha1.ConfigFailuresAnnotation:     "1",
+			v1alpha1.LastFailedRevisionAnnotation: "rev001",
+		}
+		crr := newReconciler(2, node)
+		configs := []v1alpha1.NodeNetworkConfig{makeNodeConfig("node1", "rev002", StatusProvisioned, now)}
+
+		_, _, err := crr.quarantineFailingNodes(context.Background(), revisions, configs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getNode(crr, "node1").Annotations).ToNot(HaveKey(v1alpha1.ConfigFailuresAnnotation))
+	})
+
+	It("should keep nodes quarantined until the label is removed", func() {
+		node := makeNode("node1", true)
+		node.Labels[v1alpha1.QuarantinedLabel] = "true"
+		crr := newReconciler(2, node)
+		configs := []v1alpha1.NodeNetworkConfig{makeNodeConfig("node1", "rev002", StatusProvisioned, now)}
+
+		quarantined, _, err := crr.quarantineFailingNodes(context.Background(), revisions, configs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(quarantinedNodeNames(quarantined)).To(Equal([]string{"node1"}))
+	})
+
+	It("should hold a node below the threshold back so the revision stays valid", func() {
+		crr := newReconciler(3, makeNode("node1", true), makeNode("node2", true))
+		configs := []v1alpha1.NodeNetworkConfig{
+			makeNodeConfig("node1", "rev002", StatusInvalid, now),
+			makeNodeConfig("node2", "rev002", StatusProvisioned, now),
+		}
+
+		quarantined, held, err := crr.quarantineFailingNodes(context.Background(), revisions, configs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(quarantined).To(BeEmpty())
+		Expect(quarantinedNodeNames(held)).To(Equal([]string{"node1"}))
+
+		nodes := map[string]*corev1.Node{"node1": makeNode("node1", true), "node2": makeNode("node2", true)}
+		configList := &v1alpha1.NodeNetworkConfigList{Items: configs}
+		excludeNodes(held, nodes, configList)
+		Expect(nodes).To(HaveKey("node2"))
+		Expect(nodes).ToNot(HaveKey("node1"))
+		Expect(crr.getRevisionCounters(configList.Items, &revisions[0]).invalid).To(BeZero())
+	})
+
+	It("should not hold a node back that failed an older revision", func() {
+		crr := newReconciler(3, makeNode("node1", true))
+		configs := []v1alpha1.NodeNetworkConfig{makeNodeConfig("node1", "rev001", StatusInvalid, now)}
+
+		_, held, err := crr.quarantineFailingNodes(context.Background(), revisions, configs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(held).To(BeEmpty())
+	})
+
+	It("should not hold nodes back if the revision failed on as many nodes as the threshold", func() {
+		crr := newReconciler(2, makeNode("node1", true), makeNode("node2", true))
+		configs := []v1alpha1.NodeNetworkConfig{
+			makeNodeConfig("node1", "rev002", StatusInvalid, now),
+			makeNodeConfig("node2", "rev002", StatusInvalid, now),
+		}
+
+		quarantined, held, err := crr.quarantineFailingNodes(context.Background(), revisions, configs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(quarantined).To(BeEmpty())
+		Expect(held).To(BeEmpty())
+	})
+})
@@ -0,0 +1,123 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+// DefaultHealthConditions are the Node conditions watched after a node was
+// updated to a new revision.
+const DefaultHealthConditions = "NetworkOperatorReady,BGPSessionsEstablished"
+
+// HealthMonitoring configures how long and by which signals a node is watched
+// after it was provisioned with a revision.
+type HealthMonitoring struct {
+	// Window is the time after a node was provisioned during which a health
+	// regression invalidates the revision. Zero disables the monitoring.
+	Window time.Duration
+	// Conditions are the Node condition types that must not turn False during
+	// the window.
+	Conditions []corev1.NodeConditionType
+}
+
+// ParseHealthConditions converts a comma separated list of Node condition types.
+func ParseHealthConditions(conditions string) []corev1.NodeConditionType {
+	types := []corev1.NodeConditionType{}
+	for _, c := range strings.Split(conditions, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			types = append(types, corev1.NodeConditionType(c))
+		}
+	}
+	return types
+}
+
+// checkHealthRegression invalidates the revision if a node that was provisioned
+// with it within the health window reports one of the watched conditions as
+// False since then. Invalidating the revision makes the previous valid revision
+// the one to deploy, so the nodes already updated are rolled back to it.
+func (crr *ConfigRevisionReconciler) checkHealthRegression(ctx context.Context, revision *v1alpha1.NetworkConfigRevision,
+	nodes map[string]*corev1.Node, configs []v1alpha1.NodeNetworkConfig) error {
+	if revision == nil || revision.Status.IsInvalid || crr.healthMonitoring.Window <= 0 {
+		return nil
+	}
+
+	failedNode, failedMessage, failedAt := findHealthRegression(nodes, configs, revision, &crr.healthMonitoring, time.Now())
+	if failedNode == "" {
+		return nil
+	}
+
+	if err := crr.invalidateRevision(ctx, revision, failedNode, failedMessage, failedAt); err != nil {
+		return fmt.Errorf("failed to invalidate revision %s: %w", revision.Name, err)
+	}
+	return nil
+}
+
+// findHealthRegression returns the (lexicographically smallest) node that
+// regressed after it was provisioned with the revision, a message describing
+// the regression and when it was detected by the node.
+func findHealthRegression(nodes map[string]*corev1.Node, configs []v1alpha1.NodeNetworkConfig, revision *v1alpha1.NetworkConfigRevision,
+	monitoring *HealthMonitoring, now time.Time) (failedNode, failedMessage string, failedAt metav1.Time) {
+	for i := range configs {
+		cfg := &configs[i]
+		if cfg.Spec.Revision != revision.Spec.Revision || cfg.Status.ConfigStatus != StatusProvisioned || !inHealthWindow(cfg, monitoring.Window, now) {
+			continue
+		}
+		node, ok := nodes[cfg.Name]
+		if !ok || (failedNode != "" && cfg.Name > failedNode) {
+			continue
+		}
+		for j := range node.Status.Conditions {
+			cond := &node.Status.Conditions[j]
+			if cond.Status != corev1.ConditionFalse || !slices.Contains(monitoring.Conditions, cond.Type) ||
+				cond.LastTransitionTime.Before(&cfg.Status.LastUpdate) {
+				continue
+			}
+			failedNode = cfg.Name
+			failedMessage = fmt.Sprintf("post-apply health regression: %s is False (%s): %s", cond.Type, cond.Reason, cond.Message)
+			failedAt = cond.LastTransitionTime
+			break
+		}
+	}
+	return failedNode, failedMessage, failedAt
+}
+
+func inHealthWindow(cfg *v1alpha1.NodeNetworkConfig, window time.Duration, now time.Time) bool {
+	return !cfg.Status.LastUpdate.IsZero() && now.Before(cfg.Status.LastUpdate.Add(window))
+}
+
+// getRollbackRevision returns the revision the head revision would be rolled
+// back to, as long as the head may still be invalidated by a health
+// regression. It must not be cleaned up until then.
+func (crr *ConfigRevisionReconciler) getRollbackRevision(revisions []v1alpha1.NetworkConfigRevision, configs []v1alpha1.NodeNetworkConfig) *v1alpha1.NetworkConfigRevision {
+	if crr.healthMonitoring.Window <= 0 || len(revisions) < 2 || revisions[0].Status.IsInvalid {
+		return nil
+	}
+
+	now := time.Now()
+	monitored := false
+	for i := range configs {
+		if configs[i].Spec.Revision == revisions[0].Spec.Revision && inHealthWindow(&configs[i], crr.healthMonitoring.Window, now) {
+			monitored = true
+			break
+		}
+	}
+	if !monitored {
+		return nil
+	}
+
+	return getFirstValidRevision(revisions[1:])
+}
+
+// isRollback returns true if the revision to deploy is not the latest one,
+// i.e. the nodes are rolled back because a newer revision was invalidated.
+func isRollback(revisions []v1alpha1.NetworkConfigRevision, revision *v1alpha1.NetworkConfigRevision) bool {
+	return revision != nil && len(revisions) > 0 && revisions[0].Spec.Revision != revision.Spec.Revision
+}
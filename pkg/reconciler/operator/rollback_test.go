@@ -0,0 +1,232 @@
+package operator
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+var _ = Describe("Health regression rollback", func() {
+	var (
+		now        time.Time
+		monitoring *HealthMonitoring
+		revision   v1alpha1.NetworkConfigRevision
+	)
+
+	BeforeEach(func() {
+		now = time.Now().Truncate(time.Second)
+		monitoring = &HealthMonitoring{
+			Window:     10 * time.Minute,
+			Conditions: ParseHealthConditions(DefaultHealthConditions),
+		}
+		revision = makeRevision("rev001", false, now.Add(-time.Hour))
+	})
+
+	Describe("ParseHealthConditions", func() {
+		It("should split and trim the condition types", func() {
+			Expect(ParseHealthConditions(" NetworkOperatorReady, ,BGPSessionsEstablished")).To(Equal([]corev1.NodeConditionType{
+				"NetworkOperatorReady", "BGPSessionsEstablished",
+			}))
+			Expect(ParseHealthConditions("")).To(BeEmpty())
+		})
+	})
+
+	Describe("findHealthRegression", func() {
+		It("should report a watched condition that turned False after the node was provisioned", func() {
+			nodes := map[string]*corev1.Node{
+				"node1": makeHealthNode("node1", "BGPSessionsEstablished", corev1.ConditionFalse, now.Add(-time.Minute)),
+			}
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("node1", "rev001", StatusProvisioned, now.Add(-2*time.Minute)),
+			}
+
+			node, message, at := findHealthRegression(nodes, configs, &revision, monitoring, now)
+			Expect(node).To(Equal("node1"))
+			Expect(message).To(ContainSubstring("BGPSessionsEstablished is False"))
+			Expect(at.Time).To(Equal(now.Add(-time.Minute)))
+		})
+
+		It("should pick the lexicographically smallest node", func() {
+			nodes := map[string]*corev1.Node{
+				"node1": makeHealthNode("node1", "NetworkOperatorReady", corev1.ConditionFalse, now.Add(-time.Minute)),
+				"node2": makeHealthNode("node2", "NetworkOperatorReady", corev1.ConditionFalse, now.Add(-time.Minute)),
+			}
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("node2", "rev001", StatusProvisioned, now.Add(-2*time.Minute)),
+				makeNodeConfig("node1", "rev001", StatusProvisioned, now.Add(-2*time.Minute)),
+			}
+
+			node, _, _ := findHealthRegression(nodes, configs, &revision, monitoring, now)
+			Expect(node).To(Equal("node1"))
+		})
+
+		It("should ignore conditions that were already False when the node was provisioned", func() {
+			nodes := map[string]*corev1.Node{
+				"node1": makeHealthNode("node1", "NetworkOperatorReady", corev1.ConditionFalse, now.Add(-time.Hour)),
+			}
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("node1", "rev001", StatusProvisioned, now.Add(-2*time.Minute)),
+			}
+
+			node, _, _ := findHealthRegression(nodes, configs, &revision, monitoring, now)
+			Expect(node).To(BeEmpty())
+		})
+
+		It("should ignore regressions after the health window", func() {
+			nodes := map[string]*corev1.Node{
+				"node1": makeHealthNode("node1", "NetworkOperatorReady", corev1.ConditionFalse, now.Add(-time.Minute)),
+			}
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("node1", "rev001", StatusProvisioned, now.Add(-20*time.Minute)),
+			}
+
+			node, _, _ := findHealthRegression(nodes, configs, &revision, monitoring, now)
+			Expect(node).To(BeEmpty())
+		})
+
+		It("should ignore conditions that are not watched and nodes of other revisions", func() {
+			nodes := map[string]*corev1.Node{
+				"node1": makeHealthNode("node1", "SomethingElse", corev1.ConditionFalse, now.Add(-time.Minute)),
+				"node2": makeHealthNode("node2", "NetworkOperatorReady", corev1.ConditionFalse, now.Add(-time.Minute)),
+			}
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("node1", "rev001", StatusProvisioned, now.Add(-2*time.Minute)),
+				makeNodeConfig("node2", "rev000", StatusProvisioned, now.Add(-2*time.Minute)),
+			}
+
+			node, _, _ := findHealthRegression(nodes, configs, &revision, monitoring, now)
+			Expect(node).To(BeEmpty())
+		})
+	})
+
+	Describe("checkHealthRegression", func() {
+		It("should invalidate the revision so that the previous one is deployed", func() {
+			previous := makeRevision("rev000", false, now.Add(-2*time.Hour))
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithRuntimeObjects(&revision, &previous).
+				WithStatusSubresource(&revision, &previous).
+				Build()
+			crr := &ConfigRevisionReconciler{
+				logger:           ctrl.Log.WithName("test"),
+				client:           fakeClient,
+				healthMonitoring: *monitoring,
+			}
+
+			nodes := map[string]*corev1.Node{
+				"node1": makeHealthNode("node1", "NetworkOperatorReady", corev1.ConditionFalse, now.Add(-time.Minute)),
+				"node2": makeNode("node2", true),
+			}
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("node1", "rev001", StatusProvisioned, now.Add(-2*time.Minute)),
+				makeNodeConfig("node2", "rev000", StatusProvisioned, now.Add(-time.Hour)),
+			}
+			revisions := []v1alpha1.NetworkConfigRevision{revision, previous}
+
+			Expect(crr.checkHealthRegression(context.Background(), &revisions[0], nodes, configs)).To(Succeed())
+
+			updated := &v1alpha1.NetworkConfigRevision{}
+			Expect(fakeClient.Get(context.Background(), types.NamespacedName{Name: revision.Name}, updated)).To(Succeed())
+			Expect(updated.Status.IsInvalid).To(BeTrue())
+			Expect(updated.Status.FailedNode).To(Equal("node1"))
+
+			revisionToDeploy := getFirstValidRevision(revisions)
+			Expect(revisionToDeploy.Spec.Revision).To(Equal("rev000"))
+			Expect(isRollback(revisions, revisionToDeploy)).To(BeTrue())
+			Expect(nodeNames(getOutdatedNodes(nodes, configs, revisionToDeploy))).To(ConsistOf("node1"))
+		})
+
+		It("should do nothing if the health window is disabled", func() {
+			crr := &ConfigRevisionReconciler{logger: ctrl.Log.WithName("test")}
+			nodes := map[string]*corev1.Node{
+				"node1": makeHealthNode("node1", "NetworkOperatorReady", corev1.ConditionFalse, now.Add(-time.Minute)),
+			}
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("node1", "rev001", StatusProvisioned, now.Add(-2*time.Minute)),
+			}
+
+			Expect(crr.checkHealthRegression(context.Background(), &revision, nodes, configs)).To(Succeed())
+			Expect(revision.Status.IsInvalid).To(BeFalse())
+		})
+	})
+
+	Describe("getRollbackRevision", func() {
+		It("should return the previous valid revision while the head is monitored", func() {
+			crr := &ConfigRevisionReconciler{healthMonitoring: *monitoring}
+			revisions := []v1alpha1.NetworkConfigRevision{
+				revision,
+				makeRevision("rev000", true, now.Add(-2*time.Hour)),
+				makeRevision("rev00a", false, now.Add(-3*time.Hour)),
+			}
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("node1", "rev001", StatusProvisioned, now.Add(-time.Minute)),
+			}
+
+			rollbackRevision := crr.getRollbackRevision(revisions, configs)
+			Expect(rollbackRevision).ToNot(BeNil())
+			Expect(rollbackRevision.Spec.Revision).To(Equal("rev00a"))
+		})
+
+		It("should return nil once the health window has passed", func() {
+			crr := &ConfigRevisionReconciler{healthMonitoring: *monitoring}
+			revisions := []v1alpha1.NetworkConfigRevision{
+				revision,
+				makeRevision("rev000", false, now.Add(-2*time.Hour)),
+			}
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("node1", "rev001", StatusProvisioned, now.Add(-time.Hour)),
+			}
+
+			Expect(crr.getRollbackRevision(revisions, configs)).To(BeNil())
+		})
+	})
+
+	Describe("revisionCleanup", func() {
+		It("should keep the revision to roll back to while the head is monitored", func() {
+			revision.Status.Ready = 1
+			revision.Status.Total = 1
+			previous := makeRevision("rev000", false, now.Add(-2*time.Hour))
+			older := makeRevision("rev00a", false, now.Add(-3*time.Hour))
+			cfg := makeNodeConfig("node1", "rev001", StatusProvisioned, now.Add(-time.Minute))
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithRuntimeObjects(&revision, &previous, &older, &cfg).
+				Build()
+			crr := &ConfigRevisionReconciler{
+				logger:           ctrl.Log.WithName("test"),
+				client:           fakeClient,
+				healthMonitoring: *monitoring,
+			}
+
+			Expect(crr.revisionCleanup(context.Background())).To(Succeed())
+
+			list := &v1alpha1.NetworkConfigRevisionList{}
+			Expect(fakeClient.List(context.Background(), list)).To(Succeed())
+			names := []string{}
+			for i := range list.Items {
+				names = append(names, list.Items[i].Spec.Revision)
+			}
+			Expect(names).To(ConsistOf("rev001", "rev000"))
+		})
+	})
+})
+
+func makeHealthNode(name string, conditionType corev1.NodeConditionType, status corev1.ConditionStatus, transition time.Time) *corev1.Node {
+	node := makeNode(name, true)
+	node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             "Test",
+		LastTransitionTime: metav1.NewTime(transition),
+	})
+	return node
+}
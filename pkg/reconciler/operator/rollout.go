@@ -0,0 +1,308 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+// remainingStageName is the name of the implicit last stage that holds all
+// nodes not selected by any group of the RolloutPolicy.
+const remainingStageName = "remaining"
+
+// rolloutStage is a set of nodes that is rolled out together. A stage must be
+// fully provisioned before the rollout moves on to the next one.
+type rolloutStage struct {
+	name           string
+	nodes          []*corev1.Node
+	maxUnavailable int
+	// group is the RolloutPolicy group the stage belongs to (nil for the
+	// remaining nodes) and groupIndex its position in the policy.
+	group      *v1alpha1.RolloutGroup
+	groupIndex int
+}
+
+// rolloutProgress is the per-stage state of a revision's rollout.
+type rolloutProgress struct {
+	ongoing  int
+	outdated []*corev1.Node
+}
+
+func (p *rolloutProgress) completed() bool {
+	return p.ongoing == 0 && len(p.outdated) == 0
+}
+
+func (crr *ConfigRevisionReconciler) getRolloutPolicy(ctx context.Context) (*v1alpha1.RolloutPolicy, error) {
+	policy := &v1alpha1.RolloutPolicy{}
+	if err := crr.client.Get(ctx, types.NamespacedName{Name: v1alpha1.DefaultRolloutPolicyName}, policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting RolloutPolicy %s: %w", v1alpha1.DefaultRolloutPolicyName, err)
+	}
+	return policy, nil
+}
+
+// getNodesToDeploy limits the outdated nodes to the ones that may be updated
+// according to the RolloutPolicy. Without a policy all outdated nodes are
+// returned. The rollout progress is recorded in the revision's status, which is
+// persisted with the revision counters.
+func (crr *ConfigRevisionReconciler) getNodesToDeploy(ctx context.Context, nodes map[string]*corev1.Node, outdated []*corev1.Node,
+	configs []v1alpha1.NodeNetworkConfig, revision *v1alpha1.NetworkConfigRevision) ([]*corev1.Node, error) {
+	if revision == nil {
+		return outdated, nil
+	}
+
+	policy, err := crr.getRolloutPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		revision.Status.Rollout = nil
+		return outdated, nil
+	}
+
+	stages, err := buildRolloutStages(policy, nodes, crr.maxUpdating)
+	if err != nil {
+		return nil, fmt.Errorf("error building rollout stages: %w", err)
+	}
+
+	var soakTime time.Duration
+	if policy.Spec.SoakTime != nil {
+		soakTime = policy.Spec.SoakTime.Duration
+	}
+
+	approvedGroup := getApprovedGroup(policy, revision)
+
+	nodesToDeploy, status := planRollout(stages, configs, revision, soakTime, approvedGroup, time.Now())
+	if status.SoakingUntil != nil && (revision.Status.Rollout == nil || revision.Status.Rollout.SoakingUntil == nil) {
+		crr.logger.Info("soaking before next rollout stage", "revision", revision.Name, "stage", status.Stage, "until", status.SoakingUntil)
+	}
+	if status.AwaitingApproval != "" && (revision.Status.Rollout == nil || revision.Status.Rollout.AwaitingApproval == "") {
+		crr.logger.Info("rollout stage awaits approval", "revision", revision.Name, "stage", status.Stage, "group", status.AwaitingApproval)
+	}
+	revision.Status.Rollout = status
+
+	return nodesToDeploy, nil
+}
+
+// buildRolloutStages splits the nodes into the ordered stages defined by the
+// policy. Every node is part of exactly one stage; nodes not selected by any
+// group form the last stage, which is only limited by maxUpdating.
+func buildRolloutStages(policy *v1alpha1.RolloutPolicy, nodes map[string]*corev1.Node, maxUpdating int) ([]rolloutStage, error) {
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	assigned := make(map[string]bool, len(nodes))
+	stages := []rolloutStage{}
+
+	for i := range policy.Spec.Groups {
+		group := &policy.Spec.Groups[i]
+
+		selector := labels.Everything()
+		if group.NodeSelector != nil {
+			var err error
+			if selector, err = metav1.LabelSelectorAsSelector(group.NodeSelector); err != nil {
+				return nil, fmt.Errorf("invalid nodeSelector of group %s: %w", group.Name, err)
+			}
+		}
+
+		selected := []*corev1.Node{}
+		for _, name := range names {
+			if !assigned[name] && selector.Matches(labels.Set(nodes[name].Labels)) {
+				assigned[name] = true
+				selected = append(selected, nodes[name])
+			}
+		}
+
+		for _, stage := range splitByTopology(group, selected) {
+			maxUnavailable, err := getMaxUnavailable(group.MaxUnavailable, len(stage.nodes))
+			if err != nil {
+				return nil, fmt.Errorf("invalid maxUnavailable of group %s: %w", group.Name, err)
+			}
+			stage.maxUnavailable = maxUnavailable
+			stage.group = group
+			stage.groupIndex = i
+			stages = append(stages, stage)
+		}
+	}
+
+	remaining := rolloutStage{name: remainingStageName, maxUnavailable: maxUpdating, groupIndex: len(policy.Spec.Groups)}
+	for _, name := range names {
+		if !assigned[name] {
+			remaining.nodes = append(remaining.nodes, nodes[name])
+		}
+	}
+	if len(remaining.nodes) > 0 {
+		stages = append(stages, remaining)
+	}
+
+	return stages, nil
+}
+
+// splitByTopology returns one stage per distinct value of the group's topology
+// key, ordered by value. Nodes without the label are put into the last stage.
+// Empty stages are omitted.
+func splitByTopology(group *v1alpha1.RolloutGroup, nodes []*corev1.Node) []rolloutStage {
+	if len(nodes) == 0 {
+		return nil
+	}
+	if group.TopologyKey == "" {
+		return []rolloutStage{{name: group.Name, nodes: nodes}}
+	}
+
+	byValue := map[string][]*corev1.Node{}
+	unlabelled := []*corev1.Node{}
+	for _, node := range nodes {
+		value, ok := node.Labels[group.TopologyKey]
+		if !ok {
+			unlabelled = append(unlabelled, node)
+			continue
+		}
+		byValue[value] = append(byValue[value], node)
+	}
+
+	values := make([]string, 0, len(byValue))
+	for value := range byValue {
+		values = append(values, value)
+	}
+	slices.Sort(values)
+
+	stages := make([]rolloutStage, 0, len(values)+1)
+	for _, value := range values {
+		stages = append(stages, rolloutStage{name: group.Name + "/" + value, nodes: byValue[value]})
+	}
+	if len(unlabelled) > 0 {
+		stages = append(stages, rolloutStage{name: group.Name, nodes: unlabelled})
+	}
+	return stages
+}
+
+func getMaxUnavailable(maxUnavailable *intstr.IntOrString, stageSize int) (int, error) {
+	if maxUnavailable == nil {
+		return 1, nil
+	}
+	value, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, stageSize, false)
+	if err != nil {
+		return 0, fmt.Errorf("error scaling value: %w", err)
+	}
+	if value < 1 {
+		return 1, nil
+	}
+	return value, nil
+}
+
+// getApprovedGroup returns the index of the group named by the revision's
+// rollout-approved annotation, or -1 if no (known) group was approved.
+func getApprovedGroup(policy *v1alpha1.RolloutPolicy, revision *v1alpha1.NetworkConfigRevision) int {
+	approved, ok := revision.Annotations[v1alpha1.RolloutApprovedAnnotation]
+	if !ok {
+		return -1
+	}
+	return slices.IndexFunc(policy.Spec.Groups, func(g v1alpha1.RolloutGroup) bool {
+		return g.Name == approved
+	})
+}
+
+// planRollout returns the nodes of the first stage that is not yet fully
+// provisioned, limited by the stage's maxUnavailable. When the rollout enters a
+// new stage the soak time has to pass before any node of that stage is updated.
+// Stages of groups that require approval are held back until the group (or a
+// later one) was approved.
+func planRollout(stages []rolloutStage, configs []v1alpha1.NodeNetworkConfig, revision *v1alpha1.NetworkConfigRevision,
+	soakTime time.Duration, approvedGroup int, now time.Time) ([]*corev1.Node, *v1alpha1.RevisionRolloutStatus) {
+	status := &v1alpha1.RevisionRolloutStatus{TotalStages: len(stages)}
+
+	configByNode := make(map[string]*v1alpha1.NodeNetworkConfig, len(configs))
+	for i := range configs {
+		configByNode[configs[i].Name] = &configs[i]
+	}
+
+	active := -1
+	var progress *rolloutProgress
+	for i := range stages {
+		p := getStageProgress(&stages[i], configByNode, revision)
+		if p.completed() {
+			status.CompletedStages++
+			continue
+		}
+		if active < 0 {
+			active = i
+			progress = p
+		}
+	}
+	if active < 0 {
+		return nil, status
+	}
+
+	stage := &stages[active]
+	status.Stage = stage.name
+
+	previous := revision.Status.Rollout
+	started := progress.ongoing > 0 || len(progress.outdated) < len(stage.nodes)
+	switch {
+	case previous != nil && previous.Stage == stage.name:
+		status.SoakingUntil = previous.SoakingUntil
+	case active > 0 && soakTime > 0 && !started:
+		until := metav1.NewTime(now.Add(soakTime))
+		status.SoakingUntil = &until
+	}
+	if status.SoakingUntil != nil {
+		if now.Before(status.SoakingUntil.Time) {
+			return nil, status
+		}
+		status.SoakingUntil = nil
+	}
+
+	if stage.group != nil && stage.group.RequireApproval && approvedGroup < stage.groupIndex {
+		status.AwaitingApproval = stage.group.Name
+		return nil, status
+	}
+
+	if progress.ongoing >= stage.maxUnavailable {
+		return nil, status
+	}
+	return progress.outdated, status
+}
+
+func getStageProgress(stage *rolloutStage, configs map[string]*v1alpha1.NodeNetworkConfig, revision *v1alpha1.NetworkConfigRevision) *rolloutProgress {
+	p := &rolloutProgress{}
+	for _, node := range stage.nodes {
+		cfg, ok := configs[node.Name]
+		switch {
+		case !ok || cfg.Spec.Revision != revision.Spec.Revision:
+			p.outdated = append(p.outdated, node)
+		case cfg.Status.ConfigStatus != StatusProvisioned:
+			p.ongoing++
+		}
+	}
+	return p
+}
+
+// getRolloutGate returns whether the rollout of the revision proceeds or why it
+// is held back.
+func getRolloutGate(revision *v1alpha1.NetworkConfigRevision) v1alpha1.RolloutGate {
+	switch {
+	case revision.Annotations[v1alpha1.RolloutPausedAnnotation] == "true":
+		return v1alpha1.RolloutGatePaused
+	case revision.Status.Rollout != nil && revision.Status.Rollout.SoakingUntil != nil:
+		return v1alpha1.RolloutGateSoaking
+	case revision.Status.Rollout != nil && revision.Status.Rollout.AwaitingApproval != "":
+		return v1alpha1.RolloutGateAwaitingApproval
+	default:
+		return v1alpha1.RolloutGateOpen
+	}
+}
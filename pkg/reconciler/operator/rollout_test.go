@@ -0,0 +1,292 @@
+package operator
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/telekom/das-schiff-network-operator/api/v1alpha1"
+)
+
+const zoneLabel = "topology.kubernetes.io/zone"
+
+var _ = Describe("Staged rollout", func() {
+	var (
+		nodes  map[string]*corev1.Node
+		policy *v1alpha1.RolloutPolicy
+	)
+
+	BeforeEach(func() {
+		nodes = map[string]*corev1.Node{
+			"canary": makeRolloutNode("canary", map[string]string{"canary": "true", zoneLabel: "a"}),
+			"a1":     makeRolloutNode("a1", map[string]string{zoneLabel: "a"}),
+			"a2":     makeRolloutNode("a2", map[string]string{zoneLabel: "a"}),
+			"b1":     makeRolloutNode("b1", map[string]string{zoneLabel: "b"}),
+			"nozone": makeRolloutNode("nozone", map[string]string{}),
+			"cp":     makeRolloutNode("cp", map[string]string{"control-plane": ""}),
+		}
+		policy = &v1alpha1.RolloutPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.DefaultRolloutPolicyName},
+			Spec: v1alpha1.RolloutPolicySpec{
+				Groups: []v1alpha1.RolloutGroup{
+					{
+						Name:         "canary",
+						NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+					},
+					{
+						Name: "workers",
+						NodeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "control-plane", Operator: metav1.LabelSelectorOpDoesNotExist},
+						}},
+						TopologyKey:    zoneLabel,
+						MaxUnavailable: ptrIntOrString(intstr.FromString("100%")),
+					},
+				},
+				SoakTime: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+		}
+	})
+
+	Describe("buildRolloutStages", func() {
+		It("should order stages by group and topology value", func() {
+			stages, err := buildRolloutStages(policy, nodes, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stageNames(stages)).To(Equal([]string{"canary", "workers/a", "workers/b", "workers", remainingStageName}))
+			Expect(nodeNames(stages[1].nodes)).To(Equal([]string{"a1", "a2"}))
+			Expect(nodeNames(stages[3].nodes)).To(Equal([]string{"nozone"}))
+			Expect(nodeNames(stages[4].nodes)).To(Equal([]string{"cp"}))
+		})
+
+		It("should scale maxUnavailable to the stage size", func() {
+			policy.Spec.Groups[1].MaxUnavailable = ptrIntOrString(intstr.FromString("50%"))
+			nodes["a3"] = makeRolloutNode("a3", map[string]string{zoneLabel: "a"})
+			nodes["a4"] = makeRolloutNode("a4", map[string]string{zoneLabel: "a"})
+
+			stages, err := buildRolloutStages(policy, nodes, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stages[0].maxUnavailable).To(Equal(1)) // default
+			Expect(stages[1].maxUnavailable).To(Equal(2)) // 50% of 4 nodes
+			Expect(stages[2].maxUnavailable).To(Equal(1)) // 50% of 1 node, at least 1
+			Expect(stages[4].maxUnavailable).To(Equal(3)) // remaining nodes use maxUpdating
+		})
+
+		It("should reject an invalid maxUnavailable", func() {
+			policy.Spec.Groups[0].MaxUnavailable = ptrIntOrString(intstr.FromString("one"))
+			_, err := buildRolloutStages(policy, nodes, 1)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("planRollout", func() {
+		var (
+			stages   []rolloutStage
+			revision v1alpha1.NetworkConfigRevision
+			now      time.Time
+		)
+
+		BeforeEach(func() {
+			var err error
+			stages, err = buildRolloutStages(policy, nodes, 1)
+			Expect(err).ToNot(HaveOccurred())
+			revision = makeRevision("rev001", false, time.Now())
+			now = time.Now()
+		})
+
+		It("should start with the first stage without soaking", func() {
+			toDeploy, status := planRollout(stages, nil, &revision, 10*time.Minute, -1, now)
+			Expect(nodeNames(toDeploy)).To(Equal([]string{"canary"}))
+			Expect(status.Stage).To(Equal("canary"))
+			Expect(status.CompletedStages).To(Equal(0))
+			Expect(status.TotalStages).To(Equal(5))
+			Expect(status.SoakingUntil).To(BeNil())
+		})
+
+		It("should not start the next stage while the current one is ongoing", func() {
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("canary", "rev001", StatusProvisioning, now),
+			}
+			toDeploy, status := planRollout(stages, configs, &revision, 10*time.Minute, -1, now)
+			Expect(toDeploy).To(BeEmpty())
+			Expect(status.Stage).To(Equal("canary"))
+		})
+
+		It("should soak before entering the next stage", func() {
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("canary", "rev001", StatusProvisioned, now),
+			}
+			revision.Status.Rollout = &v1alpha1.RevisionRolloutStatus{Stage: "canary"}
+
+			toDeploy, status := planRollout(stages, configs, &revision, 10*time.Minute, -1, now)
+			Expect(toDeploy).To(BeEmpty())
+			Expect(status.Stage).To(Equal("workers/a"))
+			Expect(status.CompletedStages).To(Equal(1))
+			Expect(status.SoakingUntil).ToNot(BeNil())
+			Expect(status.SoakingUntil.Time).To(BeTemporally("~", now.Add(10*time.Minute), time.Second))
+
+			// still soaking
+			revision.Status.Rollout = status
+			toDeploy, status = planRollout(stages, configs, &revision, 10*time.Minute, -1, now.Add(5*time.Minute))
+			Expect(toDeploy).To(BeEmpty())
+			Expect(status.SoakingUntil).ToNot(BeNil())
+
+			// soak time passed
+			revision.Status.Rollout = status
+			toDeploy, status = planRollout(stages, configs, &revision, 10*time.Minute, -1, now.Add(11*time.Minute))
+			Expect(nodeNames(toDeploy)).To(Equal([]string{"a1", "a2"}))
+			Expect(status.SoakingUntil).To(BeNil())
+		})
+
+		It("should respect maxUnavailable of the stage", func() {
+			policy.Spec.Groups[1].MaxUnavailable = ptrIntOrString(intstr.FromInt32(1))
+			stages, err := buildRolloutStages(policy, nodes, 1)
+			Expect(err).ToNot(HaveOccurred())
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("canary", "rev001", StatusProvisioned, now),
+				makeNodeConfig("a1", "rev001", StatusProvisioning, now),
+			}
+			revision.Status.Rollout = &v1alpha1.RevisionRolloutStatus{Stage: "workers/a"}
+
+			toDeploy, status := planRollout(stages, configs, &revision, 10*time.Minute, -1, now)
+			Expect(toDeploy).To(BeEmpty())
+			Expect(status.Stage).To(Equal("workers/a"))
+			Expect(status.SoakingUntil).To(BeNil())
+		})
+
+		It("should hold back a group that requires approval", func() {
+			policy.Spec.Groups[1].RequireApproval = true
+			stages, err := buildRolloutStages(policy, nodes, 1)
+			Expect(err).ToNot(HaveOccurred())
+			configs := []v1alpha1.NodeNetworkConfig{
+				makeNodeConfig("canary", "rev001", StatusProvisioned, now),
+			}
+
+			toDeploy, status := planRollout(stages, configs, &revision, 0, -1, now)
+			Expect(toDeploy).To(BeEmpty())
+			Expect(status.Stage).To(Equal("workers/a"))
+			Expect(status.AwaitingApproval).To(Equal("workers"))
+
+			// approving an earlier group does not open the gate
+			toDeploy, status = planRollout(stages, configs, &revision, 0, 0, now)
+			Expect(toDeploy).To(BeEmpty())
+			Expect(status.AwaitingApproval).To(Equal("workers"))
+
+			toDeploy, status = planRollout(stages, configs, &revision, 0, 1, now)
+			Expect(nodeNames(toDeploy)).To(Equal([]string{"a1", "a2"}))
+			Expect(status.AwaitingApproval).To(BeEmpty())
+		})
+
+		It("should report a finished rollout", func() {
+			configs := []v1alpha1.NodeNetworkConfig{}
+			for name := range nodes {
+				configs = append(configs, makeNodeConfig(name, "rev001", StatusProvisioned, now))
+			}
+			toDeploy, status := planRollout(stages, configs, &revision, 10*time.Minute, -1, now)
+			Expect(toDeploy).To(BeEmpty())
+			Expect(status.Stage).To(BeEmpty())
+			Expect(status.CompletedStages).To(Equal(status.TotalStages))
+		})
+	})
+
+	Describe("getApprovedGroup", func() {
+		It("should return the index of the approved group", func() {
+			revision := makeRevision("rev001", false, time.Now())
+			Expect(getApprovedGroup(policy, &revision)).To(Equal(-1))
+
+			revision.Annotations = map[string]string{v1alpha1.RolloutApprovedAnnotation: "workers"}
+			Expect(getApprovedGroup(policy, &revision)).To(Equal(1))
+
+			revision.Annotations[v1alpha1.RolloutApprovedAnnotation] = "unknown"
+			Expect(getApprovedGroup(policy, &revision)).To(Equal(-1))
+		})
+	})
+
+	Describe("getRolloutGate", func() {
+		It("should be open by default", func() {
+			revision := makeRevision("rev001", false, time.Now())
+			Expect(getRolloutGate(&revision)).To(Equal(v1alpha1.RolloutGateOpen))
+		})
+
+		It("should report a paused rollout first", func() {
+			revision := makeRevision("rev001", false, time.Now())
+			revision.Annotations = map[string]string{v1alpha1.RolloutPausedAnnotation: "true"}
+			revision.Status.Rollout = &v1alpha1.RevisionRolloutStatus{AwaitingApproval: "workers"}
+			Expect(getRolloutGate(&revision)).To(Equal(v1alpha1.RolloutGatePaused))
+		})
+
+		It("should report soaking and pending approval", func() {
+			revision := makeRevision("rev001", false, time.Now())
+			until := metav1.NewTime(time.Now().Add(time.Minute))
+			revision.Status.Rollout = &v1alpha1.RevisionRolloutStatus{SoakingUntil: &until}
+			Expect(getRolloutGate(&revision)).To(Equal(v1alpha1.RolloutGateSoaking))
+
+			revision.Status.Rollout = &v1alpha1.RevisionRolloutStatus{AwaitingApproval: "workers"}
+			Expect(getRolloutGate(&revision)).To(Equal(v1alpha1.RolloutGateAwaitingApproval))
+		})
+	})
+
+	Describe("getNodesToDeploy", func() {
+		It("should return all outdated nodes without a RolloutPolicy", func() {
+			revision := makeRevision("rev001", false, time.Now())
+			revision.Status.Rollout = &v1alpha1.RevisionRolloutStatus{Stage: "canary"}
+			crr := &ConfigRevisionReconciler{
+				logger: ctrl.Log.WithName("test"),
+				client: fake.NewClientBuilder().WithScheme(testScheme).Build(),
+			}
+			outdated := []*corev1.Node{nodes["a1"], nodes["b1"]}
+
+			toDeploy, err := crr.getNodesToDeploy(context.Background(), nodes, outdated, nil, &revision)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(toDeploy).To(Equal(outdated))
+			Expect(revision.Status.Rollout).To(BeNil())
+		})
+
+		It("should limit the nodes to the current stage", func() {
+			revision := makeRevision("rev001", false, time.Now())
+			crr := &ConfigRevisionReconciler{
+				logger:      ctrl.Log.WithName("test"),
+				client:      fake.NewClientBuilder().WithScheme(testScheme).WithObjects(policy).Build(),
+				maxUpdating: 1,
+			}
+			outdated := []*corev1.Node{nodes["a1"], nodes["canary"]}
+
+			toDeploy, err := crr.getNodesToDeploy(context.Background(), nodes, outdated, nil, &revision)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodeNames(toDeploy)).To(Equal([]string{"canary"}))
+			Expect(revision.Status.Rollout).ToNot(BeNil())
+			Expect(revision.Status.Rollout.Stage).To(Equal("canary"))
+		})
+	})
+})
+
+func makeRolloutNode(name string, labels map[string]string) *corev1.Node {
+	node := makeNode(name, true)
+	node.Labels = labels
+	return node
+}
+
+func ptrIntOrString(v intstr.IntOrString) *intstr.IntOrString {
+	return &v
+}
+
+func stageNames(stages []rolloutStage) []string {
+	names := make([]string, 0, len(stages))
+	for i := range stages {
+		names = append(names, stages[i].name)
+	}
+	return names
+}
+
+func nodeNames(nodes []*corev1.Node) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}